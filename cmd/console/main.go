@@ -13,6 +13,7 @@ import (
 	// us
 	"github.com/openshift/console-operator/pkg/cmd/crdconversionwebhook"
 	"github.com/openshift/console-operator/pkg/cmd/operator"
+	"github.com/openshift/console-operator/pkg/cmd/render"
 	"github.com/openshift/console-operator/pkg/cmd/version"
 )
 
@@ -40,6 +41,7 @@ func NewOperatorCommand() *cobra.Command {
 	cmd.AddCommand(operator.NewOperator())
 	cmd.AddCommand(version.NewVersion())
 	cmd.AddCommand(crdconversionwebhook.NewConverter())
+	cmd.AddCommand(render.NewRender())
 
 	return cmd
 }
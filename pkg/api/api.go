@@ -5,10 +5,16 @@ const (
 	AuthServerCAFileName                = "ca-bundle.crt"
 	ClusterOperatorName                 = "console"
 	ConfigResourceName                  = "cluster"
+	ConsoleConfigHistoryName            = "console-config-history"
+	ConsoleConfigProvenanceName         = "console-config-provenance"
 	ConsoleContainerPort                = 443
 	ConsoleContainerPortName            = "https"
 	ConsoleContainerTargetPort          = 8443
 	ConsoleServingCertName              = "console-serving-cert"
+	CustomBrandingMountDir              = "/var/branding/"
+	CustomFaviconConfigMapName          = "custom-favicon"
+	CustomLogoDarkConfigMapName         = "custom-logo-dark"
+	CustomLogoLightConfigMapName        = "custom-logo-light"
 	DefaultIngressCertConfigMapName     = "default-ingress-cert"
 	DownloadsPort                       = 8080
 	DownloadsPortName                   = "http"
@@ -19,6 +25,8 @@ const (
 	OAuthServingCertConfigMapName       = "oauth-serving-cert"
 	OCCLIDownloadsCustomResourceName    = "oc-cli-downloads"
 	ODOCLIDownloadsCustomResourceName   = "odo-cli-downloads"
+	OIDCIssuerConsoleNotification       = "oidc-issuer-unreachable"
+	OIDCIssuerFieldManager              = "console-operator-oidc-issuer"
 	OLMConfigGroup                      = "operators.coreos.com"
 	OLMConfigResource                   = "olmconfigs"
 	OLMConfigVersion                    = "v1"
@@ -46,6 +54,8 @@ const (
 	UpgradeConsoleNotification          = "cluster-upgrade"
 	V1Alpha1PluginI18nAnnotation        = "console.openshift.io/use-i18n"
 	VersionResourceName                 = "version"
+	WorkloadConfigKey                   = "config.yaml"
+	WorkloadConfigMapName               = "console-workload-config"
 
 	OAuthClientName                         = OpenShiftConsoleName
 	OpenShiftConsoleDeploymentName          = OpenShiftConsoleName
@@ -57,4 +67,62 @@ const (
 	OpenShiftConsoleRouteName               = OpenShiftConsoleName
 	OpenShiftConsoleServiceName             = OpenShiftConsoleName
 	RedirectContainerTargetPort             = RedirectContainerPort
+
+	// annotations of the operator config which manage the optional components
+	// independently while the console itself is Managed
+	CLIDownloadsManagementStateAnnotation           = "console.operator.openshift.io/cli-downloads-management-state"
+	DownloadsManagementStateAnnotation              = "console.operator.openshift.io/downloads-management-state"
+	OIDCIssuerNotificationManagementStateAnnotation = "console.operator.openshift.io/oidc-issuer-notification-management-state"
+	UpgradeNotificationManagementStateAnnotation    = "console.operator.openshift.io/upgrade-notification-management-state"
+
+	// annotation of the operator config holding how long changes to the inputs of
+	// the console are coalesced before they roll it out, e.g. "2m"
+	RolloutSettleWindowAnnotation = "console.operator.openshift.io/rollout-settle-window"
+
+	// annotation of the operator config listing further hostnames the console is
+	// reached at, e.g. through an external load balancer, as a comma separated
+	// list; logins are redirected back to them as well as to the console routes
+	HostnameAliasesAnnotation = "console.operator.openshift.io/hostname-aliases"
+
+	// annotations of the operator config declaring the token policy of the console
+	// OAuthClient: the max age and the inactivity timeout of its access tokens,
+	// e.g. "24h" and "15m", and its scope restrictions as a JSON list of
+	// oauth.openshift.io/v1 ScopeRestrictions. The fields never declared are left as
+	// they are set on the OAuthClient, those no longer declared are unset.
+	OAuthAccessTokenInactivityTimeoutAnnotation = "console.operator.openshift.io/oauth-access-token-inactivity-timeout"
+	OAuthAccessTokenMaxAgeAnnotation            = "console.operator.openshift.io/oauth-access-token-max-age"
+	OAuthScopeRestrictionsAnnotation            = "console.operator.openshift.io/oauth-scope-restrictions"
+	// annotation of the console OAuthClient listing the fields of its token policy
+	// the operator set, e.g. "accessTokenMaxAgeSeconds,scopeRestrictions"
+	OAuthClientTokenPolicyFieldsAnnotation = "console.operator.openshift.io/token-policy-fields"
+
+	// annotations of the operator config rotating the client secret of the
+	// integrated OAuth server: the interval of the scheduled rotations, e.g.
+	// "720h", and a token whose every new value rotates the secret on demand
+	OAuthClientSecretRotationIntervalAnnotation = "console.operator.openshift.io/oauth-client-secret-rotation-interval"
+	RotateOAuthClientSecretAnnotation           = "console.operator.openshift.io/rotate-oauth-client-secret"
+
+	// annotations of the operator config rotating the session secret of the
+	// console authenticating with OIDC, as above. Rotations only happen once the
+	// previous keys annotation is "true", i.e. the console image in use reads the
+	// previousCookie*KeyFile session config, otherwise they drop every session.
+	RotateSessionSecretAnnotation           = "console.operator.openshift.io/rotate-session-secret"
+	SessionSecretPreviousKeysAnnotation     = "console.operator.openshift.io/session-secret-previous-keys"
+	SessionSecretRotationIntervalAnnotation = "console.operator.openshift.io/session-secret-rotation-interval"
+
+	// annotation of the operator config declaring, once "true", that the console
+	// image in use reads the minTLSVersion and cipherSuites serving config, which
+	// then follow the TLS security profile of the cluster
+	ServingTLSProfileAnnotation = "console.operator.openshift.io/serving-tls-profile"
+
+	// annotations of the secrets the operator rotates recording when they were
+	// last generated and the last on-demand rotation they handled
+	SecretRotatedAtAnnotation       = "console.operator.openshift.io/rotated-at"
+	SecretRotationRequestAnnotation = "console.operator.openshift.io/rotation-request"
+
+	// annotations of the operator config referencing further branding images in
+	// openshift-config, as <configmap>/<key>
+	CustomFaviconAnnotation   = "console.operator.openshift.io/custom-favicon"
+	CustomLogoDarkAnnotation  = "console.operator.openshift.io/custom-logo-dark"
+	CustomLogoLightAnnotation = "console.operator.openshift.io/custom-logo-light"
 )
@@ -0,0 +1,259 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	// kube
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	serializerjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/klog/v2"
+
+	// openshift
+	configv1 "github.com/openshift/api/config/v1"
+	consolev1 "github.com/openshift/api/console/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+)
+
+var (
+	operatorConfigFile       string
+	consoleConfigFile        string
+	infrastructureConfigFile string
+	authenticationConfigFile string
+	oauthConfigFile          string
+	proxyConfigFile          string
+	ingressConfigFile        string
+	apiServerConfigFile      string
+	managedConfigFile        string
+	workloadConfigFile       string
+	monitoringConfigFile     string
+	olmConfigFile            string
+	telemeterClientFile      string
+	pluginFiles              []string
+	nodeArchitectures        []string
+	nodeOperatingSystems     []string
+	releaseVersion           string
+	outputDir                string
+)
+
+func NewRender() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the resources the operator would apply for the given cluster configuration",
+		Long: `Render the console-config ConfigMap, the console and downloads Deployments, Routes, Services and ConsoleCLIDownloads
+the operator would apply, reading the cluster configuration from YAML files instead of a live cluster.`,
+		Run: func(command *cobra.Command, args []string) {
+			if err := runRender(os.Stdout); err != nil {
+				klog.Fatalf("Error rendering console resources: %v", err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&operatorConfigFile, "operator-config", "", "File containing the consoles.operator.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&consoleConfigFile, "console-config", "", "File containing the consoles.config.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&infrastructureConfigFile, "infrastructure-config", "", "File containing the infrastructures.config.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&authenticationConfigFile, "authentication-config", "", "File containing the authentications.config.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&oauthConfigFile, "oauth-config", "", "File containing the oauths.config.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&proxyConfigFile, "proxy-config", "", "File containing the proxies.config.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&ingressConfigFile, "ingress-config", "", "File containing the ingresses.config.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&apiServerConfigFile, "apiserver-config", "", "File containing the apiservers.config.openshift.io 'cluster' resource.")
+	cmd.Flags().StringVar(&managedConfigFile, "managed-config", "", "File containing the openshift-config-managed/console-config ConfigMap.")
+	cmd.Flags().StringVar(&workloadConfigFile, "workload-config", "", "File containing the openshift-config/console-workload-config ConfigMap.")
+	cmd.Flags().StringVar(&monitoringConfigFile, "monitoring-shared-config", "", "File containing the openshift-config-managed/monitoring-shared-config ConfigMap.")
+	cmd.Flags().StringVar(&olmConfigFile, "olm-config", "", "File containing the olmconfigs.operators.coreos.com 'cluster' resource.")
+	cmd.Flags().StringVar(&telemeterClientFile, "telemeter-client-deployment", "", "File containing the openshift-monitoring/telemeter-client Deployment. Telemetry is disabled without it.")
+	cmd.Flags().StringArrayVar(&pluginFiles, "plugin", nil, "File containing a ConsolePlugin resource. May be repeated.")
+	cmd.Flags().StringSliceVar(&nodeArchitectures, "node-architectures", []string{"amd64"}, "Architectures of the cluster nodes.")
+	cmd.Flags().StringSliceVar(&nodeOperatingSystems, "node-operating-systems", []string{"linux"}, "Operating systems of the cluster nodes.")
+	cmd.Flags().StringVar(&releaseVersion, "release-version", "", "Version of the cluster release, which the operator reads from its RELEASE_VERSION environment variable.")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Directory to write one file per rendered resource to. Defaults to printing a YAML stream to stdout.")
+
+	for _, required := range []string{"operator-config", "console-config", "infrastructure-config", "ingress-config"} {
+		cmd.MarkFlagRequired(required)
+	}
+
+	return cmd
+}
+
+func runRender(out io.Writer) error {
+	inputs := &Inputs{
+		OperatorConfig:       &operatorv1.Console{},
+		ConsoleConfig:        &configv1.Console{},
+		InfrastructureConfig: &configv1.Infrastructure{},
+		IngressConfig:        &configv1.Ingress{},
+		NodeArchitectures:    nodeArchitectures,
+		NodeOperatingSystems: nodeOperatingSystems,
+		ReleaseVersion:       releaseVersion,
+	}
+	if err := readInto(operatorConfigFile, inputs.OperatorConfig); err != nil {
+		return err
+	}
+	if err := readInto(consoleConfigFile, inputs.ConsoleConfig); err != nil {
+		return err
+	}
+	if err := readInto(infrastructureConfigFile, inputs.InfrastructureConfig); err != nil {
+		return err
+	}
+	if err := readInto(ingressConfigFile, inputs.IngressConfig); err != nil {
+		return err
+	}
+	if len(authenticationConfigFile) != 0 {
+		inputs.AuthenticationConfig = &configv1.Authentication{}
+		if err := readInto(authenticationConfigFile, inputs.AuthenticationConfig); err != nil {
+			return err
+		}
+	}
+	if len(oauthConfigFile) != 0 {
+		inputs.OAuthConfig = &configv1.OAuth{}
+		if err := readInto(oauthConfigFile, inputs.OAuthConfig); err != nil {
+			return err
+		}
+	}
+	if len(proxyConfigFile) != 0 {
+		inputs.ProxyConfig = &configv1.Proxy{}
+		if err := readInto(proxyConfigFile, inputs.ProxyConfig); err != nil {
+			return err
+		}
+	}
+	if len(apiServerConfigFile) != 0 {
+		inputs.APIServerConfig = &configv1.APIServer{}
+		if err := readInto(apiServerConfigFile, inputs.APIServerConfig); err != nil {
+			return err
+		}
+	}
+	if len(managedConfigFile) != 0 {
+		inputs.ManagedConfig = &corev1.ConfigMap{}
+		if err := readInto(managedConfigFile, inputs.ManagedConfig); err != nil {
+			return err
+		}
+	}
+	if len(workloadConfigFile) != 0 {
+		inputs.WorkloadConfig = &corev1.ConfigMap{}
+		if err := readInto(workloadConfigFile, inputs.WorkloadConfig); err != nil {
+			return err
+		}
+	}
+	if len(monitoringConfigFile) != 0 {
+		inputs.MonitoringSharedConfig = &corev1.ConfigMap{}
+		if err := readInto(monitoringConfigFile, inputs.MonitoringSharedConfig); err != nil {
+			return err
+		}
+	}
+	if len(olmConfigFile) != 0 {
+		olmConfig, err := readUnstructured(olmConfigFile, "OLMConfig")
+		if err != nil {
+			return err
+		}
+		inputs.OLMConfig = olmConfig
+	}
+	if len(telemeterClientFile) != 0 {
+		inputs.TelemeterClientDeployment = &appsv1.Deployment{}
+		if err := readInto(telemeterClientFile, inputs.TelemeterClientDeployment); err != nil {
+			return err
+		}
+	}
+	for _, pluginFile := range pluginFiles {
+		plugin := &consolev1.ConsolePlugin{}
+		if err := readInto(pluginFile, plugin); err != nil {
+			return err
+		}
+		inputs.Plugins = append(inputs.Plugins, plugin)
+	}
+
+	objects, err := Render(inputs)
+	if err != nil {
+		return err
+	}
+	if len(outputDir) != 0 {
+		return writeFiles(outputDir, objects)
+	}
+	return writeStream(out, objects)
+}
+
+// readInto decodes the single resource in the given file into obj,
+// failing if the file holds a different kind of resource.
+func readInto(file string, obj runtime.Object) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	decoded, gvk, err := codecs.UniversalDeserializer().Decode(data, nil, obj)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	if decoded != obj {
+		return fmt.Errorf("unexpected %s in %s", gvk.Kind, file)
+	}
+	return nil
+}
+
+// readUnstructured decodes the single resource of the given kind in the file,
+// for the kinds of the optional components the scheme does not know.
+func readUnstructured(file, kind string) (*unstructured.Unstructured, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	obj := &unstructured.Unstructured{}
+	if _, _, err := unstructured.UnstructuredJSONScheme.Decode(data, nil, obj); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	if obj.GetKind() != kind {
+		return nil, fmt.Errorf("unexpected %s in %s", obj.GetKind(), file)
+	}
+	return obj, nil
+}
+
+func writeStream(out io.Writer, objects []runtime.Object) error {
+	yamlSerializer := serializerjson.NewYAMLSerializer(serializerjson.DefaultMetaFactory, scheme, scheme)
+	for i, obj := range objects {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out, "---"); err != nil {
+				return err
+			}
+		}
+		if err := yamlSerializer.Encode(obj, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFiles(dir string, objects []runtime.Object) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	yamlSerializer := serializerjson.NewYAMLSerializer(serializerjson.DefaultMetaFactory, scheme, scheme)
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		buf := &bytes.Buffer{}
+		if err := yamlSerializer.Encode(obj, buf); err != nil {
+			return err
+		}
+		kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		fileName := filepath.Join(dir, fmt.Sprintf("%s-%s.yaml", kind, accessor.GetName()))
+		if err := os.WriteFile(fileName, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
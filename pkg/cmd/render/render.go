@@ -0,0 +1,310 @@
+package render
+
+import (
+	"fmt"
+
+	// kube
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	// openshift
+	configv1 "github.com/openshift/api/config/v1"
+	consolev1 "github.com/openshift/api/console/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
+
+	// operator
+	"github.com/openshift/console-operator/bindata"
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/controllers/clidownloads"
+	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	configmapsub "github.com/openshift/console-operator/pkg/console/subresource/configmap"
+	"github.com/openshift/console-operator/pkg/console/subresource/consoleserver"
+	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
+	oauthsub "github.com/openshift/console-operator/pkg/console/subresource/oauthclient"
+	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
+	secretsub "github.com/openshift/console-operator/pkg/console/subresource/secret"
+	utilsub "github.com/openshift/console-operator/pkg/console/subresource/util"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(configv1.Install(scheme))
+	utilruntime.Must(consolev1.Install(scheme))
+	utilruntime.Must(operatorv1.Install(scheme))
+	utilruntime.Must(routev1.Install(scheme))
+}
+
+// Inputs holds the cluster state the console operator would normally read
+// from its informers during a sync.
+type Inputs struct {
+	OperatorConfig       *operatorv1.Console
+	ConsoleConfig        *configv1.Console
+	InfrastructureConfig *configv1.Infrastructure
+	AuthenticationConfig *configv1.Authentication
+	OAuthConfig          *configv1.OAuth
+	ProxyConfig          *configv1.Proxy
+	IngressConfig        *configv1.Ingress
+	APIServerConfig      *configv1.APIServer
+	ManagedConfig        *corev1.ConfigMap
+	WorkloadConfig       *corev1.ConfigMap
+	Plugins              []*consolev1.ConsolePlugin
+	NodeArchitectures    []string
+	NodeOperatingSystems []string
+	// the version of the release, the operator reads it from its RELEASE_VERSION
+	// environment variable
+	ReleaseVersion string
+	// the optional components the console integrates with, left out when they
+	// are not installed
+	MonitoringSharedConfig    *corev1.ConfigMap
+	OLMConfig                 *unstructured.Unstructured
+	TelemeterClientDeployment *appsv1.Deployment
+}
+
+// Render returns the objects the console operator would apply for the given
+// inputs, without talking to a cluster. Objects which are only copied or
+// injected by other components (service CA bundle, oauth-serving-cert, custom
+// logo and branding images) are assumed to exist and are passed to the generators as stubs.
+// The optional components are left out unless their management state is Managed,
+// the ConsoleNotifications are left out whatever their management state since
+// they follow the live state of the cluster.
+func Render(in *Inputs) ([]runtime.Object, error) {
+	if err := in.complete(); err != nil {
+		return nil, err
+	}
+
+	var objects []runtime.Object
+
+	switch managementState := in.OperatorConfig.Spec.ManagementState; managementState {
+	case operatorv1.Managed:
+	case operatorv1.Unmanaged, operatorv1.Removed:
+		// the operator leaves the console alone or takes it down, it applies nothing
+		return objects, nil
+	default:
+		return nil, fmt.Errorf("console is in an unknown state: %v", managementState)
+	}
+	downloadsManaged := util.GetManagementState(in.OperatorConfig, api.DownloadsManagementStateAnnotation) == operatorv1.Managed
+
+	// routes
+	consoleRouteConfig := routesub.NewRouteConfig(in.OperatorConfig, in.IngressConfig, api.OpenShiftConsoleRouteName)
+	consoleRoute := consoleRouteConfig.DefaultRoute(nil, in.IngressConfig)
+	activeConsoleRoute := consoleRoute
+	objects = append(objects, consoleRoute)
+	if consoleRouteConfig.IsCustomHostnameSet() {
+		activeConsoleRoute = consoleRouteConfig.CustomRoute(nil, api.OpenShiftConsoleRouteName)
+		objects = append(objects, activeConsoleRoute)
+	}
+	downloadsRouteConfig := routesub.NewRouteConfig(in.OperatorConfig, in.IngressConfig, api.OpenShiftConsoleDownloadsRouteName)
+	downloadsRoute := downloadsRouteConfig.DefaultRoute(nil, in.IngressConfig)
+	activeDownloadsRoute := downloadsRoute
+	if downloadsRouteConfig.IsCustomHostnameSet() {
+		activeDownloadsRoute = downloadsRouteConfig.CustomRoute(nil, api.OpenShiftConsoleDownloadsRouteName)
+	}
+	if downloadsManaged {
+		objects = append(objects, downloadsRoute)
+		if downloadsRouteConfig.IsCustomHostnameSet() {
+			objects = append(objects, activeDownloadsRoute)
+		}
+	}
+
+	// services
+	objects = append(objects, readService(api.OpenShiftConsoleServiceName))
+	if consoleRouteConfig.IsCustomHostnameSet() {
+		objects = append(objects, readService(api.OpenshiftConsoleRedirectServiceName))
+	}
+	if downloadsManaged {
+		objects = append(objects, readService(api.DownloadsResourceName))
+	}
+
+	// console-config
+	var authServerCAConfig *corev1.ConfigMap
+	var sessionSecret *corev1.Secret
+	var oauthServingCertConfigMap *corev1.ConfigMap
+	inactivityTimeoutSeconds := 0
+	switch in.AuthenticationConfig.Spec.Type {
+	case configv1.AuthenticationTypeOIDC:
+		oidcProvider, _, err := utilsub.GetOIDCClientConfig(in.AuthenticationConfig)
+		if err != nil {
+			return nil, err
+		}
+		if oidcProvider != nil && len(oidcProvider.Issuer.CertificateAuthority.Name) > 0 {
+			authServerCAConfig = configMapStub(oidcProvider.Issuer.CertificateAuthority.Name)
+		}
+		// the session secret holds random keys, only its reference ends up in the deployment
+		sessionSecret = &corev1.Secret{}
+		sessionSecret.Name = api.SessionSecretName
+		sessionSecret.Namespace = api.OpenShiftConsoleNamespace
+	case "", configv1.AuthenticationTypeIntegratedOAuth:
+		oauthServingCertConfigMap = configMapStub(api.OAuthServingCertConfigMapName)
+		tokenPolicy, err := oauthsub.GetTokenPolicy(in.OperatorConfig)
+		if err != nil {
+			return nil, err
+		}
+		if tokenPolicy.AccessTokenInactivityTimeoutSeconds != nil {
+			inactivityTimeoutSeconds = int(*tokenPolicy.AccessTokenInactivityTimeoutSeconds)
+		} else if in.OAuthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout != nil {
+			inactivityTimeoutSeconds = int(in.OAuthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout.Seconds())
+		}
+	}
+
+	// the copies of the branding images the operator would make
+	customBrandingConfigMaps := []*corev1.ConfigMap{}
+	for _, file := range configmapsub.CustomBrandingFiles {
+		reference, err := file.Reference(in.OperatorConfig)
+		if err != nil {
+			return nil, err
+		}
+		if len(reference.Name) > 0 {
+			customBrandingConfigMaps = append(customBrandingConfigMaps, configMapStub(file.ConfigMapName))
+		}
+	}
+
+	copiedCSVsDisabled, err := configmapsub.CopiedCSVsDisabled(in.OLMConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid olmconfig: %w", err)
+	}
+	telemeterClientIsAvailable := in.TelemeterClientDeployment != nil && deploymentsub.IsAvailable(in.TelemeterClientDeployment)
+
+	consoleConfigMap, provenance, unsupportedOverridesHaveMerged, err := configmapsub.DefaultConfigMap(
+		in.OperatorConfig,
+		in.ConsoleConfig,
+		in.AuthenticationConfig,
+		authServerCAConfig,
+		in.ManagedConfig,
+		in.MonitoringSharedConfig,
+		in.InfrastructureConfig,
+		in.APIServerConfig,
+		activeConsoleRoute,
+		inactivityTimeoutSeconds,
+		in.Plugins,
+		in.NodeArchitectures,
+		in.NodeOperatingSystems,
+		copiedCSVsDisabled,
+		telemeterClientIsAvailable,
+		customBrandingConfigMaps,
+		in.ReleaseVersion,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render console-config: %w", err)
+	}
+	if unsupportedOverridesHaveMerged {
+		if err := consoleserver.ValidateConfig([]byte(consoleConfigMap.Data[configmapsub.ConsoleConfigYamlFile])); err != nil {
+			return nil, fmt.Errorf("unsupportedConfigOverrides produce an invalid console config: %w", err)
+		}
+	}
+	provenanceConfigMap, err := configmapsub.DefaultProvenanceConfigMap(in.OperatorConfig, provenance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render console-config provenance: %w", err)
+	}
+	objects = append(objects, consoleConfigMap, provenanceConfigMap)
+
+	// deployments
+	workloadConfig, err := workload.ParseConfig(in.WorkloadConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload config: %w", err)
+	}
+	canMountCustomLogo := !configmapsub.FileNameNotSet(in.OperatorConfig) && !configmapsub.FileNameOrKeyInconsistentlySet(in.OperatorConfig)
+	var customLogoConfigMap *corev1.ConfigMap
+	if canMountCustomLogo {
+		customLogoConfigMap = configMapStub(api.OpenShiftCustomLogoConfigMapName)
+	}
+	consoleDeployment := deploymentsub.DefaultDeployment(
+		in.OperatorConfig,
+		consoleConfigMap,
+		configmapsub.DefaultServiceCAConfigMap(in.OperatorConfig),
+		oauthServingCertConfigMap,
+		authServerCAConfig,
+		configmapsub.DefaultTrustedCAConfigMap(in.OperatorConfig),
+		secretsub.Stub(),
+		sessionSecret,
+		customLogoConfigMap,
+		customBrandingConfigMaps,
+		in.ProxyConfig,
+		in.InfrastructureConfig,
+		canMountCustomLogo,
+		workloadConfig.Console,
+		workloadConfig.Autoscaling,
+	)
+	objects = append(objects, consoleDeployment)
+	if workloadConfig.Autoscaling != nil {
+		objects = append(objects, deploymentsub.DefaultHorizontalPodAutoscaler(in.OperatorConfig, workloadConfig.Autoscaling))
+	}
+	if downloadsManaged {
+		objects = append(objects, deploymentsub.DefaultDownloadsDeployment(in.OperatorConfig, in.InfrastructureConfig, workloadConfig.Downloads))
+	}
+
+	// CLI downloads, linking to the downloads route
+	if clidownloads.GetManagementState(in.OperatorConfig) == operatorv1.Managed {
+		objects = append(objects,
+			clidownloads.PlatformBasedOCConsoleCLIDownloads(activeDownloadsRoute.Spec.Host, api.OCCLIDownloadsCustomResourceName),
+			clidownloads.ODOConsoleCLIDownloads(),
+		)
+	}
+
+	for _, obj := range objects {
+		if err := setGroupVersionKind(obj); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// complete checks the required inputs are present and defaults the optional ones
+func (in *Inputs) complete() error {
+	switch {
+	case in.OperatorConfig == nil:
+		return fmt.Errorf("operator config is required")
+	case in.ConsoleConfig == nil:
+		return fmt.Errorf("console config is required")
+	case in.InfrastructureConfig == nil:
+		return fmt.Errorf("infrastructure config is required")
+	case in.IngressConfig == nil:
+		return fmt.Errorf("ingress config is required")
+	}
+	if in.AuthenticationConfig == nil {
+		in.AuthenticationConfig = &configv1.Authentication{}
+	}
+	if in.OAuthConfig == nil {
+		in.OAuthConfig = &configv1.OAuth{}
+	}
+	if in.ProxyConfig == nil {
+		in.ProxyConfig = &configv1.Proxy{}
+	}
+	if in.ManagedConfig == nil {
+		in.ManagedConfig = &corev1.ConfigMap{}
+	}
+	if in.MonitoringSharedConfig == nil {
+		in.MonitoringSharedConfig = &corev1.ConfigMap{}
+	}
+	return nil
+}
+
+func readService(name string) *corev1.Service {
+	return resourceread.ReadServiceV1OrDie(bindata.MustAsset(fmt.Sprintf("assets/services/%s-service.yaml", name)))
+}
+
+func configMapStub(name string) *corev1.ConfigMap {
+	configMap := &corev1.ConfigMap{}
+	configMap.Name = name
+	configMap.Namespace = api.OpenShiftConsoleNamespace
+	return configMap
+}
+
+// setGroupVersionKind fills in the TypeMeta, which the generators leave empty,
+// so the rendered manifests can be applied as they are.
+func setGroupVersionKind(obj runtime.Object) error {
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	return nil
+}
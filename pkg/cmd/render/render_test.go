@@ -0,0 +1,323 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	// kube
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	// openshift
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+
+	// operator
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+func testInputs() *Inputs {
+	return &Inputs{
+		OperatorConfig: &operatorv1.Console{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec: operatorv1.ConsoleSpec{
+				OperatorSpec: operatorv1.OperatorSpec{ManagementState: operatorv1.Managed},
+			},
+		},
+		ConsoleConfig: &configv1.Console{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		InfrastructureConfig: &configv1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Status: configv1.InfrastructureStatus{
+				APIServerURL:           "https://api.example.com:6443",
+				ControlPlaneTopology:   configv1.HighlyAvailableTopologyMode,
+				InfrastructureTopology: configv1.HighlyAvailableTopologyMode,
+			},
+		},
+		IngressConfig: &configv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec:       configv1.IngressSpec{Domain: "apps.example.com"},
+		},
+		NodeArchitectures:    []string{"amd64"},
+		NodeOperatingSystems: []string{"linux"},
+	}
+}
+
+func renderedNames(objects []runtime.Object) []string {
+	names := []string{}
+	for _, obj := range objects {
+		accessor, _ := meta.Accessor(obj)
+		names = append(names, obj.GetObjectKind().GroupVersionKind().Kind+"/"+accessor.GetName())
+	}
+	return names
+}
+
+func TestRender(t *testing.T) {
+	customHostnameInputs := testInputs()
+	customHostnameInputs.IngressConfig.Spec.ComponentRoutes = []configv1.ComponentRouteSpec{{
+		Name:      "console",
+		Namespace: "openshift-console",
+		Hostname:  "console.example.com",
+	}}
+
+	invalidOverridesInputs := testInputs()
+	invalidOverridesInputs.OperatorConfig.Spec.UnsupportedConfigOverrides.Raw = []byte(`{"customization":{"brand":"ocp"}}`)
+
+	tests := []struct {
+		name        string
+		inputs      *Inputs
+		want        []string
+		wantHost    string
+		wantErr     bool
+		wantReplica int32
+	}{
+		{
+			name:   "Test default cluster configuration",
+			inputs: testInputs(),
+			want: []string{
+				"Route/console",
+				"Route/downloads",
+				"Service/console",
+				"Service/downloads",
+				"ConfigMap/console-config",
+				"ConfigMap/console-config-provenance",
+				"Deployment/console",
+				"Deployment/downloads",
+				"ConsoleCLIDownload/oc-cli-downloads",
+				"ConsoleCLIDownload/odo-cli-downloads",
+			},
+			wantHost:    "console-openshift-console.apps.example.com",
+			wantReplica: 2,
+		},
+		{
+			name:   "Test custom console hostname",
+			inputs: customHostnameInputs,
+			want: []string{
+				"Route/console",
+				"Route/console-custom",
+				"Route/downloads",
+				"Service/console",
+				"Service/console-redirect",
+				"Service/downloads",
+				"ConfigMap/console-config",
+				"ConfigMap/console-config-provenance",
+				"Deployment/console",
+				"Deployment/downloads",
+				"ConsoleCLIDownload/oc-cli-downloads",
+				"ConsoleCLIDownload/odo-cli-downloads",
+			},
+			wantHost:    "console.example.com",
+			wantReplica: 2,
+		},
+		{
+			name:    "Test invalid unsupportedConfigOverrides",
+			inputs:  invalidOverridesInputs,
+			wantErr: true,
+		},
+		{
+			name:    "Test missing operator config",
+			inputs:  &Inputs{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := Render(tt.inputs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := deep.Equal(renderedNames(objects), tt.want); diff != nil {
+				t.Error(diff)
+			}
+			for _, obj := range objects {
+				switch o := obj.(type) {
+				case *corev1.ConfigMap:
+					if o.Name != "console-config" {
+						continue
+					}
+					if !strings.Contains(o.Data["console-config.yaml"], "consoleBaseAddress: https://"+tt.wantHost) {
+						t.Errorf("console-config does not point at %q:\n%s", tt.wantHost, o.Data["console-config.yaml"])
+					}
+				case *appsv1.Deployment:
+					if *o.Spec.Replicas != tt.wantReplica {
+						t.Errorf("deployment %q has %d replicas, want %d", o.Name, *o.Spec.Replicas, tt.wantReplica)
+					}
+				case *routev1.Route:
+					if len(o.Spec.Host) == 0 {
+						t.Errorf("route %q has no host", o.Name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRenderOptionalComponents(t *testing.T) {
+	optionalComponentsInputs := testInputs()
+	optionalComponentsInputs.MonitoringSharedConfig = &corev1.ConfigMap{
+		Data: map[string]string{"alertmanagerUserWorkloadHost": "alertmanager-user-workload.openshift-user-workload-monitoring.svc:9094"},
+	}
+	optionalComponentsInputs.OLMConfig = &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "operators.coreos.com/v1",
+		"kind":       "OLMConfig",
+		"spec":       map[string]interface{}{"features": map[string]interface{}{"disableCopiedCSVs": true}},
+	}}
+	optionalComponentsInputs.TelemeterClientDeployment = &appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{AvailableReplicas: 1},
+	}
+
+	tests := []struct {
+		name          string
+		inputs        *Inputs
+		wantConfig    []string
+		notWantConfig []string
+	}{
+		{
+			name:          "Test optional components not installed",
+			inputs:        testInputs(),
+			wantConfig:    []string{"TELEMETER_CLIENT_DISABLED"},
+			notWantConfig: []string{"copiedCSVsDisabled", "monitoringInfo"},
+		},
+		{
+			name:   "Test optional components installed",
+			inputs: optionalComponentsInputs,
+			wantConfig: []string{
+				"copiedCSVsDisabled: true",
+				"alertmanagerUserWorkloadHost: alertmanager-user-workload.openshift-user-workload-monitoring.svc:9094",
+			},
+			notWantConfig: []string{"TELEMETER_CLIENT_DISABLED"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := Render(tt.inputs)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, obj := range objects {
+				configMap, ok := obj.(*corev1.ConfigMap)
+				if !ok || configMap.Name != "console-config" {
+					continue
+				}
+				config := configMap.Data["console-config.yaml"]
+				for _, want := range tt.wantConfig {
+					if !strings.Contains(config, want) {
+						t.Errorf("console-config does not contain %q:\n%s", want, config)
+					}
+				}
+				for _, notWant := range tt.notWantConfig {
+					if strings.Contains(config, notWant) {
+						t.Errorf("console-config contains %q:\n%s", notWant, config)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRenderManagementStates(t *testing.T) {
+	withAnnotations := func(annotations map[string]string) *Inputs {
+		inputs := testInputs()
+		inputs.OperatorConfig.Annotations = annotations
+		return inputs
+	}
+	withManagementState := func(managementState operatorv1.ManagementState) *Inputs {
+		inputs := testInputs()
+		inputs.OperatorConfig.Spec.ManagementState = managementState
+		return inputs
+	}
+	console := []string{
+		"Route/console",
+		"Service/console",
+		"ConfigMap/console-config",
+		"ConfigMap/console-config-provenance",
+		"Deployment/console",
+	}
+
+	tests := []struct {
+		name    string
+		inputs  *Inputs
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "Test removed downloads",
+			inputs: withAnnotations(map[string]string{api.DownloadsManagementStateAnnotation: string(operatorv1.Removed)}),
+			want:   console,
+		},
+		{
+			name:   "Test unmanaged downloads",
+			inputs: withAnnotations(map[string]string{api.DownloadsManagementStateAnnotation: string(operatorv1.Unmanaged)}),
+			want:   append(console, "ConsoleCLIDownload/oc-cli-downloads", "ConsoleCLIDownload/odo-cli-downloads"),
+		},
+		{
+			name:   "Test removed CLI downloads",
+			inputs: withAnnotations(map[string]string{api.CLIDownloadsManagementStateAnnotation: string(operatorv1.Removed)}),
+			want: []string{
+				"Route/console",
+				"Route/downloads",
+				"Service/console",
+				"Service/downloads",
+				"ConfigMap/console-config",
+				"ConfigMap/console-config-provenance",
+				"Deployment/console",
+				"Deployment/downloads",
+			},
+		},
+		{
+			name:   "Test removed console",
+			inputs: withManagementState(operatorv1.Removed),
+			want:   []string{},
+		},
+		{
+			name:    "Test unknown console management state",
+			inputs:  withManagementState(""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := Render(tt.inputs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := deep.Equal(renderedNames(objects), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestRenderReleaseVersion(t *testing.T) {
+	inputs := testInputs()
+	inputs.ReleaseVersion = "4.18.0"
+	objects, err := Render(inputs)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, obj := range objects {
+		configMap, ok := obj.(*corev1.ConfigMap)
+		if !ok || configMap.Name != "console-config" {
+			continue
+		}
+		// the cluster info and the documentation links follow the release
+		config := configMap.Data["console-config.yaml"]
+		for _, want := range []string{"releaseVersion: 4.18.0", "/4.18/"} {
+			if !strings.Contains(config, want) {
+				t.Errorf("console-config does not contain %q:\n%s", want, config)
+			}
+		}
+	}
+}
@@ -17,7 +17,9 @@ import (
 	// openshift
 	v1 "github.com/openshift/api/console/v1"
 	operatorsv1 "github.com/openshift/api/operator/v1"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
+	routev1listers "github.com/openshift/client-go/route/listers/route/v1"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
 	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
@@ -25,7 +27,6 @@ import (
 	"github.com/openshift/library-go/pkg/route/routeapihelpers"
 
 	// informers
-	configclientv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	configinformer "github.com/openshift/client-go/config/informers/externalversions"
 	consoleinformersv1 "github.com/openshift/client-go/console/informers/externalversions/console/v1"
 	operatorinformersv1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
@@ -33,11 +34,11 @@ import (
 
 	// clients
 	consoleclientv1 "github.com/openshift/client-go/console/clientset/versioned/typed/console/v1"
-	routeclientv1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
 
 	// operator
 	"github.com/openshift/console-operator/pkg/api"
 	controllersutil "github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
 	"github.com/openshift/console-operator/pkg/console/subresource/util"
@@ -47,18 +48,15 @@ type CLIDownloadsSyncController struct {
 	// clients
 	operatorClient            v1helpers.OperatorClient
 	consoleCliDownloadsClient consoleclientv1.ConsoleCLIDownloadInterface
-	ingressClient             configclientv1.IngressInterface
-	routeClient               routeclientv1.RoutesGetter
+	ingressConfigLister       configlistersv1.IngressLister
+	routeLister               routev1listers.RouteLister
 	operatorConfigLister      operatorv1listers.ConsoleLister
 }
 
 func NewCLIDownloadsSyncController(
-	// top level config
-	configClient configclientv1.ConfigV1Interface,
 	// clients
 	operatorClient v1helpers.OperatorClient,
 	cliDownloadsInterface consoleclientv1.ConsoleCLIDownloadInterface,
-	routeClient routeclientv1.RoutesGetter,
 	// informers
 	operatorConfigInformer operatorinformersv1.ConsoleInformer,
 	configInformer configinformer.SharedInformerFactory,
@@ -67,18 +65,17 @@ func NewCLIDownloadsSyncController(
 	// events
 	recorder events.Recorder,
 ) factory.Controller {
+	configV1Informers := configInformer.Config().V1()
 
 	ctrl := &CLIDownloadsSyncController{
 		// clients
 		operatorClient:            operatorClient,
 		consoleCliDownloadsClient: cliDownloadsInterface,
-		ingressClient:             configClient.Ingresses(),
-		routeClient:               routeClient,
+		ingressConfigLister:       configV1Informers.Ingresses().Lister(),
+		routeLister:               routeInformer.Lister(),
 		operatorConfigLister:      operatorConfigInformer.Lister(),
 	}
 
-	configV1Informers := configInformer.Config().V1()
-
 	return factory.New().
 		WithFilteredEventsInformers( // configs
 			controllersutil.IncludeNamesFilter(api.ConfigResourceName),
@@ -89,7 +86,7 @@ func NewCLIDownloadsSyncController(
 		routeInformer.Informer(),
 	).WithInformers(
 		consoleCLIDownloadsInformers.Informer(),
-	).ResyncEvery(time.Minute).WithSync(ctrl.Sync).
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync("console-cli-downloads-controller", ctrl.Sync)).
 		ToController("ConsoleCLIDownloadsController", recorder.WithComponentSuffix("console-cli-downloads-controller"))
 }
 
@@ -100,21 +97,24 @@ func (c *CLIDownloadsSyncController) Sync(ctx context.Context, controllerContext
 	}
 	updatedOperatorConfig := operatorConfig.DeepCopy()
 
-	switch updatedOperatorConfig.Spec.ManagementState {
+	statusHandler := status.NewStatusHandler(c.operatorClient)
+
+	switch managementState := GetManagementState(updatedOperatorConfig); managementState {
 	case operatorsv1.Managed:
-		klog.V(4).Infoln("console is in a managed state: syncing ConsoleCliDownloads custom resources")
+		klog.V(4).Infoln("CLI downloads are in a managed state: syncing ConsoleCliDownloads custom resources")
 	case operatorsv1.Unmanaged:
-		klog.V(4).Infoln("console is in an unmanaged state: skipping ConsoleCliDownloads custom resources sync")
+		klog.V(4).Infoln("CLI downloads are in an unmanaged state: skipping ConsoleCliDownloads custom resources sync")
 		return nil
 	case operatorsv1.Removed:
-		klog.V(4).Infoln("console is in a removed state: deleting ConsoleCliDownloads custom resources")
-		return c.removeCLIDownloads(ctx)
+		klog.V(4).Infoln("CLI downloads are in a removed state: deleting ConsoleCliDownloads custom resources")
+		err := c.removeCLIDownloads(ctx)
+		statusHandler.AddCondition(status.HandleDegraded("OCDownloadsSync", "FailedDelete", err))
+		statusHandler.AddCondition(status.HandleDegraded("ODODownloadsSync", "FailedDelete", err))
+		return statusHandler.FlushAndReturn(err)
 	default:
-		return fmt.Errorf("console is in an unknown state: %v", updatedOperatorConfig.Spec.ManagementState)
+		return fmt.Errorf("CLI downloads are in an unknown state: %v", managementState)
 	}
-
-	statusHandler := status.NewStatusHandler(c.operatorClient)
-	ingressConfig, err := c.ingressClient.Get(ctx, api.ConfigResourceName, metav1.GetOptions{})
+	ingressConfig, err := c.ingressConfigLister.Get(api.ConfigResourceName)
 	if err != nil {
 		return statusHandler.FlushAndReturn(err)
 	}
@@ -125,7 +125,7 @@ func (c *CLIDownloadsSyncController) Sync(ctx context.Context, controllerContext
 		activeRouteName = api.OpenshiftDownloadsCustomRouteName
 	}
 
-	downloadsRoute, downloadsRouteErr := c.routeClient.Routes(api.TargetNamespace).Get(ctx, activeRouteName, metav1.GetOptions{})
+	downloadsRoute, downloadsRouteErr := c.routeLister.Routes(api.TargetNamespace).Get(activeRouteName)
 	if downloadsRouteErr != nil {
 		return downloadsRouteErr
 	}
@@ -151,6 +151,16 @@ func (c *CLIDownloadsSyncController) Sync(ctx context.Context, controllerContext
 	return statusHandler.FlushAndReturn(nil)
 }
 
+// GetManagementState returns the management state of the ConsoleCLIDownloads.
+// They link to the downloads route and go away together with it.
+func GetManagementState(operatorConfig *operatorsv1.Console) operatorsv1.ManagementState {
+	managementState := controllersutil.GetManagementState(operatorConfig, api.CLIDownloadsManagementStateAnnotation)
+	if managementState == operatorsv1.Managed && controllersutil.GetManagementState(operatorConfig, api.DownloadsManagementStateAnnotation) == operatorsv1.Removed {
+		return operatorsv1.Removed
+	}
+	return managementState
+}
+
 func (c *CLIDownloadsSyncController) removeCLIDownloads(ctx context.Context) error {
 	defer klog.V(4).Info("finished deleting ConsoleCliDownloads custom resources")
 	var errs []error
@@ -5,7 +5,10 @@ import (
 
 	"github.com/go-test/deep"
 	v1 "github.com/openshift/api/console/v1"
+	operatorsv1 "github.com/openshift/api/operator/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
 )
 
 func TestGetPlatformURL(t *testing.T) {
@@ -165,3 +168,47 @@ The oc binary offers the same capabilities as the kubectl binary, but it is furt
 		})
 	}
 }
+
+func TestGetManagementState(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        operatorsv1.ManagementState
+	}{
+		{
+			name: "Test CLI downloads follow the managed console",
+			want: operatorsv1.Managed,
+		},
+		{
+			name:        "Test CLI downloads removed",
+			annotations: map[string]string{api.CLIDownloadsManagementStateAnnotation: string(operatorsv1.Removed)},
+			want:        operatorsv1.Removed,
+		},
+		{
+			name:        "Test CLI downloads removed together with the downloads",
+			annotations: map[string]string{api.DownloadsManagementStateAnnotation: string(operatorsv1.Removed)},
+			want:        operatorsv1.Removed,
+		},
+		{
+			name: "Test unmanaged CLI downloads kept while the downloads are removed",
+			annotations: map[string]string{
+				api.CLIDownloadsManagementStateAnnotation: string(operatorsv1.Unmanaged),
+				api.DownloadsManagementStateAnnotation:    string(operatorsv1.Removed),
+			},
+			want: operatorsv1.Unmanaged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorsv1.Console{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec: operatorsv1.ConsoleSpec{
+					OperatorSpec: operatorsv1.OperatorSpec{ManagementState: operatorsv1.Managed},
+				},
+			}
+			if got := GetManagementState(operatorConfig); got != tt.want {
+				t.Errorf("GetManagementState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,549 @@
+package consoleconfigmap
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	// kube
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	coreinformersv1 "k8s.io/client-go/informers/core/v1"
+	coreclientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	// openshift
+	configv1 "github.com/openshift/api/config/v1"
+	v1 "github.com/openshift/api/console/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	configinformer "github.com/openshift/client-go/config/informers/externalversions"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
+	consoleinformersv1 "github.com/openshift/client-go/console/informers/externalversions/console/v1"
+	listerv1 "github.com/openshift/client-go/console/listers/console/v1"
+	oauthlistersv1 "github.com/openshift/client-go/oauth/listers/oauth/v1"
+	operatorinformerv1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
+	operatorlistersv1 "github.com/openshift/client-go/operator/listers/operator/v1"
+	routesinformersv1 "github.com/openshift/client-go/route/informers/externalversions/route/v1"
+	routev1listers "github.com/openshift/client-go/route/listers/route/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+
+	// operator
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	customerrors "github.com/openshift/console-operator/pkg/console/errors"
+	"github.com/openshift/console-operator/pkg/console/metrics"
+	"github.com/openshift/console-operator/pkg/console/status"
+	configmapsub "github.com/openshift/console-operator/pkg/console/subresource/configmap"
+	"github.com/openshift/console-operator/pkg/console/subresource/consoleserver"
+	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
+	oauthsub "github.com/openshift/console-operator/pkg/console/subresource/oauthclient"
+	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
+	utilsub "github.com/openshift/console-operator/pkg/console/subresource/util"
+)
+
+// ConsoleConfigMapSyncController renders the console-config ConfigMap, which
+// the console deployment mounts, together with its provenance ConfigMap.
+type ConsoleConfigMapSyncController struct {
+	operatorClient v1helpers.OperatorClient
+	// configs
+	consoleOperatorLister operatorlistersv1.ConsoleLister
+	consoleConfigLister   configlistersv1.ConsoleLister
+	infrastructureLister  configlistersv1.InfrastructureLister
+	ingressConfigLister   configlistersv1.IngressLister
+	oauthConfigLister     configlistersv1.OAuthLister
+	authnConfigLister     configlistersv1.AuthenticationLister
+	apiServerConfigLister configlistersv1.APIServerLister
+	// core kube
+	configMapClient          coreclientv1.ConfigMapsGetter
+	targetNSConfigMapLister  corev1listers.ConfigMapLister // for openshift-console namespace
+	configNSConfigMapLister  corev1listers.ConfigMapLister // for openshift-config namespace
+	managedNSConfigMapLister corev1listers.ConfigMapLister // for openshift-config-managed namespace
+	// openshift
+	oauthClientLister   oauthlistersv1.OAuthClientLister
+	routeLister         routev1listers.RouteLister
+	consolePluginLister listerv1.ConsolePluginLister
+
+	nodeComputeEnvironments *nodeComputeEnvironments
+
+	// OLM is an optional capability, its informer runs only while olmconfigs are served
+	olmConfigInformer *util.OptionalInformer
+	olmConfigLister   cache.GenericLister
+
+	monitoringDeploymentLister cache.GenericLister
+}
+
+func NewConsoleConfigMapSyncController(
+	// top level config
+	configInformer configinformer.SharedInformerFactory,
+	optionalInformers *util.OptionalInformers,
+	// clients
+	operatorClient v1helpers.OperatorClient,
+	corev1Client coreclientv1.CoreV1Interface,
+	// informers
+	operatorConfigInformer operatorinformerv1.ConsoleInformer,
+	coreV1 coreinformersv1.Interface,
+	configNSConfigMapInformer coreinformersv1.ConfigMapInformer,
+	managedCoreV1 coreinformersv1.Interface,
+	oauthClientSwitchedInformer *util.InformerWithSwitch,
+	routeInformer routesinformersv1.RouteInformer,
+	consolePluginInformer consoleinformersv1.ConsolePluginInformer,
+	// events
+	recorder events.Recorder,
+) factory.Controller {
+	targetNSConfigMapInformer := coreV1.ConfigMaps()
+	managedNSConfigMapInformer := managedCoreV1.ConfigMaps()
+	nodeInformer := coreV1.Nodes()
+	configV1Informers := configInformer.Config().V1()
+	olmConfigInformer := optionalInformers.ForResource(schema.GroupVersionResource{
+		Group:    api.OLMConfigGroup,
+		Version:  api.OLMConfigVersion,
+		Resource: api.OLMConfigResource,
+	}, "")
+	monitoringDeploymentInformer := optionalInformers.ForResource(appsv1.SchemeGroupVersion.WithResource("deployments"), deploymentsub.TelemeterClientDeploymentNamespace)
+
+	ctrl := &ConsoleConfigMapSyncController{
+		operatorClient: operatorClient,
+		// configs
+		consoleOperatorLister: operatorConfigInformer.Lister(),
+		consoleConfigLister:   configV1Informers.Consoles().Lister(),
+		infrastructureLister:  configV1Informers.Infrastructures().Lister(),
+		ingressConfigLister:   configV1Informers.Ingresses().Lister(),
+		oauthConfigLister:     configV1Informers.OAuths().Lister(),
+		authnConfigLister:     configV1Informers.Authentications().Lister(),
+		apiServerConfigLister: configV1Informers.APIServers().Lister(),
+		// core kube
+		configMapClient:          corev1Client,
+		targetNSConfigMapLister:  targetNSConfigMapInformer.Lister(),
+		configNSConfigMapLister:  configNSConfigMapInformer.Lister(),
+		managedNSConfigMapLister: managedNSConfigMapInformer.Lister(),
+		// openshift
+		oauthClientLister:   oauthClientSwitchedInformer.Lister(),
+		routeLister:         routeInformer.Lister(),
+		consolePluginLister: consolePluginInformer.Lister(),
+
+		nodeComputeEnvironments: newNodeComputeEnvironments(),
+
+		olmConfigInformer: olmConfigInformer,
+		olmConfigLister:   olmConfigInformer.Lister(),
+
+		monitoringDeploymentLister: monitoringDeploymentInformer.Lister(),
+	}
+
+	informers := []factory.Informer{
+		configV1Informers.Consoles().Informer(),
+		operatorConfigInformer.Informer(),
+		configV1Informers.Infrastructures().Informer(),
+		configV1Informers.Ingresses().Informer(),
+		configV1Informers.OAuths().Informer(),
+		configV1Informers.Authentications().Informer(),
+		configV1Informers.APIServers().Informer(),
+		olmConfigInformer,
+	}
+
+	nodeEnvironmentsInformer, err := ctrl.nodeComputeEnvironments.Track(nodeInformer)
+	if err != nil {
+		klog.Errorf("failed to track node compute environments: %v", err)
+		nodeEnvironmentsInformer = nodeInformer.Informer()
+	}
+
+	return factory.New().
+		WithFilteredEventsInformers( // configs
+			util.IncludeNamesFilter(api.ConfigResourceName),
+			informers...,
+		).WithFilteredEventsInformers( // console resources
+		util.IncludeNamesFilter(api.OpenShiftConsoleRouteName, api.OpenshiftConsoleCustomRouteName, api.OpenShiftConsoleConfigMapName, api.ConsoleConfigProvenanceName, api.ConsoleConfigHistoryName,
+			api.CustomLogoLightConfigMapName, api.CustomLogoDarkConfigMapName, api.CustomFaviconConfigMapName),
+		routeInformer.Informer(),
+		targetNSConfigMapInformer.Informer(),
+	).WithInformers(
+		nodeEnvironmentsInformer,
+		consolePluginInformer.Informer(),
+		configNSConfigMapInformer.Informer(),
+	).WithFilteredEventsInformers(
+		util.IncludeNamesFilter(api.OpenShiftConsoleConfigMapName, api.OpenShiftMonitoringConfigMapName),
+		managedNSConfigMapInformer.Informer(),
+	).WithFilteredEventsInformers(
+		factory.NamesFilter(api.OAuthClientName),
+		oauthClientSwitchedInformer.Informer(),
+	).WithFilteredEventsInformers(
+		util.IncludeNamesFilter(deploymentsub.TelemeterClientDeploymentName),
+		monitoringDeploymentInformer,
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync("console-configmap-controller", ctrl.Sync)).
+		ToController("ConsoleConfigMapController", recorder.WithComponentSuffix("console-configmap-controller"))
+}
+
+func (c *ConsoleConfigMapSyncController) Sync(ctx context.Context, controllerContext factory.SyncContext) error {
+	operatorConfig, err := c.consoleOperatorLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return err
+	}
+	operatorConfigCopy := operatorConfig.DeepCopy()
+
+	switch operatorConfigCopy.Spec.ManagementState {
+	case operatorv1.Managed:
+		klog.V(4).Infoln("console is in a managed state: syncing console-config configmap")
+	case operatorv1.Unmanaged:
+		klog.V(4).Infoln("console is in an unmanaged state: skipping console-config configmap sync")
+		return nil
+	case operatorv1.Removed:
+		klog.V(4).Infoln("console is in a removed state: removing console-config configmap")
+		return c.removeConsoleConfigMap(ctx)
+	default:
+		return fmt.Errorf("unknown state: %v", operatorConfigCopy.Spec.ManagementState)
+	}
+
+	statusHandler := status.NewStatusHandler(c.operatorClient)
+
+	consoleConfig, err := c.consoleConfigLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+	infrastructureConfig, err := c.infrastructureLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+	oauthConfig, err := c.oauthConfigLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+	ingressConfig, err := c.ingressConfigLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+	authnConfig, err := c.authnConfigLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+
+	configMapStep := metrics.StartSyncStep("ConfigMapSync")
+	// the console-config points the console at its own public hostname,
+	// wait until the RouteSyncController has created and admitted the route.
+	routeName := api.OpenShiftConsoleRouteName
+	routeConfig := routesub.NewRouteConfig(operatorConfigCopy, ingressConfig, routeName)
+	if routeConfig.IsCustomHostnameSet() {
+		routeName = api.OpenshiftConsoleCustomRouteName
+	}
+	route, _, routeErrReason, routeErr := routesub.GetActiveRouteInfo(c.routeLister, routeName)
+	if routeErr != nil {
+		configMapStep.Done(routeErrReason, routeErr)
+		statusHandler.AddConditions(status.HandleProgressingOrDegraded("ConfigMapSync", routeErrReason, routeErr))
+		return statusHandler.FlushAndReturn(routeErr)
+	}
+
+	var authServerCAConfig *corev1.ConfigMap
+	if authnConfig.Spec.Type == configv1.AuthenticationTypeOIDC {
+		// keep the current console-config rather than guess which provider to use
+		oidcProvider, _, oidcErr := utilsub.GetOIDCClientConfig(authnConfig)
+		if oidcErr != nil {
+			configMapStep.Done("AmbiguousOIDCClientConfig", oidcErr)
+			statusHandler.AddConditions(status.HandleProgressingOrDegraded("ConfigMapSync", "AmbiguousOIDCClientConfig", oidcErr))
+			return statusHandler.FlushAndReturn(oidcErr)
+		}
+		if oidcProvider != nil && len(oidcProvider.Issuer.CertificateAuthority.Name) > 0 {
+			authServerCAConfig, err = c.configNSConfigMapLister.ConfigMaps(api.OpenShiftConfigNamespace).Get(oidcProvider.Issuer.CertificateAuthority.Name)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return statusHandler.FlushAndReturn(err)
+			}
+		}
+	}
+
+	cm, _, cmErrReason, cmErr := c.SyncConfigMap(
+		ctx,
+		operatorConfigCopy,
+		consoleConfig,
+		infrastructureConfig,
+		oauthConfig,
+		authServerCAConfig,
+		authnConfig,
+		route,
+		controllerContext.Recorder(),
+	)
+	configMapStep.Done(cmErrReason, cmErr)
+	var overridesErr error
+	if customerrors.IsConfigOverridesError(cmErr) {
+		overridesErr = cmErr
+		// the deployment carries on with the last valid console-config, if there is one
+		if cm != nil {
+			cmErrReason, cmErr = "", nil
+		}
+	}
+	var rollbackErr error
+	if customerrors.IsConfigRollbackError(cmErr) {
+		rollbackErr = cmErr
+		cmErrReason, cmErr = "", nil
+	}
+	statusHandler.AddCondition(status.HandleDegraded("ConfigOverrides", "InvalidConfigOverrides", overridesErr))
+	statusHandler.AddCondition(status.HandleDegraded("ConfigRollback", "RejectedConfig", rollbackErr))
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("ConfigMapSync", cmErrReason, cmErr))
+
+	return statusHandler.FlushAndReturn(cmErr)
+}
+
+// apply configmap (needs route)
+// by the time we get to the configmap, we can assume the route exits & is configured properly
+// therefore no additional error handling is needed here.
+func (c *ConsoleConfigMapSyncController) SyncConfigMap(
+	ctx context.Context,
+	operatorConfig *operatorv1.Console,
+	consoleConfig *configv1.Console,
+	infrastructureConfig *configv1.Infrastructure,
+	oauthConfig *configv1.OAuth,
+	authServerCAConfig *corev1.ConfigMap,
+	authConfig *configv1.Authentication,
+	activeConsoleRoute *routev1.Route,
+	recorder events.Recorder,
+) (consoleConfigMap *corev1.ConfigMap, changed bool, reason string, err error) {
+
+	managedConfig, mcErr := c.managedNSConfigMapLister.ConfigMaps(api.OpenShiftConfigManagedNamespace).Get(api.OpenShiftConsoleConfigMapName)
+	if mcErr != nil {
+		if !apierrors.IsNotFound(mcErr) {
+			return nil, false, "FailedGetManagedConfig", mcErr
+		}
+		managedConfig = &corev1.ConfigMap{}
+	}
+
+	// the console serves with the TLS security profile of the cluster, if any
+	apiServerConfig, ascErr := c.apiServerConfigLister.Get(api.ConfigResourceName)
+	if ascErr != nil {
+		if !apierrors.IsNotFound(ascErr) {
+			return nil, false, "FailedGetAPIServerConfig", ascErr
+		}
+		apiServerConfig = nil
+	}
+
+	nodeArchitectures, nodeOperatingSystems := c.nodeComputeEnvironments.List()
+
+	// TODO: currently there's no way to get this for authentication type OIDC
+	inactivityTimeoutSeconds := 0
+	switch authConfig.Spec.Type {
+	case "", configv1.AuthenticationTypeIntegratedOAuth:
+		oauthClient, oacErr := c.oauthClientLister.Get(oauthsub.Stub().Name)
+		if oacErr != nil {
+			return nil, false, "FailedGetOAuthClient", oacErr
+		}
+		// the timeout is the one the OAuthClientsController leaves on the OAuthClient
+		// for the declared token policy, which the lister may not show yet. An invalid
+		// token policy is reported by the OAuthClientsController, which leaves the
+		// OAuthClient as it is meanwhile.
+		clientInactivityTimeoutSeconds := oauthClient.AccessTokenInactivityTimeoutSeconds
+		if tokenPolicy, tokenPolicyErr := oauthsub.GetTokenPolicy(operatorConfig); tokenPolicyErr == nil {
+			clientInactivityTimeoutSeconds = oauthsub.SetTokenPolicy(oauthClient.DeepCopy(), tokenPolicy).AccessTokenInactivityTimeoutSeconds
+		}
+		if clientInactivityTimeoutSeconds != nil {
+			inactivityTimeoutSeconds = int(*clientInactivityTimeoutSeconds)
+		} else {
+			if oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout != nil {
+				inactivityTimeoutSeconds = int(oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout.Seconds())
+			}
+		}
+	}
+
+	availablePlugins := c.GetAvailablePlugins(operatorConfig.Spec.Plugins)
+
+	monitoringSharedConfig, mscErr := c.managedNSConfigMapLister.ConfigMaps(api.OpenShiftConfigManagedNamespace).Get(api.OpenShiftMonitoringConfigMapName)
+	if mscErr != nil {
+		if !apierrors.IsNotFound(mscErr) {
+			return nil, false, "FailedGetMonitoringSharedConfig", mscErr
+		}
+		monitoringSharedConfig = &corev1.ConfigMap{}
+	}
+
+	telemeterClientIsAvailable, err := deploymentsub.IsTelemeterClientAvailable(c.monitoringDeploymentLister)
+	if err != nil {
+		return nil, false, "FailedTelemeterClientCheck", err
+	}
+
+	var (
+		copiedCSVsDisabled bool
+		ccdErr             error
+	)
+	if c.olmConfigInformer.IsRunning() {
+		copiedCSVsDisabled, ccdErr = c.isCopiedCSVsDisabled()
+		if ccdErr != nil {
+			return nil, false, "FailedGetOLMConfig", ccdErr
+		}
+	}
+
+	// the console only serves the branding images the operator has copied, once validated
+	customBrandingConfigMaps := []*corev1.ConfigMap{}
+	for _, file := range configmapsub.CustomBrandingFiles {
+		customBrandingConfigMap, cbErr := c.targetNSConfigMapLister.ConfigMaps(api.TargetNamespace).Get(file.ConfigMapName)
+		if apierrors.IsNotFound(cbErr) {
+			continue
+		}
+		if cbErr != nil {
+			return nil, false, "FailedGetCustomBranding", cbErr
+		}
+		customBrandingConfigMaps = append(customBrandingConfigMaps, customBrandingConfigMap)
+	}
+
+	defaultConfigmap, provenance, unsupportedOverridesHaveMerged, err := configmapsub.DefaultConfigMap(
+		operatorConfig,
+		consoleConfig,
+		authConfig,
+		authServerCAConfig,
+		managedConfig,
+		monitoringSharedConfig,
+		infrastructureConfig,
+		apiServerConfig,
+		activeConsoleRoute,
+		inactivityTimeoutSeconds,
+		availablePlugins,
+		nodeArchitectures,
+		nodeOperatingSystems,
+		copiedCSVsDisabled,
+		telemeterClientIsAvailable,
+		customBrandingConfigMaps,
+		os.Getenv("RELEASE_VERSION"),
+	)
+	if err != nil {
+		return nil, false, "FailedConsoleConfigBuilder", err
+	}
+	existingConfigMap, err := c.targetNSConfigMapLister.ConfigMaps(api.TargetNamespace).Get(defaultConfigmap.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, false, "FailedGet", err
+	}
+
+	// the console silently ignores keys it doesn't know and dies on values of the wrong type,
+	// don't roll out overrides it won't be able to read and keep the last good console-config instead.
+	if unsupportedOverridesHaveMerged {
+		if validationErr := consoleserver.ValidateConfig([]byte(defaultConfigmap.Data[configmapsub.ConsoleConfigYamlFile])); validationErr != nil {
+			klog.Errorf("unsupportedConfigOverrides produce an invalid console config: %v", validationErr)
+			overridesErr := customerrors.NewConfigOverridesError(fmt.Sprintf("unsupportedConfigOverrides produce an invalid console config, keeping the last valid one: %v", validationErr))
+			return existingConfigMap, false, "InvalidConfigOverrides", overridesErr
+		}
+	}
+
+	// the console deployment did not become available with this console-config
+	// before, roll back to the last good one until the inputs change.
+	rejectedChanges, rolledBackProvenance, err := c.rollbackRejectedConfig(defaultConfigmap, provenance)
+	if err != nil {
+		return nil, false, "FailedConfigHistory", err
+	}
+	rolledBack := rolledBackProvenance != nil
+	if rolledBack {
+		provenance = rolledBackProvenance
+	}
+
+	provenanceConfigMap, err := configmapsub.DefaultProvenanceConfigMap(operatorConfig, provenance)
+	if err != nil {
+		return nil, false, "FailedConsoleConfigProvenance", err
+	}
+	if _, _, err := resourceapply.ApplyConfigMap(ctx, c.configMapClient, recorder, provenanceConfigMap); err != nil {
+		return nil, false, "FailedApplyProvenance", err
+	}
+
+	cm, cmChanged, cmErr := resourceapply.ApplyConfigMap(ctx, c.configMapClient, recorder, defaultConfigmap)
+	if cmErr != nil {
+		return nil, false, "FailedApply", cmErr
+	}
+	if cmChanged {
+		klog.V(4).Infoln("new console config yaml:")
+		klog.V(4).Infof("%s", cm.Data)
+		logConfigChanges(existingConfigMap, cm, provenance)
+	}
+	if rolledBack {
+		rollbackErr := customerrors.NewConfigRollbackError(fmt.Sprintf("console deployment did not become available with the latest console-config, rolled back to the last good one. Rejected changes (layer in parentheses): %s", strings.Join(rejectedChanges, "; ")))
+		return cm, cmChanged, "RejectedConfig", rollbackErr
+	}
+	return cm, cmChanged, "ConsoleConfigBuilder", cmErr
+}
+
+// rollbackRejectedConfig replaces the rendered console-config with the last good
+// one when the console deployment rejected it, and returns the rejected changes
+// along with the provenance of the console-config rolled back to.
+func (c *ConsoleConfigMapSyncController) rollbackRejectedConfig(consoleConfigMap *corev1.ConfigMap, provenance consoleserver.ConfigProvenance) (rejectedChanges []string, rolledBackProvenance consoleserver.ConfigProvenance, err error) {
+	historyConfigMap, err := c.targetNSConfigMapLister.ConfigMaps(api.TargetNamespace).Get(api.ConsoleConfigHistoryName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	history, err := configmapsub.GetConfigHistory(historyConfigMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	renderedConfig := consoleConfigMap.Data[configmapsub.ConsoleConfigYamlFile]
+	lastGood := history.LastGood()
+	if !history.IsRejected(renderedConfig) || lastGood == nil {
+		return nil, nil, nil
+	}
+
+	rejectedChanges, err = provenance.Diff([]byte(lastGood.Config), []byte(renderedConfig))
+	if err != nil {
+		return nil, nil, err
+	}
+	rolledBackProvenance, err = provenance.RolledBack([]byte(lastGood.Config), []byte(renderedConfig))
+	if err != nil {
+		return nil, nil, err
+	}
+	klog.Errorf("console deployment did not become available with the rendered console-config, rolling back to the last good one")
+	consoleConfigMap.Data[configmapsub.ConsoleConfigYamlFile] = lastGood.Config
+	return rejectedChanges, rolledBackProvenance, nil
+}
+
+// logConfigChanges logs the keys of console-config changed by the last apply,
+// together with the layer responsible for each new value.
+func logConfigChanges(existing *corev1.ConfigMap, updated *corev1.ConfigMap, provenance consoleserver.ConfigProvenance) {
+	if !klog.V(4).Enabled() {
+		return
+	}
+	var existingConfig string
+	if existing != nil {
+		existingConfig = existing.Data[configmapsub.ConsoleConfigYamlFile]
+	}
+	changes, err := provenance.Diff([]byte(existingConfig), []byte(updated.Data[configmapsub.ConsoleConfigYamlFile]))
+	if err != nil {
+		klog.V(4).Infof("unable to diff console config: %v", err)
+		return
+	}
+	klog.V(4).Infof("console config changes (layer in parentheses):\n%s", strings.Join(changes, "\n"))
+}
+
+func (c *ConsoleConfigMapSyncController) GetAvailablePlugins(enabledPluginsNames []string) []*v1.ConsolePlugin {
+	var availablePlugins []*v1.ConsolePlugin
+	for _, pluginName := range utilsub.RemoveDuplicateStr(enabledPluginsNames) {
+		plugin, err := c.consolePluginLister.Get(pluginName)
+		if err != nil {
+			klog.Errorf("failed to get %q plugin: %v", pluginName, err)
+			continue
+		}
+		availablePlugins = append(availablePlugins, plugin)
+	}
+	return availablePlugins
+}
+
+func (c *ConsoleConfigMapSyncController) isCopiedCSVsDisabled() (bool, error) {
+	if !c.olmConfigInformer.HasSynced() {
+		return false, customerrors.NewSyncError("waiting for the olmconfigs informer to sync")
+	}
+	obj, err := c.olmConfigLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return false, err
+	}
+	olmConfig, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return false, fmt.Errorf("unexpected olmconfig type %T", obj)
+	}
+	return configmapsub.CopiedCSVsDisabled(olmConfig)
+}
+
+func (c *ConsoleConfigMapSyncController) removeConsoleConfigMap(ctx context.Context) error {
+	var errs []error
+	errs = append(errs, c.configMapClient.ConfigMaps(api.TargetNamespace).Delete(ctx, configmapsub.Stub().Name, metav1.DeleteOptions{}))
+	errs = append(errs, c.configMapClient.ConfigMaps(api.TargetNamespace).Delete(ctx, configmapsub.ProvenanceStub().Name, metav1.DeleteOptions{}))
+	// filter out 404 errors, which indicate that resource is already deleted
+	return utilerrors.FilterOut(utilerrors.NewAggregate(errs), apierrors.IsNotFound)
+}
@@ -0,0 +1,121 @@
+package consoleconfigmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-test/deep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	configv1 "github.com/openshift/api/config/v1"
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
+	listerv1 "github.com/openshift/client-go/console/listers/console/v1"
+	oauthlistersv1 "github.com/openshift/client-go/oauth/listers/oauth/v1"
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	customerrors "github.com/openshift/console-operator/pkg/console/errors"
+	configmapsub "github.com/openshift/console-operator/pkg/console/subresource/configmap"
+	"github.com/openshift/console-operator/pkg/console/subresource/consoleserver"
+)
+
+func TestSyncConfigMapRollsBackRejectedConfig(t *testing.T) {
+	ctx := context.Background()
+	targetNSIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	oauthClientIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := oauthClientIndexer.Add(&oauthv1.OAuthClient{ObjectMeta: metav1.ObjectMeta{Name: api.OAuthClientName}}); err != nil {
+		t.Fatal(err)
+	}
+	kubeClient := fake.NewSimpleClientset()
+	c := &ConsoleConfigMapSyncController{
+		apiServerConfigLister:      configlistersv1.NewAPIServerLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		configMapClient:            kubeClient.CoreV1(),
+		targetNSConfigMapLister:    corev1listers.NewConfigMapLister(targetNSIndexer),
+		managedNSConfigMapLister:   corev1listers.NewConfigMapLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		oauthClientLister:          oauthlistersv1.NewOAuthClientLister(oauthClientIndexer),
+		consolePluginLister:        listerv1.NewConsolePluginLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		nodeComputeEnvironments:    newNodeComputeEnvironments(),
+		olmConfigInformer:          &util.OptionalInformer{},
+		monitoringDeploymentLister: cache.NewGenericLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}), schema.GroupResource{Group: "apps", Resource: "deployments"}),
+	}
+	operatorConfig := func(productName string) *operatorv1.Console {
+		return &operatorv1.Console{
+			ObjectMeta: metav1.ObjectMeta{Name: api.ConfigResourceName},
+			Spec: operatorv1.ConsoleSpec{
+				Customization: operatorv1.ConsoleCustomization{CustomProductName: productName},
+			},
+		}
+	}
+	syncConfigMap := func(operatorConfig *operatorv1.Console) (string, consoleserver.ConfigProvenance, error) {
+		_, _, _, err := c.SyncConfigMap(
+			ctx,
+			operatorConfig,
+			&configv1.Console{},
+			&configv1.Infrastructure{Status: configv1.InfrastructureStatus{APIServerURL: "https://api.example.com:6443"}},
+			&configv1.OAuth{},
+			nil,
+			&configv1.Authentication{},
+			&routev1.Route{Spec: routev1.RouteSpec{Host: "console.example.com"}},
+			events.NewInMemoryRecorder("test"),
+		)
+		consoleConfigMap, getErr := kubeClient.CoreV1().ConfigMaps(api.TargetNamespace).Get(ctx, api.OpenShiftConsoleConfigMapName, metav1.GetOptions{})
+		if getErr != nil {
+			t.Fatal(getErr)
+		}
+		provenanceConfigMap, getErr := kubeClient.CoreV1().ConfigMaps(api.TargetNamespace).Get(ctx, api.ConsoleConfigProvenanceName, metav1.GetOptions{})
+		if getErr != nil {
+			t.Fatal(getErr)
+		}
+		provenance := consoleserver.ConfigProvenance{}
+		if unmarshalErr := yaml.Unmarshal([]byte(provenanceConfigMap.Data["provenance.yaml"]), &provenance); unmarshalErr != nil {
+			t.Fatal(unmarshalErr)
+		}
+		return consoleConfigMap.Data[configmapsub.ConsoleConfigYamlFile], provenance, err
+	}
+
+	goodConfig, goodProvenance, err := syncConfigMap(operatorConfig("good"))
+	if err != nil {
+		t.Fatalf("unexpected error rendering the good console-config: %v", err)
+	}
+	rejectedConfig, _, err := syncConfigMap(operatorConfig("rejected"))
+	if err != nil {
+		t.Fatalf("unexpected error rendering the rejected console-config: %v", err)
+	}
+
+	history := &configmapsub.ConfigHistory{}
+	history.RecordGood(goodConfig, nil)
+	history.Reject(rejectedConfig)
+	historyConfigMap, err := configmapsub.DefaultConfigHistoryConfigMap(operatorConfig("rejected"), history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targetNSIndexer.Add(historyConfigMap); err != nil {
+		t.Fatal(err)
+	}
+
+	appliedConfig, appliedProvenance, err := syncConfigMap(operatorConfig("rejected"))
+	if !customerrors.IsConfigRollbackError(err) {
+		t.Errorf("expected a config rollback error, got: %v", err)
+	}
+	if diff := deep.Equal(appliedConfig, goodConfig); diff != nil {
+		t.Errorf("expected the last good console-config to be applied: %v", diff)
+	}
+	// the rolled back custom product name is no longer set by the user-defined layer
+	wantProvenance := consoleserver.ConfigProvenance{}
+	for path, layer := range goodProvenance {
+		wantProvenance[path] = layer
+	}
+	wantProvenance["customization.customProductName"] = consoleserver.RolledBackConfigLayer
+	if diff := deep.Equal(appliedProvenance, wantProvenance); diff != nil {
+		t.Errorf("expected the provenance of the last good console-config: %v", diff)
+	}
+}
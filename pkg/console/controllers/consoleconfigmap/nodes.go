@@ -0,0 +1,139 @@
+package consoleconfigmap
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coreinformersv1 "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+type nodeComputeEnvironment struct {
+	architecture    string
+	operatingSystem string
+}
+
+// nodeComputeEnvironments keeps track of the architectures and operating systems
+// of the cluster nodes from the node informer events, so that the sync loop
+// doesn't have to list every node on each pass.
+type nodeComputeEnvironments struct {
+	lock             sync.RWMutex
+	nodes            map[string]nodeComputeEnvironment
+	architectures    map[string]int
+	operatingSystems map[string]int
+}
+
+func newNodeComputeEnvironments() *nodeComputeEnvironments {
+	return &nodeComputeEnvironments{
+		nodes:            map[string]nodeComputeEnvironment{},
+		architectures:    map[string]int{},
+		operatingSystems: map[string]int{},
+	}
+}
+
+// nodeComputeEnvironmentsInformer is the node informer which only reports itself
+// synced once the node compute environments have seen the initial list of nodes.
+type nodeComputeEnvironmentsInformer struct {
+	cache.SharedIndexInformer
+	registration cache.ResourceEventHandlerRegistration
+}
+
+func (i *nodeComputeEnvironmentsInformer) HasSynced() bool {
+	return i.SharedIndexInformer.HasSynced() && i.registration.HasSynced()
+}
+
+// Track registers the node compute environments on the node informer and
+// returns the informer the controller should wait for.
+func (n *nodeComputeEnvironments) Track(nodeInformer coreinformersv1.NodeInformer) (cache.SharedIndexInformer, error) {
+	registration, err := nodeInformer.Informer().AddEventHandler(n)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeComputeEnvironmentsInformer{
+		SharedIndexInformer: nodeInformer.Informer(),
+		registration:        registration,
+	}, nil
+}
+
+func (n *nodeComputeEnvironments) OnAdd(obj interface{}, _ bool) {
+	if node, ok := obj.(*corev1.Node); ok {
+		n.set(node)
+	}
+}
+
+func (n *nodeComputeEnvironments) OnUpdate(_, newObj interface{}) {
+	if node, ok := newObj.(*corev1.Node); ok {
+		n.set(node)
+	}
+}
+
+func (n *nodeComputeEnvironments) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if node, ok := obj.(*corev1.Node); ok {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		n.remove(node.Name)
+	}
+}
+
+// List returns the sorted architectures and operating systems of the cluster nodes.
+func (n *nodeComputeEnvironments) List() ([]string, []string) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return sets.StringKeySet(n.architectures).List(), sets.StringKeySet(n.operatingSystems).List()
+}
+
+func (n *nodeComputeEnvironments) set(node *corev1.Node) {
+	current := nodeComputeEnvironment{
+		architecture:    node.Labels[api.NodeArchitectureLabel],
+		operatingSystem: node.Labels[api.NodeOperatingSystemLabel],
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if previous, found := n.nodes[node.Name]; found {
+		if previous == current {
+			return
+		}
+		n.remove(node.Name)
+	}
+
+	if current.architecture == "" {
+		klog.Warningf("Missing architecture label %q on node %q.", api.NodeArchitectureLabel, node.GetName())
+	} else {
+		n.architectures[current.architecture]++
+	}
+	if current.operatingSystem == "" {
+		klog.Warningf("Missing operating system label %q on node %q", api.NodeOperatingSystemLabel, node.GetName())
+	} else {
+		n.operatingSystems[current.operatingSystem]++
+	}
+	n.nodes[node.Name] = current
+}
+
+// remove expects the lock to be held
+func (n *nodeComputeEnvironments) remove(nodeName string) {
+	previous, found := n.nodes[nodeName]
+	if !found {
+		return
+	}
+	delete(n.nodes, nodeName)
+	release(n.architectures, previous.architecture)
+	release(n.operatingSystems, previous.operatingSystem)
+}
+
+func release(counts map[string]int, key string) {
+	if key == "" {
+		return
+	}
+	counts[key]--
+	if counts[key] <= 0 {
+		delete(counts, key)
+	}
+}
@@ -1,4 +1,4 @@
-package operator
+package consoleconfigmap
 
 import (
 	"testing"
@@ -7,9 +7,10 @@ import (
 	"github.com/openshift/console-operator/pkg/api"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
-func TestGetNodeComputeEnvironments(t *testing.T) {
+func TestNodeComputeEnvironments(t *testing.T) {
 	tests := []struct {
 		name                     string
 		nodeList                 *v1.NodeList
@@ -17,7 +18,7 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 		expectedOperatingSystems []string
 	}{
 		{
-			name: "Test getNodeComputeEnvironments",
+			name: "Test nodeComputeEnvironments",
 			nodeList: &v1.NodeList{
 				Items: []v1.Node{
 					{
@@ -31,7 +32,7 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 					},
 					{
 						ObjectMeta: metav1.ObjectMeta{
-							Name: "node-1",
+							Name: "node-2",
 							Labels: map[string]string{
 								api.NodeArchitectureLabel:    "baz",
 								api.NodeOperatingSystemLabel: "bat",
@@ -44,13 +45,13 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 			expectedOperatingSystems: []string{"bar", "bat"},
 		},
 		{
-			name:                     "Test getNodeComputeEnvironments empty node list",
+			name:                     "Test nodeComputeEnvironments empty node list",
 			nodeList:                 &v1.NodeList{},
 			expectedArchitectures:    []string{},
 			expectedOperatingSystems: []string{},
 		},
 		{
-			name: "Test getNodeComputeEnvironments missing arch label",
+			name: "Test nodeComputeEnvironments missing arch label",
 			nodeList: &v1.NodeList{
 				Items: []v1.Node{
 					{
@@ -76,7 +77,7 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 			expectedOperatingSystems: []string{"bar", "bat"},
 		},
 		{
-			name: "Test getNodeComputeEnvironments empty arch label",
+			name: "Test nodeComputeEnvironments empty arch label",
 			nodeList: &v1.NodeList{
 				Items: []v1.Node{
 					{
@@ -103,7 +104,7 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 			expectedOperatingSystems: []string{"bar", "bat"},
 		},
 		{
-			name: "Test getNodeComputeEnvironments duplicate arch label",
+			name: "Test nodeComputeEnvironments duplicate arch label",
 			nodeList: &v1.NodeList{
 				Items: []v1.Node{
 					{
@@ -130,7 +131,7 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 			expectedOperatingSystems: []string{"bar", "bat"},
 		},
 		{
-			name: "Test getNodeComputeEnvironments missing OS label",
+			name: "Test nodeComputeEnvironments missing OS label",
 			nodeList: &v1.NodeList{
 				Items: []v1.Node{
 					{
@@ -156,7 +157,7 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 			expectedOperatingSystems: []string{"bat"},
 		},
 		{
-			name: "Test getNodeComputeEnvironments empty OS label",
+			name: "Test nodeComputeEnvironments empty OS label",
 			nodeList: &v1.NodeList{
 				Items: []v1.Node{
 					{
@@ -183,7 +184,7 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 			expectedOperatingSystems: []string{"bat"},
 		},
 		{
-			name: "Test getNodeComputeEnvironments duplicate OS label",
+			name: "Test nodeComputeEnvironments duplicate OS label",
 			nodeList: &v1.NodeList{
 				Items: []v1.Node{
 					{
@@ -213,7 +214,11 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actualArchitectures, actualOperatingSystems := getNodeComputeEnvironments(tt.nodeList)
+			environments := newNodeComputeEnvironments()
+			for i := range tt.nodeList.Items {
+				environments.OnAdd(&tt.nodeList.Items[i], true)
+			}
+			actualArchitectures, actualOperatingSystems := environments.List()
 			if diff := deep.Equal(tt.expectedArchitectures, actualArchitectures); diff != nil {
 				t.Error(diff)
 				return
@@ -226,3 +231,35 @@ func TestGetNodeComputeEnvironments(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeComputeEnvironmentsEvents(t *testing.T) {
+	node := func(name, arch, os string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					api.NodeArchitectureLabel:    arch,
+					api.NodeOperatingSystemLabel: os,
+				},
+			},
+		}
+	}
+
+	environments := newNodeComputeEnvironments()
+	environments.OnAdd(node("node-1", "amd64", "linux"), true)
+	environments.OnAdd(node("node-2", "amd64", "linux"), true)
+	environments.OnAdd(node("node-3", "arm64", "windows"), false)
+
+	// relabelling one of two amd64 nodes keeps amd64 around
+	environments.OnUpdate(node("node-1", "amd64", "linux"), node("node-1", "s390x", "linux"))
+	// deleting the only windows node drops windows, also when the delete is missed
+	environments.OnDelete(cache.DeletedFinalStateUnknown{Key: "node-3", Obj: node("node-3", "arm64", "windows")})
+
+	actualArchitectures, actualOperatingSystems := environments.List()
+	if diff := deep.Equal([]string{"amd64", "s390x"}, actualArchitectures); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal([]string{"linux"}, actualOperatingSystems); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -9,7 +9,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appsinformersv1 "k8s.io/client-go/informers/apps/v1"
+	coreinformersv1 "k8s.io/client-go/informers/core/v1"
 	appsclientv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -19,6 +21,7 @@ import (
 	operatorinformerv1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorlistersv1 "github.com/openshift/client-go/operator/listers/operator/v1"
 	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
@@ -28,6 +31,7 @@ import (
 
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
 	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
 )
 
 type DownloadsDeploymentSyncController struct {
@@ -36,7 +40,8 @@ type DownloadsDeploymentSyncController struct {
 	consoleOperatorLister operatorlistersv1.ConsoleLister
 	infrastructureLister  configlistersv1.InfrastructureLister
 	// core kube
-	deploymentClient appsclientv1.DeploymentsGetter
+	deploymentClient        appsclientv1.DeploymentsGetter
+	configNSConfigMapLister corev1listers.ConfigMapLister
 }
 
 func NewDownloadsDeploymentSyncController(
@@ -48,6 +53,7 @@ func NewDownloadsDeploymentSyncController(
 	// core kube
 	deploymentClient appsclientv1.DeploymentsGetter,
 	deploymentInformer appsinformersv1.DeploymentInformer,
+	configNSConfigMapInformer coreinformersv1.ConfigMapInformer,
 	// events
 	recorder events.Recorder,
 ) factory.Controller {
@@ -59,7 +65,8 @@ func NewDownloadsDeploymentSyncController(
 		consoleOperatorLister: operatorConfigInformer.Lister(),
 		infrastructureLister:  configInformer.Config().V1().Infrastructures().Lister(),
 		// client
-		deploymentClient: deploymentClient,
+		deploymentClient:        deploymentClient,
+		configNSConfigMapLister: configNSConfigMapInformer.Lister(),
 	}
 
 	configNameFilter := util.IncludeNamesFilter(api.ConfigResourceName)
@@ -73,7 +80,10 @@ func NewDownloadsDeploymentSyncController(
 		).WithFilteredEventsInformers( // downloads deployment
 		downloadsNameFilter,
 		deploymentInformer.Informer(),
-	).ResyncEvery(time.Minute).WithSync(ctrl.Sync).
+	).WithFilteredEventsInformers( // workload config
+		util.IncludeNamesFilter(api.WorkloadConfigMapName),
+		configNSConfigMapInformer.Informer(),
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync("console-downloads-deployment-controller", ctrl.Sync)).
 		ToController("ConsoleDownloadsDeploymentSyncController", recorder.WithComponentSuffix("console-downloads-deployment-controller"))
 }
 
@@ -84,19 +94,22 @@ func (c *DownloadsDeploymentSyncController) Sync(ctx context.Context, controller
 	}
 	operatorConfigCopy := operatorConfig.DeepCopy()
 
-	switch operatorConfigCopy.Spec.ManagementState {
+	statusHandler := status.NewStatusHandler(c.operatorClient)
+
+	switch managementState := util.GetManagementState(operatorConfigCopy, api.DownloadsManagementStateAnnotation); managementState {
 	case operatorv1.Managed:
-		klog.V(4).Infoln("console is in a managed state: syncing downloads deployment")
+		klog.V(4).Infoln("downloads are in a managed state: syncing downloads deployment")
 	case operatorv1.Unmanaged:
-		klog.V(4).Infoln("console is in an unmanaged state: skipping downloads deployment sync")
+		klog.V(4).Infoln("downloads are in an unmanaged state: skipping downloads deployment sync")
 		return nil
 	case operatorv1.Removed:
-		klog.V(4).Infoln("console is in an removed state: removing synced downloads deployment")
-		return c.removeDownloadsDeployment(ctx)
+		klog.V(4).Infoln("downloads are in a removed state: removing synced downloads deployment")
+		err := c.removeDownloadsDeployment(ctx)
+		statusHandler.AddConditions(status.HandleProgressingOrDegraded("DownloadsDeploymentSync", "FailedDelete", err))
+		return statusHandler.FlushAndReturn(err)
 	default:
-		return fmt.Errorf("unknown state: %v", operatorConfigCopy.Spec.ManagementState)
+		return fmt.Errorf("unknown state: %v", managementState)
 	}
-	statusHandler := status.NewStatusHandler(c.operatorClient)
 
 	infrastructureConfig, err := c.infrastructureLister.Get(api.ConfigResourceName)
 	statusHandler.AddCondition(status.HandleDegraded("DownloadsDeploymentSync", "FailedInfrastructureConfigGet", err))
@@ -104,7 +117,13 @@ func (c *DownloadsDeploymentSyncController) Sync(ctx context.Context, controller
 		return statusHandler.FlushAndReturn(err)
 	}
 
-	actualDownloadsDownloadsDeployment, _, downloadsDeploymentErr := c.SyncDownloadsDeployment(ctx, operatorConfigCopy, infrastructureConfig, controllerContext)
+	workloadConfig, err := workload.GetConfig(c.configNSConfigMapLister, workload.DownloadsSection)
+	statusHandler.AddCondition(status.HandleDegraded("DownloadsWorkloadConfig", "InvalidWorkloadConfig", err))
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+
+	actualDownloadsDownloadsDeployment, _, downloadsDeploymentErr := c.SyncDownloadsDeployment(ctx, operatorConfigCopy, infrastructureConfig, workloadConfig.Downloads, controllerContext)
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("DownloadsDeploymentSync", "FailedApply", downloadsDeploymentErr))
 	if downloadsDeploymentErr != nil {
 		return statusHandler.FlushAndReturn(downloadsDeploymentErr)
@@ -114,9 +133,9 @@ func (c *DownloadsDeploymentSyncController) Sync(ctx context.Context, controller
 	return statusHandler.FlushAndReturn(nil)
 }
 
-func (c *DownloadsDeploymentSyncController) SyncDownloadsDeployment(ctx context.Context, operatorConfigCopy *operatorv1.Console, infrastructureConfig *configv1.Infrastructure, controllerContext factory.SyncContext) (*appsv1.Deployment, bool, error) {
+func (c *DownloadsDeploymentSyncController) SyncDownloadsDeployment(ctx context.Context, operatorConfigCopy *operatorv1.Console, infrastructureConfig *configv1.Infrastructure, podOverrides *workload.PodOverrides, controllerContext factory.SyncContext) (*appsv1.Deployment, bool, error) {
 
-	requiredDownloadsDeployment := deploymentsub.DefaultDownloadsDeployment(operatorConfigCopy, infrastructureConfig)
+	requiredDownloadsDeployment := deploymentsub.DefaultDownloadsDeployment(operatorConfigCopy, infrastructureConfig, podOverrides)
 
 	return resourceapply.ApplyDeployment(ctx,
 		c.deploymentClient,
@@ -9,10 +9,8 @@ import (
 	"time"
 
 	// k8s
-
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coreinformersv1 "k8s.io/client-go/informers/core/v1"
-	coreclientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 
@@ -20,12 +18,12 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	operatorsv1 "github.com/openshift/api/operator/v1"
 	routev1 "github.com/openshift/api/route/v1"
-	configclientv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	configinformer "github.com/openshift/client-go/config/informers/externalversions"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
 	v1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
-	routeclientv1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
 	routesinformersv1 "github.com/openshift/client-go/route/informers/externalversions/route/v1"
+	routev1listers "github.com/openshift/client-go/route/listers/route/v1"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
@@ -34,6 +32,7 @@ import (
 	// console-operator
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
 )
@@ -42,19 +41,15 @@ type HealthCheckController struct {
 	// clients
 	operatorClient       v1helpers.OperatorClient
 	operatorConfigLister operatorv1listers.ConsoleLister
-	infrastructureClient configclientv1.InfrastructureInterface
-	ingressClient        configclientv1.IngressInterface
-	routeClient          routeclientv1.RoutesGetter
-	configMapClient      coreclientv1.ConfigMapsGetter
+	infrastructureLister configlistersv1.InfrastructureLister
+	ingressConfigLister  configlistersv1.IngressLister
+	routeLister          routev1listers.RouteLister
+	configMapLister      corev1listers.ConfigMapLister
 }
 
 func NewHealthCheckController(
-	// top level config
-	configClient configclientv1.ConfigV1Interface,
 	// clients
 	operatorClient v1helpers.OperatorClient,
-	routev1Client routeclientv1.RoutesGetter,
-	configMapClient coreclientv1.ConfigMapsGetter,
 	// informers
 	operatorConfigInformer v1.ConsoleInformer,
 	configInformer configinformer.SharedInformerFactory,
@@ -63,18 +58,18 @@ func NewHealthCheckController(
 	// events
 	recorder events.Recorder,
 ) factory.Controller {
+	configMapInformer := coreInformer.ConfigMaps()
+	configV1Informers := configInformer.Config().V1()
+
 	ctrl := &HealthCheckController{
 		operatorClient:       operatorClient,
 		operatorConfigLister: operatorConfigInformer.Lister(),
-		infrastructureClient: configClient.Infrastructures(),
-		ingressClient:        configClient.Ingresses(),
-		routeClient:          routev1Client,
-		configMapClient:      configMapClient,
+		infrastructureLister: configV1Informers.Infrastructures().Lister(),
+		ingressConfigLister:  configV1Informers.Ingresses().Lister(),
+		routeLister:          routeInformer.Lister(),
+		configMapLister:      configMapInformer.Lister(),
 	}
 
-	configMapInformer := coreInformer.ConfigMaps()
-	configV1Informers := configInformer.Config().V1()
-
 	return factory.New().
 		WithFilteredEventsInformers( // configs
 			util.IncludeNamesFilter(api.ConfigResourceName),
@@ -87,7 +82,7 @@ func NewHealthCheckController(
 	).WithFilteredEventsInformers( // route
 		util.IncludeNamesFilter(api.OpenShiftConsoleRouteName, api.OpenshiftConsoleCustomRouteName),
 		routeInformer.Informer(),
-	).ResyncEvery(30*time.Second).WithSync(ctrl.Sync).
+	).ResyncEvery(30*time.Second).WithSync(metrics.InstrumentSync("health-check-controller", ctrl.Sync)).
 		ToController("HealthCheckController", recorder.WithComponentSuffix("health-check-controller"))
 }
 
@@ -113,12 +108,12 @@ func (c *HealthCheckController) Sync(ctx context.Context, controllerContext fact
 	default:
 		return fmt.Errorf("unknown state: %v", updatedOperatorConfig.Spec.ManagementState)
 	}
-	ingressConfig, err := c.ingressClient.Get(ctx, api.ConfigResourceName, metav1.GetOptions{})
+	ingressConfig, err := c.ingressConfigLister.Get(api.ConfigResourceName)
 	if err != nil {
 		klog.Errorf("ingress config error: %v", err)
 		return statusHandler.FlushAndReturn(err)
 	}
-	infrastructureConfig, err := c.infrastructureClient.Get(ctx, api.ConfigResourceName, metav1.GetOptions{})
+	infrastructureConfig, err := c.infrastructureLister.Get(api.ConfigResourceName)
 	if err != nil {
 		klog.Errorf("infrastructure config error: %v", err)
 		return statusHandler.FlushAndReturn(err)
@@ -136,10 +131,10 @@ func (c *HealthCheckController) Sync(ctx context.Context, controllerContext fact
 		activeRouteName = api.OpenshiftConsoleCustomRouteName
 	}
 
-	activeRoute, activeRouteErr := c.routeClient.Routes(api.OpenShiftConsoleNamespace).Get(ctx, activeRouteName, metav1.GetOptions{})
+	activeRoute, activeRouteErr := c.routeLister.Routes(api.OpenShiftConsoleNamespace).Get(activeRouteName)
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("RouteHealth", "FailedRouteGet", activeRouteErr))
 	if activeRouteErr != nil {
-		statusHandler.FlushAndReturn(activeRouteErr)
+		return statusHandler.FlushAndReturn(activeRouteErr)
 	}
 
 	routeHealthCheckErrReason, routeHealthCheckErr := c.CheckRouteHealth(ctx, updatedOperatorConfig, activeRoute)
@@ -201,7 +196,7 @@ func (c *HealthCheckController) getCA(ctx context.Context, tls *routev1.TLSConfi
 	}
 
 	for _, cmName := range []string{api.TrustedCAConfigMapName, api.DefaultIngressCertConfigMapName} {
-		cm, err := c.configMapClient.ConfigMaps(api.OpenShiftConsoleNamespace).Get(ctx, cmName, metav1.GetOptions{})
+		cm, err := c.configMapLister.ConfigMaps(api.OpenShiftConsoleNamespace).Get(cmName)
 		if err != nil {
 			klog.V(4).Infof("failed to GET configmap %s / %s ", api.OpenShiftConsoleNamespace, cmName)
 			return nil, err
@@ -6,9 +6,12 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	appsv1informers "k8s.io/client-go/informers/apps/v1"
 	corev1informers "k8s.io/client-go/informers/core/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -30,7 +33,9 @@ import (
 
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
+	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
 	oauthsub "github.com/openshift/console-operator/pkg/console/subresource/oauthclient"
 	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
 	secretsub "github.com/openshift/console-operator/pkg/console/subresource/secret"
@@ -40,6 +45,10 @@ import (
 //
 //	updates:
 //	- oauthclient.oauth.openshift.io/console (created by CVO)
+//	  .secret with the client secret of console-oauth-config, the secrets it
+//	  replaced are kept in .additionalSecrets until the console runs with it
+//	  .redirectURIs with the callbacks of the console routes and hostname aliases
+//	  the token lifetimes and scope restrictions with the declared token policy
 //	writes:
 //	- consoles.operator.openshift.io/cluster .status.conditions:
 //		- type=OAuthClientSyncProgressing
@@ -55,6 +64,7 @@ type oauthClientsController struct {
 	routesLister                routev1listers.RouteLister
 	ingressConfigLister         configv1lister.IngressLister
 	targetNSSecretsLister       corev1listers.SecretLister
+	targetNSDeploymentsLister   appsv1listers.DeploymentLister
 }
 
 func NewOAuthClientsController(
@@ -65,6 +75,7 @@ func NewOAuthClientsController(
 	routeInformer routev1informers.RouteInformer,
 	ingressConfigInformer configv1informers.IngressInformer,
 	targetNSsecretsInformer corev1informers.SecretInformer,
+	targetNSDeploymentsInformer appsv1informers.DeploymentInformer,
 	oauthClientSwitchedInformer *util.InformerWithSwitch,
 	recorder events.Recorder,
 ) factory.Controller {
@@ -79,10 +90,11 @@ func NewOAuthClientsController(
 		routesLister:                routeInformer.Lister(),
 		ingressConfigLister:         ingressConfigInformer.Lister(),
 		targetNSSecretsLister:       targetNSsecretsInformer.Lister(),
+		targetNSDeploymentsLister:   targetNSDeploymentsInformer.Lister(),
 	}
 
 	return factory.New().
-		WithSync(c.sync).
+		WithSync(metrics.InstrumentSync("oauth-clients-controller", c.sync)).
 		WithInformers(
 			authnInformer.Informer(),
 			consoleOperatorInformer.Informer(),
@@ -94,6 +106,10 @@ func NewOAuthClientsController(
 			factory.NamesFilter(api.OAuthClientName),
 			oauthClientSwitchedInformer.Informer(),
 		).
+		WithFilteredEventsInformers(
+			factory.NamesFilter(api.OpenShiftConsoleDeploymentName),
+			targetNSDeploymentsInformer.Informer(),
+		).
 		WithSyncDegradedOnError(operatorClient).
 		ResyncEvery(wait.Jitter(time.Minute, 1.0)).
 		ToController("OAuthClientsController", recorder.WithComponentSuffix("oauth-clients-controller"))
@@ -153,7 +169,17 @@ func (c *oauthClientsController) sync(ctx context.Context, controllerContext fac
 		return err
 	}
 
-	oauthErrReason, err := c.syncOAuthClient(ctx, clientSecret, consoleURL.String())
+	hostnameAliases, hostnameAliasesErr := routesub.GetHostnameAliases(operatorConfig)
+	statusHandler.AddCondition(status.HandleDegraded("HostnameAliases", "InvalidHostnameAliases", hostnameAliasesErr))
+
+	// the active route comes first, logins started from the other hostnames of the
+	// console are redirected back to them as well
+	hosts := append([]string{consoleURL.Host}, routeConfig.GetConsoleHostnames(hostnameAliases)...)
+	// an invalid token policy leaves the one of the OAuthClient in place
+	tokenPolicy, tokenPolicyErr := oauthsub.GetTokenPolicy(operatorConfig)
+	statusHandler.AddCondition(status.HandleDegraded("OAuthClientTokenPolicy", "InvalidTokenPolicy", tokenPolicyErr))
+
+	oauthErrReason, err := c.syncOAuthClient(ctx, clientSecret, hosts, tokenPolicy)
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("OAuthClientSync", oauthErrReason, err))
 	if err != nil {
 		return statusHandler.FlushAndReturn(err)
@@ -191,15 +217,23 @@ func (c *oauthClientsController) handleManaged(ctx context.Context) (bool, error
 func (c *oauthClientsController) syncOAuthClient(
 	ctx context.Context,
 	sec *corev1.Secret,
-	consoleURL string,
+	hosts []string,
+	tokenPolicy *oauthsub.TokenPolicy,
 ) (reason string, err error) {
 	oauthClient, err := c.oauthClientLister.Get(oauthsub.Stub().Name)
 	if err != nil {
 		// at this point we must die & wait for someone to fix the lack of an outhclient. there is nothing we can do.
 		return "FailedGet", fmt.Errorf("oauth client for console does not exist and cannot be created (%w)", err)
 	}
+	rolledOut, err := c.isClientSecretRolledOut(sec)
+	if err != nil {
+		return "FailedDeploymentGet", err
+	}
 	clientCopy := oauthClient.DeepCopy()
-	oauthsub.RegisterConsoleToOAuthClient(clientCopy, consoleURL, secretsub.GetSecretString(sec))
+	oauthsub.RegisterConsoleToOAuthClient(clientCopy, hosts, secretsub.GetSecretString(sec), rolledOut)
+	if tokenPolicy != nil {
+		oauthsub.SetTokenPolicy(clientCopy, tokenPolicy)
+	}
 	_, _, oauthErr := oauthsub.CustomApplyOAuth(c.oauthClient, clientCopy, ctx)
 	if oauthErr != nil {
 		return "FailedRegister", oauthErr
@@ -207,6 +241,20 @@ func (c *oauthClientsController) syncOAuthClient(
 	return "", nil
 }
 
+// isClientSecretRolledOut returns whether every console pod runs with the given
+// client secret, and so whether the secrets it replaced can be dropped.
+func (c *oauthClientsController) isClientSecretRolledOut(sec *corev1.Secret) (bool, error) {
+	depl, err := c.targetNSDeploymentsLister.Deployments(api.OpenShiftConsoleNamespace).Get(api.OpenShiftConsoleDeploymentName)
+	if apierrors.IsNotFound(err) {
+		// no console pod runs with any secret yet
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return deploymentsub.IsAvailableAndUpdated(depl) && deploymentsub.IsRunningOAuthClientSecret(depl, sec), nil
+}
+
 func (c *oauthClientsController) deregisterClient(ctx context.Context) error {
 	// existingOAuthClient is not a delete, it is a deregister/neutralize
 	existingOAuthClient, err := c.oauthClientLister.Get(oauthsub.Stub().Name)
@@ -0,0 +1,71 @@
+package oauthclients
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
+	secretsub "github.com/openshift/console-operator/pkg/console/subresource/secret"
+)
+
+func TestIsClientSecretRolledOut(t *testing.T) {
+	previousSecret := secretsub.DefaultSecret(&operatorv1.Console{}, "previous")
+	currentSecret := secretsub.DefaultSecret(&operatorv1.Console{}, "current")
+	deployment := func(clientSecret *corev1.Secret, replicas, updatedReplicas, availableReplicas int32) *appsv1.Deployment {
+		d := deploymentsub.DefaultDeployment(&operatorv1.Console{}, &corev1.ConfigMap{}, &corev1.ConfigMap{}, nil, nil, &corev1.ConfigMap{}, clientSecret, nil, nil, nil, &configv1.Proxy{}, &configv1.Infrastructure{}, false, nil, nil)
+		d.Status.Replicas = replicas
+		d.Status.UpdatedReplicas = updatedReplicas
+		d.Status.AvailableReplicas = availableReplicas
+		return d
+	}
+
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		want       bool
+	}{
+		{
+			name: "Test no console deployment",
+			want: true,
+		},
+		{
+			name:       "Test console still running the previous secret",
+			deployment: deployment(previousSecret, 2, 2, 2),
+		},
+		{
+			name:       "Test console rolling out the current secret",
+			deployment: deployment(currentSecret, 3, 1, 2),
+		},
+		{
+			name:       "Test console rolled out with the current secret",
+			deployment: deployment(currentSecret, 2, 2, 2),
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if tt.deployment != nil {
+				if err := indexer.Add(tt.deployment); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c := &oauthClientsController{targetNSDeploymentsLister: appsv1listers.NewDeploymentLister(indexer)}
+
+			got, err := c.isClientSecretRolledOut(currentSecret)
+			if err != nil {
+				t.Fatalf("isClientSecretRolledOut() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isClientSecretRolledOut() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package oauthclientsecret
 import (
 	"context"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1clients "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -17,6 +19,7 @@ import (
 	operatorv1informers "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
 	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	"github.com/openshift/console-operator/pkg/crypto"
 	"github.com/openshift/library-go/pkg/controller/factory"
@@ -37,6 +40,11 @@ import (
 //
 // The secret written is 'openshift-console/console-oauth-config' in .Data['clientSecret']
 //
+// With IntegratedOAuth the client secret is generated anew every rotation
+// interval set on the operator config, and whenever the rotation token set on
+// it changes. The oauthClientsController keeps the previous secret valid until
+// the console runs with the new one.
+//
 // ==========
 //
 //	writes:
@@ -44,6 +52,7 @@ import (
 //	- consoles.operator.openshift.io/cluster .status.conditions:
 //		- type=OAuthClientSecretSyncProgressing
 //		- type=OAuthClientSecretSyncDegraded
+//		- type=OAuthClientSecretRotationDegraded
 type oauthClientSecretController struct {
 	operatorClient v1helpers.OperatorClient
 	secretsClient  corev1clients.SecretsGetter
@@ -74,7 +83,7 @@ func NewOAuthClientSecretController(
 	}
 
 	return factory.New().
-		WithSync(c.sync).
+		WithSync(metrics.InstrumentSync("oauthclient-secret-controller", c.sync)).
 		WithInformers(
 			authnInformer.Informer(),
 			consoleOperatorInformer.Informer(),
@@ -105,18 +114,41 @@ func (c *oauthClientSecretController) sync(ctx context.Context, syncCtx factory.
 		return fmt.Errorf("failed to retrieve authentication config: %w", err)
 	}
 
+	operatorConfig, err := c.consoleOperatorLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return err
+	}
+
+	// the client secret is only rotated with IntegratedOAuth, the rotation
+	// condition is cleared with the other authentication types
+	statusHandler.AddCondition(status.HandleDegraded("OAuthClientSecretRotation", "", nil))
+
 	var secretString string
+	var rotatedAt time.Time
 	switch authConfig.Spec.Type {
 	case "", configv1.AuthenticationTypeIntegratedOAuth:
 		// in OpenShift controlled world, we generate the client secret ourselves
-		if clientSecret != nil {
+		rotationInterval, rotationIntervalErr := secretsub.GetRotationInterval(operatorConfig, api.OAuthClientSecretRotationIntervalAnnotation, minRotationInterval)
+		statusHandler.AddCondition(status.HandleDegraded("OAuthClientSecretRotation", "InvalidRotationInterval", rotationIntervalErr))
+
+		now := time.Now()
+		rotationReason, nextRotation := rotationDue(operatorConfig, clientSecret, rotationInterval, now)
+		if len(rotationReason) == 0 {
 			secretString = secretsub.GetSecretString(clientSecret)
+			if !nextRotation.IsZero() {
+				syncCtx.Queue().AddAfter(syncCtx.QueueKey(), nextRotation.Sub(now))
+			}
+			break
 		}
-		if len(secretString) == 0 {
-			secretString = crypto.Random256BitsString()
-		}
+		klog.V(2).Infof("rotating the OAuth client secret: %s", rotationReason)
+		secretString = crypto.Random256BitsString()
+		rotatedAt = now
 	case configv1.AuthenticationTypeOIDC:
-		_, clientConfig := utilsub.GetOIDCClientConfig(authConfig)
+		_, clientConfig, err := utilsub.GetOIDCClientConfig(authConfig)
+		if err != nil {
+			statusHandler.AddConditions(status.HandleProgressingOrDegraded("OAuthClientSecretSync", "AmbiguousOIDCClientConfig", err))
+			return statusHandler.FlushAndReturn(err)
+		}
 		if clientConfig == nil {
 			// no config, flush the condition and return
 			statusHandler.AddConditions(status.HandleProgressingOrDegraded("OAuthClientSecretSync", "", nil))
@@ -146,20 +178,19 @@ func (c *oauthClientSecretController) sync(ctx context.Context, syncCtx factory.
 		return statusHandler.FlushAndReturn(nil)
 	}
 
-	err = c.syncSecret(ctx, secretString, syncCtx.Recorder())
+	requiredSecret := secretsub.DefaultSecret(operatorConfig, secretString)
+	if !rotatedAt.IsZero() {
+		secretsub.SetRotated(requiredSecret, operatorConfig.GetAnnotations()[api.RotateOAuthClientSecretAnnotation], rotatedAt)
+	}
+	err = c.syncSecret(ctx, requiredSecret, syncCtx.Recorder())
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("OAuthClientSecretSync", "FailedApply", err))
 	return statusHandler.FlushAndReturn(err)
 }
 
-func (c *oauthClientSecretController) syncSecret(ctx context.Context, clientSecret string, recorder events.Recorder) error {
-	operatorConfig, err := c.consoleOperatorLister.Get(api.ConfigResourceName)
-	if err != nil {
-		return err
-	}
-
+func (c *oauthClientSecretController) syncSecret(ctx context.Context, requiredSecret *corev1.Secret, recorder events.Recorder) error {
 	secret, err := c.targetNSSecretsLister.Secrets(api.TargetNamespace).Get("console-oauth-config")
-	if apierrors.IsNotFound(err) || secretsub.GetSecretString(secret) != clientSecret {
-		_, _, err = resourceapply.ApplySecret(ctx, c.secretsClient, recorder, secretsub.DefaultSecret(operatorConfig, clientSecret))
+	if apierrors.IsNotFound(err) || secretsub.GetSecretString(secret) != secretsub.GetSecretString(requiredSecret) {
+		_, _, err = resourceapply.ApplySecret(ctx, c.secretsClient, recorder, requiredSecret)
 	}
 	return err
 }
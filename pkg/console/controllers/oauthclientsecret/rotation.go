@@ -0,0 +1,25 @@
+package oauthclientsecret
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+	secretsub "github.com/openshift/console-operator/pkg/console/subresource/secret"
+)
+
+// minRotationInterval keeps the scheduled rotations from outpacing the
+// rollouts of the console.
+const minRotationInterval = time.Hour
+
+// rotationDue returns why the client secret has to be generated anew, if it
+// has to, and otherwise when its next scheduled rotation is due, if any.
+func rotationDue(operatorConfig *operatorv1.Console, secret *corev1.Secret, interval time.Duration, now time.Time) (string, time.Time) {
+	if secret == nil || len(secretsub.GetSecretString(secret)) == 0 {
+		return "generated", time.Time{}
+	}
+	return secretsub.RotationDue(secret, operatorConfig.GetAnnotations()[api.RotateOAuthClientSecretAnnotation], interval, now)
+}
@@ -0,0 +1,83 @@
+package oauthclientsecret
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+	secretsub "github.com/openshift/console-operator/pkg/console/subresource/secret"
+)
+
+func TestRotationDue(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	secret := func(annotations map[string]string) *corev1.Secret {
+		s := secretsub.SetSecretString(secretsub.Stub(), "secret")
+		s.Annotations = annotations
+		s.CreationTimestamp = metav1.NewTime(now.Add(-48 * time.Hour))
+		return s
+	}
+
+	tests := []struct {
+		name               string
+		configAnnotations  map[string]string
+		secret             *corev1.Secret
+		interval           time.Duration
+		wantReason         string
+		wantNextRotationAt time.Time
+	}{
+		{
+			name:       "Test missing secret",
+			wantReason: "generated",
+		},
+		{
+			name:   "Test secret without rotation",
+			secret: secret(nil),
+		},
+		{
+			name:              "Test requested rotation",
+			configAnnotations: map[string]string{api.RotateOAuthClientSecretAnnotation: "1"},
+			secret:            secret(nil),
+			wantReason:        "requested",
+		},
+		{
+			name:              "Test handled rotation request",
+			configAnnotations: map[string]string{api.RotateOAuthClientSecretAnnotation: "1"},
+			secret:            secret(map[string]string{api.SecretRotationRequestAnnotation: "1"}),
+		},
+		{
+			name:               "Test scheduled rotation not due",
+			secret:             secret(map[string]string{api.SecretRotatedAtAnnotation: now.Add(-time.Hour).Format(time.RFC3339)}),
+			interval:           24 * time.Hour,
+			wantNextRotationAt: now.Add(23 * time.Hour),
+		},
+		{
+			name:       "Test scheduled rotation due",
+			secret:     secret(map[string]string{api.SecretRotatedAtAnnotation: now.Add(-25 * time.Hour).Format(time.RFC3339)}),
+			interval:   24 * time.Hour,
+			wantReason: "scheduled",
+		},
+		{
+			name:       "Test scheduled rotation due since the creation of the secret",
+			secret:     secret(nil),
+			interval:   24 * time.Hour,
+			wantReason: "scheduled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.configAnnotations}}
+			reason, nextRotationAt := rotationDue(operatorConfig, tt.secret, tt.interval, now)
+			if reason != tt.wantReason {
+				t.Errorf("rotationDue() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if !nextRotationAt.Equal(tt.wantNextRotationAt) {
+				t.Errorf("rotationDue() next rotation = %v, want %v", nextRotationAt, tt.wantNextRotationAt)
+			}
+		})
+	}
+}
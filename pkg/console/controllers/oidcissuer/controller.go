@@ -0,0 +1,330 @@
+package oidcissuer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	// kube
+	apiexensionsv1informers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions/apiextensions/v1"
+	apiexensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"golang.org/x/net/http/httpproxy"
+
+	// openshift
+	configv1 "github.com/openshift/api/config/v1"
+	consolev1 "github.com/openshift/api/console/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	configv1client "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
+	configinformer "github.com/openshift/client-go/config/informers/externalversions"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
+	consoleclientv1 "github.com/openshift/client-go/console/clientset/versioned/typed/console/v1"
+	operatorinformerv1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
+	operatorlistersv1 "github.com/openshift/client-go/operator/listers/operator/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+
+	// operator
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
+	"github.com/openshift/console-operator/pkg/console/status"
+	utilsub "github.com/openshift/console-operator/pkg/console/subresource/util"
+)
+
+// oidcIssuerController checks the OIDC issuer of the console client the way the
+// console connects to it, i.e. through the cluster proxy and trusting the CA
+// configured for the issuer.
+//
+//	writes:
+//	- authentication.config.openshift.io/cluster .status.oidcClients:
+//		- componentName=console
+//		- componentNamespace=openshift-console
+//		- conditions:
+//			- IssuerReachable
+//	- consoles.operator.openshift.io/cluster .status.conditions:
+//		- type=OIDCIssuerReachable
+//		- type=OIDCIssuerNotificationSyncProgressing
+//		- type=OIDCIssuerNotificationSyncDegraded
+//		- type=OIDCIssuerStatusHandlerProgressing
+//		- type=OIDCIssuerStatusHandlerDegraded
+//	- consolenotifications.console.openshift.io/oidc-issuer-unreachable, unless
+//	  the console.operator.openshift.io/oidc-issuer-notification-management-state
+//	  annotation of the operator config is Removed or Unmanaged
+type oidcIssuerController struct {
+	operatorClient            v1helpers.OperatorClient
+	consoleNotificationClient consoleclientv1.ConsoleNotificationInterface
+
+	authnLister             configlistersv1.AuthenticationLister
+	proxyConfigLister       configlistersv1.ProxyLister
+	consoleOperatorLister   operatorlistersv1.ConsoleLister
+	crdLister               apiexensionsv1listers.CustomResourceDefinitionLister
+	configConfigMapLister   corev1listers.ConfigMapLister
+	targetNSConfigMapLister corev1listers.ConfigMapLister
+
+	authStatusHandler *status.AuthStatusHandler
+}
+
+func NewOIDCIssuerController(
+	operatorClient v1helpers.OperatorClient,
+	authenticationClient configv1client.AuthenticationInterface,
+	consoleNotificationClient consoleclientv1.ConsoleNotificationInterface,
+	configInformer configinformer.SharedInformerFactory,
+	consoleOperatorInformer operatorinformerv1.ConsoleInformer,
+	crdInformer apiexensionsv1informers.CustomResourceDefinitionInformer,
+	configConfigMapInformer corev1informers.ConfigMapInformer,
+	targetNSConfigMapInformer corev1informers.ConfigMapInformer,
+	recorder events.Recorder,
+) factory.Controller {
+	configV1Informers := configInformer.Config().V1()
+
+	c := &oidcIssuerController{
+		operatorClient:            operatorClient,
+		consoleNotificationClient: consoleNotificationClient,
+
+		authnLister:             configV1Informers.Authentications().Lister(),
+		proxyConfigLister:       configV1Informers.Proxies().Lister(),
+		consoleOperatorLister:   consoleOperatorInformer.Lister(),
+		crdLister:               crdInformer.Lister(),
+		configConfigMapLister:   configConfigMapInformer.Lister(),
+		targetNSConfigMapLister: targetNSConfigMapInformer.Lister(),
+
+		authStatusHandler: status.NewIssuerStatusHandler(authenticationClient, api.OpenShiftConsoleName, api.TargetNamespace, api.OIDCIssuerFieldManager),
+	}
+
+	// the issuer may go down at any time, the resync is the interval of the checks
+	return factory.New().
+		WithFilteredEventsInformers(
+			util.IncludeNamesFilter(api.ConfigResourceName),
+			configV1Informers.Authentications().Informer(),
+			configV1Informers.Proxies().Informer(),
+			consoleOperatorInformer.Informer(),
+		).WithFilteredEventsInformers(
+		factory.NamesFilter("authentications.config.openshift.io"),
+		crdInformer.Informer(),
+	).WithFilteredEventsInformers(
+		util.IncludeNamesFilter(api.TrustedCAConfigMapName),
+		targetNSConfigMapInformer.Informer(),
+	).WithInformers(
+		configConfigMapInformer.Informer(),
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync("oidc-issuer-controller", c.Sync)).
+		ToController("OIDCIssuerController", recorder.WithComponentSuffix("oidc-issuer-controller"))
+}
+
+func (c *oidcIssuerController) Sync(ctx context.Context, controllerContext factory.SyncContext) error {
+	operatorConfig, err := c.consoleOperatorLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return err
+	}
+
+	statusHandler := status.NewStatusHandler(c.operatorClient)
+
+	switch operatorConfig.Spec.ManagementState {
+	case operatorv1.Managed:
+		klog.V(4).Infoln("console is in a managed state: checking the OIDC issuer")
+	case operatorv1.Unmanaged:
+		klog.V(4).Infoln("console is in an unmanaged state: skipping the OIDC issuer checks")
+		return nil
+	case operatorv1.Removed:
+		klog.V(4).Infoln("console is in a removed state: removing the OIDC issuer notification")
+		err := c.removeNotification(ctx)
+		statusHandler.AddConditions(status.HandleProgressingOrDegraded("OIDCIssuerNotificationSync", "FailedDelete", err))
+		return statusHandler.FlushAndReturn(err)
+	default:
+		return fmt.Errorf("unknown state: %v", operatorConfig.Spec.ManagementState)
+	}
+
+	authnConfig, err := c.authnLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+
+	var (
+		oidcProvider *configv1.OIDCProvider
+		issuerReason string
+		issuerErr    error
+	)
+	if authnConfig.Spec.Type == configv1.AuthenticationTypeOIDC {
+		// the OIDCSetupController reports an ambiguous OIDC config
+		oidcProvider, _, _ = utilsub.GetOIDCClientConfig(authnConfig)
+	}
+	if oidcProvider != nil {
+		issuerReason, issuerErr = c.checkIssuer(ctx, oidcProvider)
+		if issuerErr != nil {
+			klog.V(4).Infof("OIDC issuer %q check failed: %v", oidcProvider.Issuer.URL, issuerErr)
+		}
+	}
+	// the issuer being down is not an error of the operator, it is checked again on the next resync
+	if oidcProvider != nil {
+		statusHandler.AddCondition(status.HandleReachable("OIDCIssuer", issuerReason, issuerErr))
+	} else {
+		statusHandler.AddCondition(status.ClearReachable("OIDCIssuer"))
+	}
+
+	notificationReason, notificationErr := c.syncNotification(ctx, operatorConfig, oidcProvider, issuerErr)
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("OIDCIssuerNotificationSync", notificationReason, notificationErr))
+	if notificationErr != nil {
+		return statusHandler.FlushAndReturn(notificationErr)
+	}
+
+	applyErr := c.applyAuthStatus(ctx, authnConfig, oidcProvider, issuerReason, issuerErr)
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("OIDCIssuerStatusHandler", "FailedApply", applyErr))
+	return statusHandler.FlushAndReturn(applyErr)
+}
+
+func (c *oidcIssuerController) checkIssuer(ctx context.Context, oidcProvider *configv1.OIDCProvider) (string, error) {
+	caPool, err := c.getCA(oidcProvider)
+	if err != nil {
+		return "FailedLoadCA", fmt.Errorf("failed to read the CA of the OIDC issuer: %w", err)
+	}
+	proxyConfig, err := c.proxyConfigLister.Get(api.ConfigResourceName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "FailedGetProxyConfig", err
+	}
+	return checkIssuer(ctx, clientFor(caPool, proxyConfig), oidcProvider.Issuer.URL)
+}
+
+// getCA returns the CA the console trusts the issuer with: the one configured
+// for the issuer, or the trusted CA bundle of the cluster.
+func (c *oidcIssuerController) getCA(oidcProvider *configv1.OIDCProvider) (*x509.CertPool, error) {
+	if caName := oidcProvider.Issuer.CertificateAuthority.Name; len(caName) > 0 {
+		caConfigMap, err := c.configConfigMapLister.ConfigMaps(api.OpenShiftConfigNamespace).Get(caName)
+		if err != nil {
+			return nil, err
+		}
+		caPool := x509.NewCertPool()
+		if ok := caPool.AppendCertsFromPEM([]byte(caConfigMap.Data[api.AuthServerCAFileName])); !ok {
+			return nil, fmt.Errorf("no certificate found in the %q key of the %s/%s configmap", api.AuthServerCAFileName, api.OpenShiftConfigNamespace, caName)
+		}
+		return caPool, nil
+	}
+
+	caPool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, err
+	}
+	trustedCAConfigMap, err := c.targetNSConfigMapLister.ConfigMaps(api.TargetNamespace).Get(api.TrustedCAConfigMapName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return caPool, nil
+		}
+		return nil, err
+	}
+	if ok := caPool.AppendCertsFromPEM([]byte(trustedCAConfigMap.Data[api.TrustedCABundleKey])); !ok {
+		klog.V(4).Infof("failed to parse %s %s", api.TrustedCAConfigMapName, api.TrustedCABundleKey)
+	}
+	return caPool, nil
+}
+
+func (c *oidcIssuerController) syncNotification(ctx context.Context, operatorConfig *operatorv1.Console, oidcProvider *configv1.OIDCProvider, issuerErr error) (string, error) {
+	switch managementState := util.GetManagementState(operatorConfig, api.OIDCIssuerNotificationManagementStateAnnotation); managementState {
+	case operatorv1.Managed:
+	case operatorv1.Unmanaged:
+		return "", nil
+	case operatorv1.Removed:
+		if err := c.removeNotification(ctx); err != nil {
+			return "FailedDelete", err
+		}
+		return "", nil
+	default:
+		return "UnknownManagementState", fmt.Errorf("unknown state: %v", managementState)
+	}
+
+	if oidcProvider == nil || issuerErr == nil {
+		if err := c.removeNotification(ctx); err != nil {
+			return "FailedDelete", err
+		}
+		return "", nil
+	}
+
+	required := &consolev1.ConsoleNotification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: api.OIDCIssuerConsoleNotification,
+		},
+		Spec: consolev1.ConsoleNotificationSpec{
+			Text:            fmt.Sprintf("The OIDC provider %s cannot be reached, logging in to the console may fail until it is reachable again.", oidcProvider.Name),
+			Location:        consolev1.BannerTop,
+			Color:           "#FFFFFF",
+			BackgroundColor: "#C9190B",
+		},
+	}
+	existing, err := c.consoleNotificationClient.Get(ctx, required.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := c.consoleNotificationClient.Create(ctx, required, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return "FailedCreate", err
+		}
+		return "", nil
+	}
+	if err != nil {
+		return "FailedGet", err
+	}
+	if equality.Semantic.DeepEqual(existing.Spec, required.Spec) {
+		return "", nil
+	}
+	updated := existing.DeepCopy()
+	updated.Spec = required.Spec
+	if _, err := c.consoleNotificationClient.Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return "FailedUpdate", err
+	}
+	return "", nil
+}
+
+func (c *oidcIssuerController) removeNotification(ctx context.Context) error {
+	err := c.consoleNotificationClient.Delete(ctx, api.OIDCIssuerConsoleNotification, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *oidcIssuerController) applyAuthStatus(ctx context.Context, authnConfig *configv1.Authentication, oidcProvider *configv1.OIDCProvider, issuerReason string, issuerErr error) error {
+	// see the OIDCSetupController, the status of the OIDC clients is feature gated
+	oidcClientsSchema, err := util.AuthnConfigHasOIDCFields(c.crdLister)
+	if err != nil || !oidcClientsSchema {
+		return err
+	}
+
+	switch {
+	case oidcProvider == nil:
+	case issuerErr != nil:
+		c.authStatusHandler.IssuerUnreachable(issuerReason, issuerErr.Error())
+	default:
+		c.authStatusHandler.IssuerReachable("AsExpected", "")
+	}
+	return c.authStatusHandler.Apply(ctx, authnConfig)
+}
+
+// clientFor returns a client which connects to the issuer the way the console
+// does, through the cluster proxy.
+func clientFor(caPool *x509.CertPool, proxyConfig *configv1.Proxy) *http.Client {
+	proxy := &httpproxy.Config{}
+	if proxyConfig != nil {
+		proxy.HTTPProxy = proxyConfig.Status.HTTPProxy
+		proxy.HTTPSProxy = proxyConfig.Status.HTTPSProxy
+		proxy.NoProxy = proxyConfig.Status.NoProxy
+	}
+	proxyFunc := proxy.ProxyFunc()
+
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				return proxyFunc(req.URL)
+			},
+			TLSClientConfig: &tls.Config{
+				RootCAs: caPool,
+			},
+		},
+	}
+}
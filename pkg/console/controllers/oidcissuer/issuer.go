@@ -0,0 +1,91 @@
+package oidcissuer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	wellKnownPath = "/.well-known/openid-configuration"
+	// bounds the size of the documents read from the issuer
+	maxDocumentBytes = 1 << 20
+)
+
+// discoveryDocument holds the fields of the OpenID provider metadata the console
+// depends on.
+type discoveryDocument struct {
+	Issuer        string `json:"issuer"`
+	JWKSURI       string `json:"jwks_uri"`
+	TokenEndpoint string `json:"token_endpoint"`
+}
+
+type jsonWebKeySet struct {
+	Keys []json.RawMessage `json:"keys"`
+}
+
+// checkIssuer fetches the discovery document and the JWKS of the issuer the way
+// the console does, and returns why the console would fail to log users in with
+// it, if it would.
+func checkIssuer(ctx context.Context, client *http.Client, issuerURL string) (string, error) {
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + wellKnownPath
+	discovery := &discoveryDocument{}
+	if err := getJSON(ctx, client, discoveryURL, discovery); err != nil {
+		return "DiscoveryFailed", fmt.Errorf("failed to get the discovery document of the OIDC issuer: %w", err)
+	}
+	if discovery.Issuer != issuerURL {
+		return "IssuerMismatch", fmt.Errorf("the discovery document at %s is for the issuer %q, expected %q", discoveryURL, discovery.Issuer, issuerURL)
+	}
+	if len(discovery.JWKSURI) == 0 || len(discovery.TokenEndpoint) == 0 {
+		return "InvalidDiscoveryDocument", fmt.Errorf("the discovery document at %s is missing the jwks_uri or the token_endpoint", discoveryURL)
+	}
+
+	keySet := &jsonWebKeySet{}
+	if err := getJSON(ctx, client, discovery.JWKSURI, keySet); err != nil {
+		return "JWKSFailed", fmt.Errorf("failed to get the JWKS of the OIDC issuer: %w", err)
+	}
+	if len(keySet.Keys) == 0 {
+		return "EmptyJWKS", fmt.Errorf("the JWKS at %s holds no keys", discovery.JWKSURI)
+	}
+
+	// codes are exchanged with a POST, any response tells the endpoint is reachable
+	if err := reach(ctx, client, discovery.TokenEndpoint); err != nil {
+		return "TokenEndpointUnreachable", fmt.Errorf("failed to reach the token endpoint of the OIDC issuer: %w", err)
+	}
+	return "", nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, into interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returns '%s'", url, resp.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDocumentBytes)).Decode(into); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+	return nil
+}
+
+func reach(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,83 @@
+package oidcissuer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckIssuer(t *testing.T) {
+	tests := []struct {
+		name       string
+		issuer     func(serverURL string) string
+		jwks       string
+		noJWKS     bool
+		noDocument bool
+		wantReason string
+	}{
+		{
+			name:   "Test reachable issuer",
+			issuer: func(serverURL string) string { return serverURL },
+			jwks:   `{"keys": [{"kty": "RSA", "kid": "test"}]}`,
+		},
+		{
+			name:       "Test missing discovery document",
+			issuer:     func(serverURL string) string { return serverURL },
+			noDocument: true,
+			wantReason: "DiscoveryFailed",
+		},
+		{
+			name:       "Test issuer mismatch",
+			issuer:     func(serverURL string) string { return "https://other.example.com" },
+			jwks:       `{"keys": [{"kty": "RSA", "kid": "test"}]}`,
+			wantReason: "IssuerMismatch",
+		},
+		{
+			name:       "Test missing JWKS",
+			issuer:     func(serverURL string) string { return serverURL },
+			noJWKS:     true,
+			wantReason: "JWKSFailed",
+		},
+		{
+			name:       "Test empty JWKS",
+			issuer:     func(serverURL string) string { return serverURL },
+			jwks:       `{"keys": []}`,
+			wantReason: "EmptyJWKS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewTLSServer(mux)
+			defer server.Close()
+
+			if !tt.noDocument {
+				mux.HandleFunc(wellKnownPath, func(w http.ResponseWriter, r *http.Request) {
+					json.NewEncoder(w).Encode(discoveryDocument{
+						Issuer:        tt.issuer(server.URL),
+						JWKSURI:       server.URL + "/keys",
+						TokenEndpoint: server.URL + "/token",
+					})
+				})
+			}
+			if !tt.noJWKS {
+				mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(tt.jwks))
+				})
+			}
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			})
+
+			reason, err := checkIssuer(context.Background(), server.Client(), server.URL)
+			if reason != tt.wantReason {
+				t.Errorf("checkIssuer() reason = %q, want %q, error: %v", reason, tt.wantReason, err)
+			}
+			if (err != nil) != (len(tt.wantReason) > 0) {
+				t.Errorf("checkIssuer() error = %v, want reason %q", err, tt.wantReason)
+			}
+		})
+	}
+}
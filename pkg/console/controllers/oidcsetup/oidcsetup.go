@@ -7,7 +7,6 @@ import (
 
 	configv1client "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	corev1 "k8s.io/api/core/v1"
-	apiexensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiexensionsv1informers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions/apiextensions/v1"
 	apiexensionsv1listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +26,8 @@ import (
 	operatorv1informers "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
 	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
@@ -96,7 +97,7 @@ func NewOIDCSetupController(
 		authStatusHandler: status.NewAuthStatusHandler(authenticationClient, api.OpenShiftConsoleName, api.TargetNamespace, api.OpenShiftConsoleOperator),
 	}
 	return factory.New().
-		WithSync(c.sync).
+		WithSync(metrics.InstrumentSync("oidc-setup-controller", c.sync)).
 		ResyncEvery(wait.Jitter(time.Minute, 1.0)).
 		WithFilteredEventsInformers(
 			factory.NamesFilter("authentications.config.openshift.io"),
@@ -122,7 +123,7 @@ func (c *oidcSetupController) sync(ctx context.Context, syncCtx factory.SyncCont
 		return nil
 	}
 
-	oidcClientsSchema, err := authnConfigHasOIDCFields(c.crdLister)
+	oidcClientsSchema, err := util.AuthnConfigHasOIDCFields(c.crdLister)
 	if err != nil {
 		return statusHandler.FlushAndReturn(err)
 	}
@@ -159,10 +160,10 @@ func (c *oidcSetupController) sync(ctx context.Context, syncCtx factory.SyncCont
 	// we need to keep track of errors during the sync so that we can requeue
 	// if any occur
 	var errs []error
-	syncErr := c.syncAuthTypeOIDC(ctx, authnConfig, operatorConfig, syncCtx.Recorder())
+	syncErrReason, syncErr := c.syncAuthTypeOIDC(ctx, authnConfig, operatorConfig, syncCtx.Recorder())
 	statusHandler.AddConditions(
 		status.HandleProgressingOrDegraded(
-			"OIDCClientConfig", "OIDCConfigSyncFailed",
+			"OIDCClientConfig", syncErrReason,
 			syncErr,
 		),
 	)
@@ -182,35 +183,40 @@ func (c *oidcSetupController) sync(ctx context.Context, syncCtx factory.SyncCont
 	return statusHandler.FlushAndReturn(nil)
 }
 
-func (c *oidcSetupController) syncAuthTypeOIDC(ctx context.Context, authnConfig *configv1.Authentication, operatorConfig *operatorv1.Console, recorder events.Recorder) error {
+func (c *oidcSetupController) syncAuthTypeOIDC(ctx context.Context, authnConfig *configv1.Authentication, operatorConfig *operatorv1.Console, recorder events.Recorder) (string, error) {
 
-	oidcProvider, clientConfig := utilsub.GetOIDCClientConfig(authnConfig)
+	oidcProvider, clientConfig, err := utilsub.GetOIDCClientConfig(authnConfig)
+	if err != nil {
+		c.authStatusHandler.WithCurrentOIDCClient(nil, "")
+		c.authStatusHandler.Degraded("AmbiguousOIDCClientConfig", err.Error())
+		return "AmbiguousOIDCClientConfig", err
+	}
 	if clientConfig == nil {
-		c.authStatusHandler.WithCurrentOIDCClient("")
+		c.authStatusHandler.WithCurrentOIDCClient(nil, "")
 		c.authStatusHandler.Unavailable("OIDCClientConfig", "no OIDC client found")
-		return nil
+		return "", nil
 	}
 
 	if len(clientConfig.ClientID) == 0 {
-		return fmt.Errorf("no ID set on console's OIDC client")
+		return "OIDCConfigSyncFailed", fmt.Errorf("no ID set on console's OIDC client")
 	}
-	c.authStatusHandler.WithCurrentOIDCClient(clientConfig.ClientID)
+	c.authStatusHandler.WithCurrentOIDCClient(oidcProvider, clientConfig.ClientID)
 
 	if len(clientConfig.ClientSecret.Name) == 0 {
 		c.authStatusHandler.Degraded("OIDCClientMissingSecret", "no client secret in the OIDC client config")
-		return nil
+		return "", nil
 	}
 
 	clientSecret, err := c.targetNSSecretsLister.Secrets(api.TargetNamespace).Get("console-oauth-config")
 	if err != nil {
 		c.authStatusHandler.Degraded("OIDCClientSecretGet", err.Error())
-		return err
+		return "OIDCConfigSyncFailed", err
 	}
 
 	if caCMName := oidcProvider.Issuer.CertificateAuthority.Name; len(caCMName) > 0 {
 		caCM, err := c.configConfigMapLister.ConfigMaps(api.OpenShiftConfigNamespace).Get(caCMName)
 		if err != nil {
-			return fmt.Errorf("failed to get the CA configMap %q configured for the OIDC provider %q: %w", caCMName, oidcProvider.Name, err)
+			return "OIDCConfigSyncFailed", fmt.Errorf("failed to get the CA configMap %q configured for the OIDC provider %q: %w", caCMName, oidcProvider.Name, err)
 		}
 
 		_, _, err = resourceapply.SyncPartialConfigMap(ctx,
@@ -222,28 +228,28 @@ func (c *oidcSetupController) syncAuthTypeOIDC(ctx context.Context, authnConfig
 			[]metav1.OwnerReference{*utilsub.OwnerRefFrom(operatorConfig)})
 
 		if err != nil {
-			return fmt.Errorf("failed to sync the provider's CA configMap: %w", err)
+			return "OIDCConfigSyncFailed", fmt.Errorf("failed to sync the provider's CA configMap: %w", err)
 		}
 	}
 
-	if valid, msg, err := c.checkClientConfigStatus(authnConfig, clientSecret); err != nil {
+	if valid, msg, err := c.checkClientConfigStatus(oidcProvider, clientSecret); err != nil {
 		c.authStatusHandler.Degraded("DeploymentOIDCConfig", err.Error())
-		return err
+		return "OIDCConfigSyncFailed", err
 
 	} else if !valid {
 		c.authStatusHandler.Progressing("DeploymentOIDCConfig", msg)
-		return nil
+		return "", nil
 	}
 
 	c.authStatusHandler.Available("OIDCConfigAvailable", "")
-	return nil
+	return "", nil
 }
 
 // checkClientConfigStatus checks whether the current client configuration is being currently in use,
 // by looking at the deployment status. It checks whether the deployment is available and updated,
-// and also whether the resource versions for the oauth secret and server CA trust configmap match
-// the deployment.
-func (c *oidcSetupController) checkClientConfigStatus(authnConfig *configv1.Authentication, clientSecret *corev1.Secret) (bool, string, error) {
+// and also whether the content of the oauth secret and server CA trust configmap is the one mounted
+// by the deployment.
+func (c *oidcSetupController) checkClientConfigStatus(oidcProvider *configv1.OIDCProvider, clientSecret *corev1.Secret) (bool, string, error) {
 	depl, err := c.targetNSDeploymentsLister.Deployments(api.OpenShiftConsoleNamespace).Get(api.OpenShiftConsoleDeploymentName)
 	if err != nil {
 		return false, "", err
@@ -254,22 +260,17 @@ func (c *oidcSetupController) checkClientConfigStatus(authnConfig *configv1.Auth
 		return false, "deployment unavailable or outdated", nil
 	}
 
-	if clientSecret.GetResourceVersion() != depl.ObjectMeta.Annotations["console.openshift.io/oauth-secret-version"] {
+	if !deploymentsub.IsRunningOAuthClientSecret(depl, clientSecret) {
 		return false, "client secret version not up to date in current deployment", nil
 	}
 
-	if len(authnConfig.Spec.OIDCProviders) > 0 {
-		serverCAConfigName := authnConfig.Spec.OIDCProviders[0].Issuer.CertificateAuthority.Name
-		if len(serverCAConfigName) == 0 {
-			return deplAvailableUpdated, "", nil
-		}
-
+	if serverCAConfigName := oidcProvider.Issuer.CertificateAuthority.Name; len(serverCAConfigName) > 0 {
 		serverCAConfig, err := c.targetNSConfigMapLister.ConfigMaps(api.OpenShiftConsoleNamespace).Get(serverCAConfigName)
 		if err != nil {
 			return false, "", err
 		}
 
-		if serverCAConfig.GetResourceVersion() != depl.ObjectMeta.Annotations["console.openshift.io/authn-ca-trust-config-version"] {
+		if !deploymentsub.IsRunningAuthnCATrust(depl, serverCAConfig) {
 			return false, "OIDC provider CA version not up to date in current deployment", nil
 		}
 	}
@@ -300,28 +301,3 @@ func (c *oidcSetupController) handleManaged() (bool, error) {
 		return false, fmt.Errorf("console is in an unknown state: %v", managementState)
 	}
 }
-
-func authnConfigHasOIDCFields(crdLister apiexensionsv1listers.CustomResourceDefinitionLister) (bool, error) {
-	authnCRD, err := crdLister.Get("authentications.config.openshift.io")
-	if err != nil {
-		return false, err
-	}
-
-	var authnV1Config *apiexensionsv1.CustomResourceDefinitionVersion
-	for _, version := range authnCRD.Spec.Versions {
-		if version.Name == "v1" && version.Served && version.Storage {
-			authnV1Config = &version
-			break
-		}
-	}
-
-	if authnV1Config == nil {
-		return false, fmt.Errorf("authentications.config.openshift.io is not served or stored as v1")
-	}
-
-	schema := authnV1Config.Schema.OpenAPIV3Schema
-	_, clientsExist := schema.Properties["status"].Properties["oidcClients"]
-
-	return clientsExist, nil
-
-}
@@ -9,8 +9,11 @@ import (
 	v1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	coreinformersv1 "k8s.io/client-go/informers/core/v1"
 	policyv1 "k8s.io/client-go/informers/policy/v1"
 	policyv1client "k8s.io/client-go/kubernetes/typed/policy/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 
 	// openshift
@@ -18,6 +21,7 @@ import (
 	operatorv1informers "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
 	"github.com/openshift/console-operator/bindata"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
@@ -27,15 +31,20 @@ import (
 	// console-operator
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/subresource/deployment"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
 
 	"github.com/openshift/library-go/pkg/operator/events"
 )
 
 type PodDisruptionBudgetController struct {
-	pdbName              string
-	operatorClient       v1helpers.OperatorClient
-	operatorConfigLister operatorv1listers.ConsoleLister
-	pdbClient            policyv1client.PodDisruptionBudgetsGetter
+	pdbName        string
+	operatorClient v1helpers.OperatorClient
+	// annotation holding the management state of the component owning the PDB
+	managementStateAnnotation string
+	operatorConfigLister      operatorv1listers.ConsoleLister
+	pdbClient                 policyv1client.PodDisruptionBudgetsGetter
+	configNSConfigMapLister   corev1listers.ConfigMapLister
 }
 
 func NewPodDisruptionBudgetController(
@@ -47,15 +56,18 @@ func NewPodDisruptionBudgetController(
 	pdbClient policyv1client.PodDisruptionBudgetsGetter,
 	// informer
 	pdbInformer policyv1.PodDisruptionBudgetInformer,
+	configNSConfigMapInformer coreinformersv1.ConfigMapInformer,
 	//events
 	recorder events.Recorder,
 ) factory.Controller {
 
 	ctrl := &PodDisruptionBudgetController{
-		pdbName:              pdbName,
-		operatorClient:       operatorClient,
-		operatorConfigLister: operatorConfigInformer.Lister(),
-		pdbClient:            pdbClient,
+		pdbName:                   pdbName,
+		operatorClient:            operatorClient,
+		managementStateAnnotation: util.GetManagementStateAnnotation(pdbName),
+		operatorConfigLister:      operatorConfigInformer.Lister(),
+		pdbClient:                 pdbClient,
+		configNSConfigMapLister:   configNSConfigMapInformer.Lister(),
 	}
 
 	return factory.New().
@@ -67,7 +79,11 @@ func NewPodDisruptionBudgetController(
 			util.IncludeNamesFilter(api.ConfigResourceName),
 			operatorConfigInformer.Informer(),
 		).
-		ResyncEvery(time.Minute).WithSync(ctrl.Sync).
+		WithFilteredEventsInformers( // workload config
+			util.IncludeNamesFilter(api.WorkloadConfigMapName),
+			configNSConfigMapInformer.Informer(),
+		).
+		ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync(fmt.Sprintf("%s-pdb-controller", pdbName), ctrl.Sync)).
 		ToController("PodDisruptionBudgetController", recorder.WithComponentSuffix(fmt.Sprintf("%s-pdb-controller", pdbName)))
 }
 
@@ -78,25 +94,31 @@ func (c *PodDisruptionBudgetController) Sync(ctx context.Context, controllerCont
 	}
 	updatedOperatorConfig := operatorConfig.DeepCopy()
 
-	switch updatedOperatorConfig.Spec.ManagementState {
+	switch managementState := util.GetManagementState(updatedOperatorConfig, c.managementStateAnnotation); managementState {
 	case operatorsv1.Managed:
-		klog.V(4).Infof("console-operator is in a managed state: syncing %q pdb", c.pdbName)
+		klog.V(4).Infof("%q pdb is in a managed state: syncing it", c.pdbName)
 	case operatorsv1.Unmanaged:
-		klog.V(4).Infof("console-operator is in an unmanaged state: skipping pdb %q sync", c.pdbName)
+		klog.V(4).Infof("%q pdb is in an unmanaged state: skipping its sync", c.pdbName)
 		return nil
 	case operatorsv1.Removed:
-		klog.V(4).Infof("console-operator is in a removed state: deleting %q pdb", c.pdbName)
+		klog.V(4).Infof("%q pdb is in a removed state: deleting it", c.pdbName)
 		if err = c.removePodDisruptionBudget(ctx); err != nil {
 			return err
 		}
 		return c.removePodDisruptionBudget(ctx)
 	default:
-		return fmt.Errorf("unknown state: %v", updatedOperatorConfig.Spec.ManagementState)
+		return fmt.Errorf("unknown state: %v", managementState)
 	}
 
 	statusHandler := status.NewStatusHandler(c.operatorClient)
 
-	requiredPDB := c.getDefaultPodDisruptionBudget()
+	workloadConfig, err := workload.GetConfig(c.configNSConfigMapLister, workload.AutoscalingSection)
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("PDBSync", "InvalidWorkloadConfig", err))
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+
+	requiredPDB := c.getDefaultPodDisruptionBudget(workloadConfig.Autoscaling)
 	_, _, pdbErr := resourceapply.ApplyPodDisruptionBudget(ctx, c.pdbClient, controllerContext.Recorder(), requiredPDB)
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("PDBSync", "FailedApply", pdbErr))
 	if pdbErr != nil {
@@ -114,8 +136,15 @@ func (c *PodDisruptionBudgetController) removePodDisruptionBudget(ctx context.Co
 	return err
 }
 
-// Load manifests and create the PDBs
-func (c *PodDisruptionBudgetController) getDefaultPodDisruptionBudget() *v1.PodDisruptionBudget {
+// Load manifests and create the PDBs. The disruptions allowed for an autoscaled
+// console grow along with its replicas, as for its rolling updates. An autoscaler
+// which may go down to a single replica keeps the single disruption of the
+// manifest, a share of one pod would round up to it anyway.
+func (c *PodDisruptionBudgetController) getDefaultPodDisruptionBudget(autoscaling *workload.Autoscaling) *v1.PodDisruptionBudget {
 	pdb := resourceread.ReadPodDisruptionBudgetV1OrDie(bindata.MustAsset(fmt.Sprintf("assets/pdb/%s-pdb.yaml", c.pdbName)))
+	if c.pdbName == api.OpenShiftConsoleName && autoscaling != nil && autoscaling.MinReplicas >= 2 {
+		maxUnavailable := intstr.FromString(deployment.AutoscaledRollingUpdatePercentage)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
 	return pdb
 }
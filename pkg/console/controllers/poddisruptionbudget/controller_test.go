@@ -0,0 +1,58 @@
+package pdb
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
+)
+
+func TestGetDefaultPodDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		name               string
+		pdbName            string
+		autoscaling        *workload.Autoscaling
+		wantMinAvailable   *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:               "Test console PDB",
+			pdbName:            api.OpenShiftConsolePDBName,
+			wantMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name:               "Test autoscaled console PDB",
+			pdbName:            api.OpenShiftConsolePDBName,
+			autoscaling:        &workload.Autoscaling{MinReplicas: 2, MaxReplicas: 6},
+			wantMaxUnavailable: ptr.To(intstr.FromString("25%")),
+		},
+		{
+			name:               "Test autoscaled console PDB down to a single replica",
+			pdbName:            api.OpenShiftConsolePDBName,
+			autoscaling:        &workload.Autoscaling{MinReplicas: 1, MaxReplicas: 6},
+			wantMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name:               "Test downloads PDB",
+			pdbName:            api.OpenShiftConsoleDownloadsPDBName,
+			autoscaling:        &workload.Autoscaling{MinReplicas: 1, MaxReplicas: 6},
+			wantMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &PodDisruptionBudgetController{pdbName: tt.pdbName}
+			pdb := c.getDefaultPodDisruptionBudget(tt.autoscaling)
+			if diff := deep.Equal(pdb.Spec.MinAvailable, tt.wantMinAvailable); diff != nil {
+				t.Errorf("minAvailable: %v", diff)
+			}
+			if diff := deep.Equal(pdb.Spec.MaxUnavailable, tt.wantMaxUnavailable); diff != nil {
+				t.Errorf("maxUnavailable: %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package publicconfig
+
+import (
+	// standard lib
+	"context"
+	"fmt"
+	"time"
+
+	// kube
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformersv1 "k8s.io/client-go/informers/core/v1"
+	coreclientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+
+	// openshift
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	configclientv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
+	configinformer "github.com/openshift/client-go/config/informers/externalversions"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
+	operatorinformerv1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
+	operatorlistersv1 "github.com/openshift/client-go/operator/listers/operator/v1"
+	routesinformersv1 "github.com/openshift/client-go/route/informers/externalversions/route/v1"
+	routev1listers "github.com/openshift/client-go/route/listers/route/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+
+	// operator
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
+	"github.com/openshift/console-operator/pkg/console/status"
+	configmapsub "github.com/openshift/console-operator/pkg/console/subresource/configmap"
+	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
+)
+
+// PublicConfigSyncController publishes the console URL, both in the status of
+// console.config.openshift.io and in the console-public ConfigMap in the
+// openshift-config-managed namespace, as soon as the console route is admitted.
+type PublicConfigSyncController struct {
+	operatorClient v1helpers.OperatorClient
+	// configs
+	consoleOperatorLister operatorlistersv1.ConsoleLister
+	consoleConfigClient   configclientv1.ConsoleInterface
+	consoleConfigLister   configlistersv1.ConsoleLister
+	ingressConfigLister   configlistersv1.IngressLister
+	// core kube
+	configMapClient coreclientv1.ConfigMapsGetter
+	// openshift
+	routeLister routev1listers.RouteLister
+}
+
+func NewPublicConfigSyncController(
+	// top level config
+	configClient configclientv1.ConfigV1Interface,
+	configInformer configinformer.SharedInformerFactory,
+	// clients
+	operatorClient v1helpers.OperatorClient,
+	configMapClient coreclientv1.ConfigMapsGetter,
+	// informers
+	operatorConfigInformer operatorinformerv1.ConsoleInformer,
+	managedNSConfigMapInformer coreinformersv1.ConfigMapInformer,
+	routeInformer routesinformersv1.RouteInformer,
+	// events
+	recorder events.Recorder,
+) factory.Controller {
+	configV1Informers := configInformer.Config().V1()
+
+	ctrl := &PublicConfigSyncController{
+		operatorClient: operatorClient,
+		// configs
+		consoleOperatorLister: operatorConfigInformer.Lister(),
+		consoleConfigClient:   configClient.Consoles(),
+		consoleConfigLister:   configV1Informers.Consoles().Lister(),
+		ingressConfigLister:   configV1Informers.Ingresses().Lister(),
+		// core kube
+		configMapClient: configMapClient,
+		// openshift
+		routeLister: routeInformer.Lister(),
+	}
+
+	return factory.New().
+		WithFilteredEventsInformers( // configs
+			util.IncludeNamesFilter(api.ConfigResourceName),
+			operatorConfigInformer.Informer(),
+			configV1Informers.Consoles().Informer(),
+			configV1Informers.Ingresses().Informer(),
+		).WithFilteredEventsInformers( // console routes
+		util.IncludeNamesFilter(api.OpenShiftConsoleRouteName, api.OpenshiftConsoleCustomRouteName),
+		routeInformer.Informer(),
+	).WithFilteredEventsInformers(
+		util.IncludeNamesFilter(api.OpenShiftConsolePublicConfigMapName),
+		managedNSConfigMapInformer.Informer(),
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync("console-public-config-controller", ctrl.Sync)).
+		ToController("ConsolePublicConfigController", recorder.WithComponentSuffix("console-public-config-controller"))
+}
+
+func (c *PublicConfigSyncController) Sync(ctx context.Context, controllerContext factory.SyncContext) error {
+	operatorConfig, err := c.consoleOperatorLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return err
+	}
+	operatorConfigCopy := operatorConfig.DeepCopy()
+
+	switch operatorConfigCopy.Spec.ManagementState {
+	case operatorv1.Managed:
+		klog.V(4).Infoln("console is in a managed state: syncing public console config")
+	case operatorv1.Unmanaged:
+		klog.V(4).Infoln("console is in an unmanaged state: skipping public console config sync")
+		return nil
+	case operatorv1.Removed:
+		klog.V(4).Infoln("console is in a removed state: clearing public console config")
+		return c.removePublicConfig(ctx, controllerContext.Recorder())
+	default:
+		return fmt.Errorf("unknown state: %v", operatorConfigCopy.Spec.ManagementState)
+	}
+
+	statusHandler := status.NewStatusHandler(c.operatorClient)
+
+	consoleConfig, err := c.consoleConfigLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+	ingressConfig, err := c.ingressConfigLister.Get(api.ConfigResourceName)
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+
+	routeName := api.OpenShiftConsoleRouteName
+	routeConfig := routesub.NewRouteConfig(operatorConfigCopy, ingressConfig, routeName)
+	if routeConfig.IsCustomHostnameSet() {
+		routeName = api.OpenshiftConsoleCustomRouteName
+	}
+	_, consoleURL, routeErrReason, routeErr := routesub.GetActiveRouteInfo(c.routeLister, routeName)
+	if routeErr != nil {
+		// nothing to publish until the route is admitted
+		statusHandler.AddCondition(status.HandleDegraded("ConsolePublicConfigMap", routeErrReason, routeErr))
+		return statusHandler.FlushAndReturn(routeErr)
+	}
+
+	consoleConfigStep := metrics.StartSyncStep("ConsoleConfig")
+	_, consoleConfigErr := c.SyncConsoleConfig(ctx, consoleConfig, consoleURL.String())
+	consoleConfigStep.Done("FailedUpdate", consoleConfigErr)
+	statusHandler.AddCondition(status.HandleDegraded("ConsoleConfig", "FailedUpdate", consoleConfigErr))
+	if consoleConfigErr != nil {
+		klog.Errorf("could not update console config status: %v", consoleConfigErr)
+	}
+
+	publicConfigStep := metrics.StartSyncStep("ConsolePublicConfigMap")
+	_, _, consolePublicConfigErr := c.SyncConsolePublicConfig(ctx, consoleURL.String(), controllerContext.Recorder())
+	publicConfigStep.Done("FailedApply", consolePublicConfigErr)
+	statusHandler.AddCondition(status.HandleDegraded("ConsolePublicConfigMap", "FailedApply", consolePublicConfigErr))
+	if consolePublicConfigErr != nil {
+		klog.Errorf("could not update public console config status: %v", consolePublicConfigErr)
+	}
+
+	if consoleConfigErr != nil {
+		return statusHandler.FlushAndReturn(consoleConfigErr)
+	}
+	return statusHandler.FlushAndReturn(consolePublicConfigErr)
+}
+
+func (c *PublicConfigSyncController) SyncConsoleConfig(ctx context.Context, consoleConfig *configv1.Console, consoleURL string) (*configv1.Console, error) {
+	oldURL := consoleConfig.Status.ConsoleURL
+	metrics.HandleConsoleURL(oldURL, consoleURL)
+	if oldURL != consoleURL {
+		klog.V(4).Infof("updating console.config.openshift.io with url: %v", consoleURL)
+		updated := consoleConfig.DeepCopy()
+		updated.Status.ConsoleURL = consoleURL
+		return c.consoleConfigClient.UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	}
+	return consoleConfig, nil
+}
+
+func (c *PublicConfigSyncController) SyncConsolePublicConfig(ctx context.Context, consoleURL string, recorder events.Recorder) (*corev1.ConfigMap, bool, error) {
+	requiredConfigMap := configmapsub.DefaultPublicConfig(consoleURL)
+	return resourceapply.ApplyConfigMap(ctx, c.configMapClient, recorder, requiredConfigMap)
+}
+
+// clear the console URL from the public config map in openshift-config-managed
+func (c *PublicConfigSyncController) removePublicConfig(ctx context.Context, recorder events.Recorder) error {
+	_, _, err := resourceapply.ApplyConfigMap(ctx, c.configMapClient, recorder, configmapsub.EmptyPublicConfig())
+	return err
+}
@@ -11,15 +11,15 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coreinformersv1 "k8s.io/client-go/informers/core/v1"
-	coreclientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 
 	// openshift
 	configv1 "github.com/openshift/api/config/v1"
 	operatorsv1 "github.com/openshift/api/operator/v1"
 	routev1 "github.com/openshift/api/route/v1"
-	configclientv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	configinformer "github.com/openshift/client-go/config/informers/externalversions"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
 	v1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
 	routeclientv1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
@@ -32,6 +32,7 @@ import (
 	// console-operator
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
 )
@@ -39,24 +40,24 @@ import (
 type RouteSyncController struct {
 	routeName            string
 	isHealthCheckEnabled bool
+	// annotation holding the management state of the component owning the route
+	managementStateAnnotation string
 	// clients
 	operatorClient       v1helpers.OperatorClient
 	operatorConfigLister operatorv1listers.ConsoleLister
-	ingressClient        configclientv1.IngressInterface
+	ingressConfigLister  configlistersv1.IngressLister
 	routeClient          routeclientv1.RoutesGetter
-	secretClient         coreclientv1.SecretsGetter
+	secretLister         corev1listers.SecretLister
 }
 
 func NewRouteSyncController(
 	routeName string,
 	isHealthCheckEnabled bool,
 	// top level config
-	configClient configclientv1.ConfigV1Interface,
 	configInformer configinformer.SharedInformerFactory,
 	// clients
 	operatorClient v1helpers.OperatorClient,
 	routev1Client routeclientv1.RoutesGetter,
-	secretClient coreclientv1.SecretsGetter,
 	// informers
 	operatorConfigInformer v1.ConsoleInformer,
 	secretInformer coreinformersv1.SecretInformer,
@@ -64,18 +65,19 @@ func NewRouteSyncController(
 	// events
 	recorder events.Recorder,
 ) factory.Controller {
+	configV1Informers := configInformer.Config().V1()
+
 	ctrl := &RouteSyncController{
-		routeName:            routeName,
-		isHealthCheckEnabled: isHealthCheckEnabled,
-		operatorClient:       operatorClient,
-		operatorConfigLister: operatorConfigInformer.Lister(),
-		ingressClient:        configClient.Ingresses(),
-		routeClient:          routev1Client,
-		secretClient:         secretClient,
+		routeName:                 routeName,
+		isHealthCheckEnabled:      isHealthCheckEnabled,
+		managementStateAnnotation: util.GetManagementStateAnnotation(routeName),
+		operatorClient:            operatorClient,
+		operatorConfigLister:      operatorConfigInformer.Lister(),
+		ingressConfigLister:       configV1Informers.Ingresses().Lister(),
+		routeClient:               routev1Client,
+		secretLister:              secretInformer.Lister(),
 	}
 
-	configV1Informers := configInformer.Config().V1()
-
 	return factory.New().
 		WithFilteredEventsInformers( // configs
 			util.IncludeNamesFilter(api.ConfigResourceName),
@@ -87,7 +89,7 @@ func NewRouteSyncController(
 	).WithFilteredEventsInformers( // route
 		util.IncludeNamesFilter(routeName, routesub.GetCustomRouteName(routeName)),
 		routeInformer.Informer(),
-	).ResyncEvery(time.Minute).WithSync(ctrl.Sync).
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync(fmt.Sprintf("%s-route-controller", routeName), ctrl.Sync)).
 		ToController(fmt.Sprintf("%sRouteController", strings.Title(routeName)), recorder.WithComponentSuffix(fmt.Sprintf("%s-route-controller", routeName)))
 }
 
@@ -98,25 +100,29 @@ func (c *RouteSyncController) Sync(ctx context.Context, controllerContext factor
 	}
 	updatedOperatorConfig := operatorConfig.DeepCopy()
 
-	switch updatedOperatorConfig.Spec.ManagementState {
+	statusHandler := status.NewStatusHandler(c.operatorClient)
+
+	switch managementState := util.GetManagementState(updatedOperatorConfig, c.managementStateAnnotation); managementState {
 	case operatorsv1.Managed:
-		klog.V(4).Infof("console-operator is in a managed state: syncing %q route", c.routeName)
+		klog.V(4).Infof("%q route is in a managed state: syncing it", c.routeName)
 	case operatorsv1.Unmanaged:
-		klog.V(4).Infof("console-operator is in an unmanaged state: skipping %q route sync", c.routeName)
+		klog.V(4).Infof("%q route is in an unmanaged state: skipping its sync", c.routeName)
 		return nil
 	case operatorsv1.Removed:
-		klog.V(4).Infof("console-operator is in a removed state: deleting %q route", c.routeName)
-		if err = c.removeRoute(ctx, routesub.GetCustomRouteName(c.routeName)); err != nil {
-			return err
+		klog.V(4).Infof("%q route is in a removed state: deleting it", c.routeName)
+		if err = c.removeRoute(ctx, routesub.GetCustomRouteName(c.routeName)); err == nil {
+			err = c.removeRoute(ctx, c.routeName)
+		}
+		for _, typePrefix := range []string{"CustomRouteSync", "DefaultRouteSync"} {
+			statusHandler.AddConditions(status.HandleProgressingOrDegraded(strings.Title(c.routeName)+typePrefix, "FailedDelete", err))
+			statusHandler.AddCondition(status.HandleUpgradable(strings.Title(c.routeName)+typePrefix, "FailedDelete", err))
 		}
-		return c.removeRoute(ctx, c.routeName)
+		return statusHandler.FlushAndReturn(err)
 	default:
-		return fmt.Errorf("unknown state: %v", updatedOperatorConfig.Spec.ManagementState)
+		return fmt.Errorf("unknown state: %v", managementState)
 	}
 
-	statusHandler := status.NewStatusHandler(c.operatorClient)
-
-	ingressConfig, err := c.ingressClient.Get(ctx, api.ConfigResourceName, metav1.GetOptions{})
+	ingressConfig, err := c.ingressConfigLister.Get(api.ConfigResourceName)
 	if err != nil {
 		return statusHandler.FlushAndReturn(err)
 	}
@@ -226,7 +232,7 @@ func (c *RouteSyncController) SyncCustomRoute(ctx context.Context, routeConfig *
 
 func (c *RouteSyncController) GetCustomRouteTLSSecret(ctx context.Context, routeConfig *routesub.RouteConfig) (*corev1.Secret, error) {
 	if routeConfig.IsCustomTLSSecretSet() {
-		customTLSSecret, customTLSSecretErr := c.secretClient.Secrets(api.OpenShiftConfigNamespace).Get(ctx, routeConfig.GetCustomTLSSecretName(), metav1.GetOptions{})
+		customTLSSecret, customTLSSecretErr := c.secretLister.Secrets(api.OpenShiftConfigNamespace).Get(routeConfig.GetCustomTLSSecretName())
 		if customTLSSecretErr != nil {
 			return nil, fmt.Errorf("failed to GET custom route TLS secret: %s", customTLSSecretErr)
 		}
@@ -246,7 +252,7 @@ func (c *RouteSyncController) GetDefaultRouteTLSSecret(ctx context.Context, rout
 		return nil, nil
 	}
 
-	secret, secretErr := c.secretClient.Secrets(api.OpenShiftConfigNamespace).Get(ctx, routeConfig.GetDefaultTLSSecretName(), metav1.GetOptions{})
+	secret, secretErr := c.secretLister.Secrets(api.OpenShiftConfigNamespace).Get(routeConfig.GetDefaultTLSSecretName())
 	if secretErr != nil {
 		return nil, fmt.Errorf("failed to GET default route TLS secret: %s", secretErr)
 	}
@@ -13,13 +13,14 @@ import (
 	"k8s.io/klog/v2"
 
 	operatorsv1 "github.com/openshift/api/operator/v1"
-	configclientv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	configinformer "github.com/openshift/client-go/config/informers/externalversions"
+	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
 	operatorinformersv1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
 	"github.com/openshift/console-operator/bindata"
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
 	"github.com/openshift/library-go/pkg/controller/factory"
@@ -33,10 +34,12 @@ import (
 // the informers will automatically notify it of changes
 // and kick the sync loop
 type ServiceSyncController struct {
-	serviceName          string
-	operatorClient       v1helpers.OperatorClient
-	operatorConfigLister operatorv1listers.ConsoleLister
-	ingressClient        configclientv1.IngressInterface
+	serviceName    string
+	operatorClient v1helpers.OperatorClient
+	// annotation holding the management state of the component owning the service
+	managementStateAnnotation string
+	operatorConfigLister      operatorv1listers.ConsoleLister
+	ingressConfigLister       configlistersv1.IngressLister
 	// live clients, we dont need listers w/caches
 	serviceClient coreclientv1.ServicesGetter
 }
@@ -46,7 +49,6 @@ type ServiceSyncController struct {
 func NewServiceSyncController(
 	serviceName string,
 	// top level config
-	configClient configclientv1.ConfigV1Interface,
 	configInformer configinformer.SharedInformerFactory,
 	// clients
 	operatorClient v1helpers.OperatorClient,
@@ -57,17 +59,17 @@ func NewServiceSyncController(
 	// events
 	recorder events.Recorder,
 ) factory.Controller {
+	configV1Informers := configInformer.Config().V1()
 
 	ctrl := &ServiceSyncController{
-		serviceName:          serviceName,
-		operatorClient:       operatorClient,
-		operatorConfigLister: operatorConfigInformer.Lister(),
-		ingressClient:        configClient.Ingresses(),
-		serviceClient:        corev1Client,
+		serviceName:               serviceName,
+		operatorClient:            operatorClient,
+		managementStateAnnotation: util.GetManagementStateAnnotation(serviceName),
+		operatorConfigLister:      operatorConfigInformer.Lister(),
+		ingressConfigLister:       configV1Informers.Ingresses().Lister(),
+		serviceClient:             corev1Client,
 	}
 
-	configV1Informers := configInformer.Config().V1()
-
 	return factory.New().
 		WithFilteredEventsInformers( // configs
 			util.IncludeNamesFilter(api.ConfigResourceName),
@@ -76,7 +78,7 @@ func NewServiceSyncController(
 		).WithFilteredEventsInformers( // console resources
 		util.IncludeNamesFilter(serviceName, ctrl.getRedirectServiceName()),
 		serviceInformer.Informer(),
-	).ResyncEvery(time.Minute).WithSync(ctrl.Sync).
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync(fmt.Sprintf("%s-service-controller", serviceName), ctrl.Sync)).
 		ToController("ConsoleServiceController", recorder.WithComponentSuffix("console-service-controller"))
 }
 
@@ -87,25 +89,25 @@ func (c *ServiceSyncController) Sync(ctx context.Context, controllerContext fact
 	}
 	updatedOperatorConfig := operatorConfig.DeepCopy()
 
-	switch updatedOperatorConfig.Spec.ManagementState {
+	switch managementState := util.GetManagementState(updatedOperatorConfig, c.managementStateAnnotation); managementState {
 	case operatorsv1.Managed:
-		klog.V(4).Infof("console-operator is in a managed state: syncing %q service", c.serviceName)
+		klog.V(4).Infof("%q service is in a managed state: syncing it", c.serviceName)
 	case operatorsv1.Unmanaged:
-		klog.V(4).Infof("console-operator is in an unmanaged state: skipping service %q sync", c.serviceName)
+		klog.V(4).Infof("%q service is in an unmanaged state: skipping its sync", c.serviceName)
 		return nil
 	case operatorsv1.Removed:
-		klog.V(4).Infof("console-operator is in a removed state: deleting %q service", c.serviceName)
+		klog.V(4).Infof("%q service is in a removed state: deleting it", c.serviceName)
 		if err = c.removeService(ctx, c.getRedirectServiceName()); err != nil {
 			return err
 		}
 		return c.removeService(ctx, c.serviceName)
 	default:
-		return fmt.Errorf("unknown state: %v", updatedOperatorConfig.Spec.ManagementState)
+		return fmt.Errorf("unknown state: %v", managementState)
 	}
 
 	statusHandler := status.NewStatusHandler(c.operatorClient)
 
-	ingressConfig, err := c.ingressClient.Get(ctx, api.ConfigResourceName, metav1.GetOptions{})
+	ingressConfig, err := c.ingressConfigLister.Get(api.ConfigResourceName)
 	if err != nil {
 		return statusHandler.FlushAndReturn(err)
 	}
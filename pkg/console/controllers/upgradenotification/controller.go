@@ -20,6 +20,7 @@ import (
 	operatorv1listers "github.com/openshift/client-go/operator/listers/operator/v1"
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
@@ -66,7 +67,10 @@ func NewUpgradeNotificationController(
 		WithFilteredEventsInformers( // configs
 			util.IncludeNamesFilter(api.VersionResourceName),
 			configV1Informers.ClusterVersions().Informer(),
-		).ResyncEvery(time.Minute).WithSync(ctrl.Sync).
+		).WithFilteredEventsInformers(
+		util.IncludeNamesFilter(api.ConfigResourceName),
+		operatorConfigInformer.Informer(),
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync("cluster-upgrade-notification-controller", ctrl.Sync)).
 		ToController("ClusterUpgradeNotificationController", recorder.WithComponentSuffix("cluster-upgrade-notification-controller"))
 }
 
@@ -77,21 +81,23 @@ func (c *UpgradeNotificationController) Sync(ctx context.Context, controllerCont
 	}
 	updatedOperatorConfig := operatorConfig.DeepCopy()
 
-	switch updatedOperatorConfig.Spec.ManagementState {
+	statusHandler := status.NewStatusHandler(c.operatorClient)
+
+	switch managementState := util.GetManagementState(updatedOperatorConfig, api.UpgradeNotificationManagementStateAnnotation); managementState {
 	case operatorsv1.Managed:
-		klog.V(4).Info("console-operator is in a managed state: syncing upgrade notification")
+		klog.V(4).Info("upgrade notification is in a managed state: syncing it")
 	case operatorsv1.Unmanaged:
-		klog.V(4).Info("console-operator is in an unmanaged state: skipping upgrade notification sync")
+		klog.V(4).Info("upgrade notification is in an unmanaged state: skipping its sync")
 		return nil
 	case operatorsv1.Removed:
-		klog.V(4).Info("console-operator is in a removed state: deleting upgrade notification")
-		return c.removeUpgradeNotification(ctx)
+		klog.V(4).Info("upgrade notification is in a removed state: deleting it")
+		err := c.removeUpgradeNotification(ctx)
+		statusHandler.AddConditions(status.HandleProgressingOrDegraded("ConsoleNotificationSync", "FailedDelete", err))
+		return statusHandler.FlushAndReturn(err)
 	default:
-		return fmt.Errorf("unknown state: %v", updatedOperatorConfig.Spec.ManagementState)
+		return fmt.Errorf("unknown state: %v", managementState)
 	}
 
-	statusHandler := status.NewStatusHandler(c.operatorClient)
-
 	reason, err := c.syncClusterUpgradeNotification(ctx)
 	if err != nil {
 		klog.V(4).Infof("error syncing %s consolenotification custom resource: %s", api.UpgradeConsoleNotification, err)
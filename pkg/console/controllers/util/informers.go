@@ -13,6 +13,7 @@ import (
 	oauthinformersv1 "github.com/openshift/client-go/oauth/informers/externalversions/oauth/v1"
 	oauthlistersv1 "github.com/openshift/client-go/oauth/listers/oauth/v1"
 	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
 
@@ -71,7 +72,7 @@ func NewSwitchedInformer(
 	}
 
 	s.switchController = factory.New().
-		WithSync(s.sync).
+		WithSync(metrics.InstrumentSync("informer-with-switch-controller", s.sync)).
 		WithInformers(authnInformer.Informer()).
 		ToController("InformerWithSwitchController", recorder.WithComponentSuffix("informer-with-switch-controller"))
 
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+var (
+	// ManagementStateAnnotations are the annotations of the operator config which
+	// manage the optional components of the console
+	ManagementStateAnnotations = []string{
+		api.CLIDownloadsManagementStateAnnotation,
+		api.DownloadsManagementStateAnnotation,
+		api.OIDCIssuerNotificationManagementStateAnnotation,
+		api.UpgradeNotificationManagementStateAnnotation,
+	}
+
+	componentManagementStates = sets.NewString(
+		string(operatorv1.Managed),
+		string(operatorv1.Unmanaged),
+		string(operatorv1.Removed),
+	)
+)
+
+// GetManagementState returns the management state of an optional console component,
+// e.g. the downloads, given the annotation of the operator config which holds it.
+// The component follows the console unless the console is Managed and the annotation
+// is set, an Unmanaged or Removed console takes its optional components along.
+// An invalid annotation leaves the component Unmanaged, it is reported through
+// ValidateManagementStateAnnotations.
+func GetManagementState(operatorConfig *operatorv1.Console, annotation string) operatorv1.ManagementState {
+	state := operatorConfig.Spec.ManagementState
+	if state != operatorv1.Managed || len(annotation) == 0 {
+		return state
+	}
+	componentState, ok := operatorConfig.GetAnnotations()[annotation]
+	if !ok {
+		return state
+	}
+	if !componentManagementStates.Has(componentState) {
+		return operatorv1.Unmanaged
+	}
+	return operatorv1.ManagementState(componentState)
+}
+
+// ValidateManagementStateAnnotations returns an error naming the management state
+// annotations of the operator config set to an invalid value, and their values.
+func ValidateManagementStateAnnotations(operatorConfig *operatorv1.Console) error {
+	invalid := []string{}
+	for _, annotation := range ManagementStateAnnotations {
+		componentState, ok := operatorConfig.GetAnnotations()[annotation]
+		if ok && !componentManagementStates.Has(componentState) {
+			invalid = append(invalid, fmt.Sprintf("%s=%q", annotation, componentState))
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid management state annotations %s, the components are left unmanaged: must be one of %s",
+		strings.Join(invalid, ", "), strings.Join(componentManagementStates.List(), ", "))
+}
+
+// GetManagementStateAnnotation returns the annotation which holds the management
+// state of the component owning the resource, or nothing if the resource belongs
+// to the console itself.
+func GetManagementStateAnnotation(resourceName string) string {
+	if resourceName == api.DownloadsResourceName {
+		return api.DownloadsManagementStateAnnotation
+	}
+	return ""
+}
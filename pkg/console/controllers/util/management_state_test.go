@@ -0,0 +1,143 @@
+package util
+
+import (
+	"errors"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+func TestGetManagementState(t *testing.T) {
+	type args struct {
+		managementState operatorv1.ManagementState
+		annotations     map[string]string
+		annotation      string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want operatorv1.ManagementState
+	}{
+		{
+			name: "Test component follows the managed console",
+			args: args{
+				managementState: operatorv1.Managed,
+				annotation:      api.DownloadsManagementStateAnnotation,
+			},
+			want: operatorv1.Managed,
+		},
+		{
+			name: "Test component removed while the console is managed",
+			args: args{
+				managementState: operatorv1.Managed,
+				annotations:     map[string]string{api.DownloadsManagementStateAnnotation: string(operatorv1.Removed)},
+				annotation:      api.DownloadsManagementStateAnnotation,
+			},
+			want: operatorv1.Removed,
+		},
+		{
+			name: "Test component unmanaged while the console is managed",
+			args: args{
+				managementState: operatorv1.Managed,
+				annotations:     map[string]string{api.DownloadsManagementStateAnnotation: string(operatorv1.Unmanaged)},
+				annotation:      api.DownloadsManagementStateAnnotation,
+			},
+			want: operatorv1.Unmanaged,
+		},
+		{
+			name: "Test component managed while the console is removed",
+			args: args{
+				managementState: operatorv1.Removed,
+				annotations:     map[string]string{api.DownloadsManagementStateAnnotation: string(operatorv1.Managed)},
+				annotation:      api.DownloadsManagementStateAnnotation,
+			},
+			want: operatorv1.Removed,
+		},
+		{
+			name: "Test component managed while the console is unmanaged",
+			args: args{
+				managementState: operatorv1.Unmanaged,
+				annotations:     map[string]string{api.DownloadsManagementStateAnnotation: string(operatorv1.Managed)},
+				annotation:      api.DownloadsManagementStateAnnotation,
+			},
+			want: operatorv1.Unmanaged,
+		},
+		{
+			name: "Test console resources ignore the component annotations",
+			args: args{
+				managementState: operatorv1.Managed,
+				annotations:     map[string]string{api.DownloadsManagementStateAnnotation: string(operatorv1.Removed)},
+				annotation:      GetManagementStateAnnotation(api.OpenShiftConsoleRouteName),
+			},
+			want: operatorv1.Managed,
+		},
+		{
+			name: "Test invalid component management state",
+			args: args{
+				managementState: operatorv1.Managed,
+				annotations:     map[string]string{api.UpgradeNotificationManagementStateAnnotation: "Deleted"},
+				annotation:      api.UpgradeNotificationManagementStateAnnotation,
+			},
+			want: operatorv1.Unmanaged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.Console{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.args.annotations},
+				Spec: operatorv1.ConsoleSpec{
+					OperatorSpec: operatorv1.OperatorSpec{ManagementState: tt.args.managementState},
+				},
+			}
+			if got := GetManagementState(operatorConfig, tt.args.annotation); got != tt.want {
+				t.Errorf("GetManagementState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateManagementStateAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     error
+	}{
+		{
+			name: "Test no management state annotations",
+		},
+		{
+			name: "Test valid management state annotations",
+			annotations: map[string]string{
+				api.DownloadsManagementStateAnnotation:           string(operatorv1.Removed),
+				api.UpgradeNotificationManagementStateAnnotation: string(operatorv1.Unmanaged),
+			},
+		},
+		{
+			name: "Test invalid management state annotations",
+			annotations: map[string]string{
+				api.DownloadsManagementStateAnnotation:           "removed",
+				api.UpgradeNotificationManagementStateAnnotation: "Deleted",
+				api.CLIDownloadsManagementStateAnnotation:        string(operatorv1.Managed),
+			},
+			wantErr: errors.New(`invalid management state annotations ` +
+				`console.operator.openshift.io/downloads-management-state="removed", ` +
+				`console.operator.openshift.io/upgrade-notification-management-state="Deleted", ` +
+				`the components are left unmanaged: must be one of Managed, Removed, Unmanaged`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			err := ValidateManagementStateAnnotations(operatorConfig)
+			if (err == nil) != (tt.wantErr == nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("ValidateManagementStateAnnotations() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
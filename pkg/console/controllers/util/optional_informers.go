@@ -0,0 +1,261 @@
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// OptionalInformers starts and stops dynamic informers for resources which come
+// and go with optional cluster capabilities, such as the OLMConfig of OLM, as the
+// resources appear in and disappear from discovery.
+type OptionalInformers struct {
+	discoveryClient discovery.DiscoveryInterface
+	dynamicClient   dynamic.Interface
+	resync          time.Duration
+	pollInterval    time.Duration
+
+	lock      sync.Mutex
+	informers []*OptionalInformer
+}
+
+func NewOptionalInformers(
+	discoveryClient discovery.DiscoveryInterface,
+	dynamicClient dynamic.Interface,
+	resync time.Duration,
+) *OptionalInformers {
+	return &OptionalInformers{
+		discoveryClient: discoveryClient,
+		dynamicClient:   dynamicClient,
+		resync:          resync,
+		pollInterval:    time.Minute,
+	}
+}
+
+// ForResource returns the informer for the resource, restricted to the namespace
+// unless the namespace is empty. The informer runs only while the resource is served.
+func (o *OptionalInformers) ForResource(resource schema.GroupVersionResource, namespace string) *OptionalInformer {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for _, informer := range o.informers {
+		if informer.resource == resource && informer.namespace == namespace {
+			return informer
+		}
+	}
+	informer := &OptionalInformer{
+		resource:  resource,
+		namespace: namespace,
+		newInformer: func() cache.SharedIndexInformer {
+			return dynamicinformer.NewFilteredDynamicInformer(o.dynamicClient, resource, namespace, o.resync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil).Informer()
+		},
+	}
+	o.informers = append(o.informers, informer)
+	return informer
+}
+
+// Start checks discovery for the requested resources right away, so that the
+// informers of served resources are running before the controllers wait for
+// them to sync, and then on every poll interval until the stop channel is closed.
+func (o *OptionalInformers) Start(stopCh <-chan struct{}) {
+	o.sync(stopCh)
+	go func() {
+		_ = wait.PollUntilContextCancel(wait.ContextForChannel(stopCh), o.pollInterval, false, func(context.Context) (bool, error) {
+			o.sync(stopCh)
+			return false, nil
+		})
+	}()
+	go func() {
+		<-stopCh
+		o.lock.Lock()
+		defer o.lock.Unlock()
+		for _, informer := range o.informers {
+			informer.stop()
+		}
+	}()
+}
+
+func (o *OptionalInformers) sync(stopCh <-chan struct{}) {
+	o.lock.Lock()
+	informers := append([]*OptionalInformer{}, o.informers...)
+	o.lock.Unlock()
+
+	for _, informer := range informers {
+		enabled, err := o.isResourceEnabled(informer.resource)
+		if err != nil {
+			klog.Errorf("failed to find if %s is served, retrying in %v: %v", informer.resource, o.pollInterval, err)
+			continue
+		}
+		if enabled {
+			informer.ensureRunning(stopCh)
+		} else {
+			informer.stop()
+		}
+	}
+}
+
+func (o *OptionalInformers) isResourceEnabled(resource schema.GroupVersionResource) (bool, error) {
+	resources, err := o.discoveryClient.ServerResourcesForGroupVersion(resource.GroupVersion().String())
+	// If discovery returns NotFound, then we know the group version is not served
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, apiResource := range resources.APIResources {
+		if apiResource.Name == resource.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// OptionalInformer stands in for the dynamic informer of an optional resource
+// both towards controllers, which register their event handlers once, and
+// towards listers, which read from whichever informer is currently running.
+type OptionalInformer struct {
+	resource    schema.GroupVersionResource
+	namespace   string
+	newInformer func() cache.SharedIndexInformer
+
+	lock     sync.RWMutex
+	informer cache.SharedIndexInformer
+	stopFunc context.CancelFunc
+	handlers []cache.ResourceEventHandler
+}
+
+// AddEventHandler registers the handler on the running informer and on every
+// informer started later on.
+func (i *OptionalInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.handlers = append(i.handlers, handler)
+	if i.informer != nil {
+		if _, err := i.informer.AddEventHandler(handler); err != nil {
+			return nil, err
+		}
+	}
+	return &optionalInformerRegistration{informer: i}, nil
+}
+
+// HasSynced reports true while the resource is not served, like alwaysSyncedInformer
+// there would be nothing to wait for.
+func (i *OptionalInformer) HasSynced() bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	if i.informer == nil {
+		return true
+	}
+	return i.informer.HasSynced()
+}
+
+// IsRunning reports whether the resource is served and its informer running.
+func (i *OptionalInformer) IsRunning() bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return i.informer != nil
+}
+
+// Lister returns a lister which finds nothing while the resource is not served.
+func (i *OptionalInformer) Lister() cache.GenericLister {
+	return &optionalLister{informer: i}
+}
+
+func (i *OptionalInformer) ensureRunning(stopCh <-chan struct{}) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.informer != nil {
+		return
+	}
+
+	klog.Infof("%s is served, starting its informer", i.resource)
+	informer := i.newInformer()
+	for _, handler := range i.handlers {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			klog.Errorf("failed to add event handler to the %s informer: %v", i.resource, err)
+		}
+	}
+	ctx, cancel := context.WithCancel(wait.ContextForChannel(stopCh))
+	go informer.Run(ctx.Done())
+	i.informer, i.stopFunc = informer, cancel
+}
+
+func (i *OptionalInformer) stop() {
+	i.lock.Lock()
+	if i.informer == nil {
+		i.lock.Unlock()
+		return
+	}
+	klog.Infof("%s is no longer served, stopping its informer", i.resource)
+	i.stopFunc()
+	objs := i.informer.GetStore().List()
+	handlers := i.handlers
+	i.informer, i.stopFunc = nil, nil
+	i.lock.Unlock()
+
+	// the objects are gone together with the resource, let the controllers know
+	for _, obj := range objs {
+		for _, handler := range handlers {
+			handler.OnDelete(obj)
+		}
+	}
+}
+
+func (i *OptionalInformer) indexer() cache.Indexer {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	if i.informer == nil {
+		return nil
+	}
+	return i.informer.GetIndexer()
+}
+
+type optionalInformerRegistration struct {
+	informer *OptionalInformer
+}
+
+func (r *optionalInformerRegistration) HasSynced() bool {
+	return r.informer.HasSynced()
+}
+
+type optionalLister struct {
+	informer  *OptionalInformer
+	namespace string
+}
+
+func (l *optionalLister) List(selector labels.Selector) ([]runtime.Object, error) {
+	indexer := l.informer.indexer()
+	if indexer == nil {
+		return nil, nil
+	}
+	if len(l.namespace) > 0 {
+		return cache.NewGenericLister(indexer, l.informer.resource.GroupResource()).ByNamespace(l.namespace).List(selector)
+	}
+	return cache.NewGenericLister(indexer, l.informer.resource.GroupResource()).List(selector)
+}
+
+func (l *optionalLister) Get(name string) (runtime.Object, error) {
+	indexer := l.informer.indexer()
+	if indexer == nil {
+		return nil, apierrors.NewNotFound(l.informer.resource.GroupResource(), name)
+	}
+	if len(l.namespace) > 0 {
+		return cache.NewGenericLister(indexer, l.informer.resource.GroupResource()).ByNamespace(l.namespace).Get(name)
+	}
+	return cache.NewGenericLister(indexer, l.informer.resource.GroupResource()).Get(name)
+}
+
+func (l *optionalLister) ByNamespace(namespace string) cache.GenericNamespaceLister {
+	return &optionalLister{informer: l.informer, namespace: namespace}
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestOptionalInformers(t *testing.T) {
+	testCtx, testCancel := context.WithCancel(context.TODO())
+	defer testCancel()
+
+	olmConfigResource := schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1", Resource: "olmconfigs"}
+	olmConfig := &unstructured.Unstructured{}
+	olmConfig.SetAPIVersion("operators.coreos.com/v1")
+	olmConfig.SetKind("OLMConfig")
+	olmConfig.SetName("cluster")
+
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{olmConfigResource: "OLMConfigList"},
+		olmConfig,
+	)
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+
+	optionalInformers := NewOptionalInformers(discoveryClient, dynamicClient, 0)
+	informer := optionalInformers.ForResource(olmConfigResource, "")
+	if optionalInformers.ForResource(olmConfigResource, "") != informer {
+		t.Error("ForResource: expected the same informer for the same resource")
+	}
+
+	var deleted []string
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			deleted = append(deleted, obj.(metav1.Object).GetName())
+		},
+	})
+
+	// the resource is not served yet
+	optionalInformers.sync(testCtx.Done())
+	if informer.IsRunning() {
+		t.Error("informer is running while the resource is not served")
+	}
+	if !informer.HasSynced() {
+		t.Error("informer is not synced while the resource is not served")
+	}
+	if _, err := informer.Lister().Get("cluster"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected NotFound from the lister while the resource is not served, got: %v", err)
+	}
+
+	// the resource shows up in discovery
+	discoveryClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: olmConfigResource.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: olmConfigResource.Resource, Kind: "OLMConfig"}},
+	}}
+	optionalInformers.sync(testCtx.Done())
+	if !informer.IsRunning() {
+		t.Fatal("informer is not running while the resource is served")
+	}
+	err := wait.PollUntilContextTimeout(testCtx, 100*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		return informer.HasSynced(), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error while waiting for informer to sync: %v", err)
+	}
+	if _, err := informer.Lister().Get("cluster"); err != nil {
+		t.Errorf("unexpected error from the lister while the resource is served: %v", err)
+	}
+
+	// the resource goes away again
+	discoveryClient.Resources = nil
+	optionalInformers.sync(testCtx.Done())
+	if informer.IsRunning() {
+		t.Error("informer is running after the resource is no longer served")
+	}
+	if _, err := informer.Lister().Get("cluster"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected NotFound from the lister after the resource is no longer served, got: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "cluster" {
+		t.Errorf("expected the cached objects to be deleted when the informer stops, got: %v", deleted)
+	}
+}
@@ -0,0 +1,25 @@
+package errors
+
+// a config overrides error reports unsupportedConfigOverrides which would turn
+// console-config into something the console is unable to read. The rendered
+// console-config is not applied, the last good one stays in place.
+type ConfigOverridesError struct {
+	message string
+}
+
+// implement the error interface
+func (e *ConfigOverridesError) Error() string {
+	return e.message
+}
+
+func NewConfigOverridesError(msg string) *ConfigOverridesError {
+	err := &ConfigOverridesError{
+		message: msg,
+	}
+	return err
+}
+
+func IsConfigOverridesError(err error) bool {
+	_, ok := err.(*ConfigOverridesError)
+	return ok
+}
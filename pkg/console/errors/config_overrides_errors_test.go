@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestIsConfigOverridesError(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  error
+		output bool
+	}{
+		{
+			name:   "IsConfigOverridesError returns true if passed a ConfigOverridesError",
+			input:  NewConfigOverridesError("Yup, its a config overrides error"),
+			output: true,
+		}, {
+			name:   "IsConfigOverridesError returns false if passed a regular Error",
+			input:  fmt.Errorf("A regular error"),
+			output: false,
+		}, {
+			name:   "IsConfigOverridesError returns true if passed nil",
+			input:  nil,
+			output: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(IsConfigOverridesError(tt.input), tt.output); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+}
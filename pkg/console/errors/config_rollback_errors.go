@@ -0,0 +1,24 @@
+package errors
+
+// a config rollback error reports a console-config the console deployment did not
+// become available with. The last good console-config is applied in its place.
+type ConfigRollbackError struct {
+	message string
+}
+
+// implement the error interface
+func (e *ConfigRollbackError) Error() string {
+	return e.message
+}
+
+func NewConfigRollbackError(msg string) *ConfigRollbackError {
+	err := &ConfigRollbackError{
+		message: msg,
+	}
+	return err
+}
+
+func IsConfigRollbackError(err error) bool {
+	_, ok := err.(*ConfigRollbackError)
+	return ok
+}
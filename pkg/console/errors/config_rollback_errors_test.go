@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestIsConfigRollbackError(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  error
+		output bool
+	}{
+		{
+			name:   "IsConfigRollbackError returns true if passed a ConfigRollbackError",
+			input:  NewConfigRollbackError("Yup, its a config rollback error"),
+			output: true,
+		}, {
+			name:   "IsConfigRollbackError returns false if passed a regular Error",
+			input:  fmt.Errorf("A regular error"),
+			output: false,
+		}, {
+			name:   "IsConfigRollbackError returns false if passed nil",
+			input:  nil,
+			output: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(IsConfigRollbackError(tt.input), tt.output); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift/library-go/pkg/controller/factory"
+	k8smetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	// reason label of the steps that went through without an error
+	SyncStepSucceeded = "Succeeded"
+	// reason label of the steps that failed without giving a reason
+	SyncStepFailed = "Failed"
+)
+
+var (
+	syncStepDuration = k8smetrics.NewHistogramVec(
+		&k8smetrics.HistogramOpts{
+			Name:    "console_operator_sync_step_duration_seconds",
+			Help:    "Duration of the steps of the console sync, labeled by step and outcome reason.",
+			Buckets: k8smetrics.DefBuckets,
+		},
+		[]string{"step", "reason"},
+	)
+
+	syncStepTotal = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Name: "console_operator_sync_step_total",
+			Help: "Number of runs of the steps of the console sync, labeled by step and outcome reason.",
+		},
+		[]string{"step", "reason"},
+	)
+
+	controllerSyncDuration = k8smetrics.NewHistogramVec(
+		&k8smetrics.HistogramOpts{
+			Name:    "console_operator_controller_sync_duration_seconds",
+			Help:    "Duration of the syncs of the console operator controllers.",
+			Buckets: k8smetrics.DefBuckets,
+		},
+		[]string{"controller"},
+	)
+
+	controllerSyncErrors = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Name: "console_operator_controller_sync_errors_total",
+			Help: "Number of failed syncs of the console operator controllers.",
+		},
+		[]string{"controller"},
+	)
+
+	controllerLastSuccessfulSync = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Name: "console_operator_controller_last_successful_sync_timestamp_seconds",
+			Help: "Unix time of the last successful sync of the console operator controllers.",
+		},
+		[]string{"controller"},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(
+		syncStepDuration,
+		syncStepTotal,
+		controllerSyncDuration,
+		controllerSyncErrors,
+		controllerLastSuccessfulSync,
+	)
+}
+
+// SyncStep times a single step of the console sync, such as DeploymentSync.
+type SyncStep struct {
+	name  string
+	start time.Time
+}
+
+func StartSyncStep(name string) *SyncStep {
+	return &SyncStep{name: name, start: time.Now()}
+}
+
+// Done records the duration and the outcome of the step. The reason is the one
+// reported on the step's conditions and only matters when the step failed.
+func (s *SyncStep) Done(reason string, err error) {
+	defer recoverMetricPanic()
+	switch {
+	case err == nil:
+		reason = SyncStepSucceeded
+	case len(reason) == 0:
+		reason = SyncStepFailed
+	}
+	syncStepDuration.WithLabelValues(s.name, reason).Observe(time.Since(s.start).Seconds())
+	syncStepTotal.WithLabelValues(s.name, reason).Inc()
+}
+
+// InstrumentSync wraps the sync function of a controller, recording how long
+// every sync took, how many of them failed and when the last one succeeded.
+// The controllers provided by library-go, e.g. the ClusterOperatorStatusController
+// or the ResourceSyncController, run their own sync functions and are not covered.
+func InstrumentSync(controller string, sync factory.SyncFunc) factory.SyncFunc {
+	return func(ctx context.Context, syncCtx factory.SyncContext) error {
+		start := time.Now()
+		err := sync(ctx, syncCtx)
+		observeControllerSync(controller, start, err)
+		return err
+	}
+}
+
+func observeControllerSync(controller string, start time.Time, err error) {
+	defer recoverMetricPanic()
+	controllerSyncDuration.WithLabelValues(controller).Observe(time.Since(start).Seconds())
+	if err != nil {
+		controllerSyncErrors.WithLabelValues(controller).Inc()
+		return
+	}
+	controllerLastSuccessfulSync.WithLabelValues(controller).SetToCurrentTime()
+}
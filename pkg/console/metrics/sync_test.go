@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestSyncStepDone(t *testing.T) {
+	tests := []struct {
+		name           string
+		step           string
+		reason         string
+		err            error
+		expectedReason string
+	}{
+		{
+			name:           "Test successful step",
+			step:           "TestSuccessfulStep",
+			reason:         "FailedApply",
+			err:            nil,
+			expectedReason: SyncStepSucceeded,
+		},
+		{
+			name:           "Test failed step with reason",
+			step:           "TestFailedStep",
+			reason:         "FailedApply",
+			err:            errors.New("apply failed"),
+			expectedReason: "FailedApply",
+		},
+		{
+			name:           "Test failed step without reason",
+			step:           "TestFailedStepWithoutReason",
+			reason:         "",
+			err:            errors.New("apply failed"),
+			expectedReason: SyncStepFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			StartSyncStep(tt.step).Done(tt.reason, tt.err)
+
+			total, err := testutil.GetCounterMetricValue(syncStepTotal.WithLabelValues(tt.step, tt.expectedReason))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != 1 {
+				t.Errorf("expected 1 run of %s with reason %s, got %v", tt.step, tt.expectedReason, total)
+			}
+			count, err := testutil.GetHistogramMetricCount(syncStepDuration.WithLabelValues(tt.step, tt.expectedReason))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 1 {
+				t.Errorf("expected 1 duration of %s with reason %s, got %v", tt.step, tt.expectedReason, count)
+			}
+		})
+	}
+}
+
+func TestInstrumentSync(t *testing.T) {
+	var syncErr error
+	sync := InstrumentSync("test-controller", func(ctx context.Context, syncCtx factory.SyncContext) error {
+		return syncErr
+	})
+
+	if err := sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lastSuccess, err := testutil.GetGaugeMetricValue(controllerLastSuccessfulSync.WithLabelValues("test-controller"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSuccess == 0 {
+		t.Error("expected the last successful sync time to be set")
+	}
+
+	syncErr = errors.New("sync failed")
+	if err := sync(context.TODO(), nil); err != syncErr {
+		t.Fatalf("expected the sync error to be returned, got: %v", err)
+	}
+	errorCount, err := testutil.GetCounterMetricValue(controllerSyncErrors.WithLabelValues("test-controller"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errorCount != 1 {
+		t.Errorf("expected 1 failed sync, got %v", errorCount)
+	}
+	syncCount, err := testutil.GetHistogramMetricCount(controllerSyncDuration.WithLabelValues("test-controller"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if syncCount != 2 {
+		t.Errorf("expected 2 sync durations, got %v", syncCount)
+	}
+}
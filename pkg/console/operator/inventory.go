@@ -0,0 +1,203 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	// kube
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+
+	// openshift
+	consolev1 "github.com/openshift/api/console/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/library-go/pkg/operator/resourcesynccontroller"
+
+	// operator
+	"github.com/openshift/console-operator/pkg/api"
+	configmapsub "github.com/openshift/console-operator/pkg/console/subresource/configmap"
+	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
+	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
+)
+
+var (
+	configMapsResource          = corev1.SchemeGroupVersion.WithResource("configmaps")
+	secretsResource             = corev1.SchemeGroupVersion.WithResource("secrets")
+	servicesResource            = corev1.SchemeGroupVersion.WithResource("services")
+	deploymentsResource         = appsv1.SchemeGroupVersion.WithResource("deployments")
+	autoscalersResource         = autoscalingv2.SchemeGroupVersion.WithResource("horizontalpodautoscalers")
+	podDisruptionBudgetResource = policyv1.SchemeGroupVersion.WithResource("poddisruptionbudgets")
+	routesResource              = routev1.GroupVersion.WithResource("routes")
+	cliDownloadsResource        = consolev1.GroupVersion.WithResource("consoleclidownloads")
+	notificationsResource       = consolev1.GroupVersion.WithResource("consolenotifications")
+)
+
+// inventoryObject is an object created by the operator, which is deleted
+// once the console is Removed.
+type inventoryObject struct {
+	resource  schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+func (o inventoryObject) String() string {
+	if len(o.namespace) == 0 {
+		return fmt.Sprintf("%s %s", o.resource.GroupResource(), o.name)
+	}
+	return fmt.Sprintf("%s %s/%s", o.resource.GroupResource(), o.namespace, o.name)
+}
+
+// getInventory lists every object the operator and its controllers create, either
+// directly or through the resourceSyncer. The console-public ConfigMap is not part
+// of it, other components read it, so it is emptied rather than deleted. Beside
+// the ConfigMaps named here, it holds those in the target namespace the operator
+// config owns, e.g. the copy of the CA of the OIDC provider, which is named after
+// its source in openshift-config and outlives a change of the provider.
+func getInventory(operatorConfig *operatorv1.Console, targetNSConfigMaps []*corev1.ConfigMap) []inventoryObject {
+	inventory := []inventoryObject{}
+	inventoried := sets.NewString()
+	inTargetNamespace := func(resource schema.GroupVersionResource, names ...string) {
+		for _, name := range names {
+			object := inventoryObject{resource: resource, namespace: api.TargetNamespace, name: name}
+			if inventoried.Has(object.String()) {
+				continue
+			}
+			inventoried.Insert(object.String())
+			inventory = append(inventory, object)
+		}
+	}
+
+	inTargetNamespace(configMapsResource,
+		api.OpenShiftConsoleConfigMapName,
+		api.ConsoleConfigProvenanceName,
+		api.ConsoleConfigHistoryName,
+		api.ServiceCAConfigMapName,
+		api.TrustedCAConfigMapName,
+		api.OpenShiftCustomLogoConfigMapName,
+		api.OAuthServingCertConfigMapName,
+		api.DefaultIngressCertConfigMapName,
+	)
+	for _, file := range configmapsub.CustomBrandingFiles {
+		inTargetNamespace(configMapsResource, file.ConfigMapName)
+	}
+	for _, configMap := range targetNSConfigMaps {
+		if metav1.IsControlledBy(configMap, operatorConfig) {
+			inTargetNamespace(configMapsResource, configMap.Name)
+		}
+	}
+	inTargetNamespace(secretsResource, deploymentsub.ConsoleOauthConfigName, api.SessionSecretName)
+	inTargetNamespace(deploymentsResource, api.OpenShiftConsoleDeploymentName, api.OpenShiftConsoleDownloadsDeploymentName)
+	inTargetNamespace(autoscalersResource, api.OpenShiftConsoleDeploymentName)
+	inTargetNamespace(servicesResource, api.OpenShiftConsoleServiceName, api.OpenshiftConsoleRedirectServiceName, api.DownloadsResourceName)
+	inTargetNamespace(routesResource,
+		api.OpenShiftConsoleRouteName,
+		routesub.GetCustomRouteName(api.OpenShiftConsoleRouteName),
+		api.OpenShiftConsoleDownloadsRouteName,
+		routesub.GetCustomRouteName(api.OpenShiftConsoleDownloadsRouteName),
+	)
+	inTargetNamespace(podDisruptionBudgetResource, api.OpenShiftConsolePDBName, api.OpenShiftConsoleDownloadsPDBName)
+
+	inventory = append(inventory,
+		inventoryObject{resource: cliDownloadsResource, name: api.OCCLIDownloadsCustomResourceName},
+		inventoryObject{resource: cliDownloadsResource, name: api.ODOCLIDownloadsCustomResourceName},
+		inventoryObject{resource: notificationsResource, name: api.UpgradeConsoleNotification},
+		inventoryObject{resource: notificationsResource, name: api.OIDCIssuerConsoleNotification},
+	)
+	return inventory
+}
+
+// UpdateStaticSyncSources points the resourceSyncer at the ConfigMaps of
+// openshift-config-managed the console always reads or, once the console is
+// Removed, at nothing so that their copies are deleted rather than recreated.
+func UpdateStaticSyncSources(resourceSyncer resourcesynccontroller.ResourceSyncer, removed bool) error {
+	for _, name := range []string{api.OAuthServingCertConfigMapName, api.DefaultIngressCertConfigMapName} {
+		source := resourcesynccontroller.ResourceLocation{}
+		if !removed {
+			source = resourcesynccontroller.ResourceLocation{Name: name, Namespace: api.OpenShiftConfigManagedNamespace}
+		}
+		err := resourceSyncer.SyncConfigMap(
+			resourcesynccontroller.ResourceLocation{Name: name, Namespace: api.OpenShiftConsoleNamespace},
+			source,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearSyncSources empties the source of every copy the resourceSyncer makes, so
+// that it deletes those copies of the inventory instead of recreating them. The
+// sync loop of a Managed console sets the sources again.
+func (c *consoleOperator) clearSyncSources() error {
+	if err := UpdateStaticSyncSources(c.resourceSyncer, true); err != nil {
+		return err
+	}
+	destinations := []string{api.OpenShiftCustomLogoConfigMapName}
+	for _, file := range configmapsub.CustomBrandingFiles {
+		destinations = append(destinations, file.ConfigMapName)
+	}
+	for _, name := range destinations {
+		err := c.resourceSyncer.SyncConfigMap(
+			resourcesynccontroller.ResourceLocation{Name: name, Namespace: api.OpenShiftConsoleNamespace},
+			resourcesynccontroller.ResourceLocation{},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// get returns the object from the lister of its resource.
+func (o inventoryObject) get(listers map[schema.GroupVersionResource]cache.GenericLister) (runtime.Object, error) {
+	lister, ok := listers[o.resource]
+	if !ok {
+		return nil, fmt.Errorf("no lister for %s", o.resource.GroupResource())
+	}
+	if len(o.namespace) == 0 {
+		return lister.Get(o.name)
+	}
+	return lister.ByNamespace(o.namespace).Get(o.name)
+}
+
+// removeInventory deletes the objects of the inventory found in the informer caches
+// and returns those which still exist, e.g. because they are held back by a
+// finalizer or their deletion has not been observed yet.
+func removeInventory(ctx context.Context, dynamicClient dynamic.Interface, listers map[schema.GroupVersionResource]cache.GenericLister, inventory []inventoryObject) ([]inventoryObject, error) {
+	remaining := []inventoryObject{}
+	errs := []error{}
+	for _, object := range inventory {
+		existing, err := object.get(listers)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err == nil {
+			var existingMeta metav1.Object
+			existingMeta, err = meta.Accessor(existing)
+			if err == nil && existingMeta.GetDeletionTimestamp() == nil {
+				err = dynamicClient.Resource(object.resource).Namespace(object.namespace).Delete(ctx, object.name, metav1.DeleteOptions{})
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		remaining = append(remaining, object)
+	}
+	return remaining, utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,238 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resourcesynccontroller"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+
+	"github.com/openshift/console-operator/pkg/api"
+	utilsub "github.com/openshift/console-operator/pkg/console/subresource/util"
+)
+
+func TestGetInventory(t *testing.T) {
+	operatorConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Name: api.ConfigResourceName, UID: "console-uid"}}
+	otherConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Name: "other", UID: "other-uid"}}
+	configMap := func(name string, owner *operatorv1.Console) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: api.TargetNamespace}}
+		utilsub.AddOwnerRef(cm, utilsub.OwnerRefFrom(owner))
+		return cm
+	}
+	oidcCA := inventoryObject{resource: configMapsResource, namespace: api.TargetNamespace, name: "oidc-ca"}
+
+	tests := []struct {
+		name               string
+		targetNSConfigMaps []*corev1.ConfigMap
+		wantOIDCCA         bool
+	}{
+		{
+			name:       "Test inventory without copies of an OIDC provider CA",
+			wantOIDCCA: false,
+		},
+		{
+			name:               "Test inventory with the copy of an OIDC provider CA",
+			targetNSConfigMaps: []*corev1.ConfigMap{configMap("oidc-ca", operatorConfig)},
+			wantOIDCCA:         true,
+		},
+		{
+			name:               "Test inventory without the configmaps the operator config does not own",
+			targetNSConfigMaps: []*corev1.ConfigMap{configMap("oidc-ca", nil), configMap("oidc-ca", otherConfig)},
+			wantOIDCCA:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := false
+			inventoried := map[inventoryObject]bool{}
+			for _, object := range getInventory(operatorConfig, tt.targetNSConfigMaps) {
+				if inventoried[object] {
+					t.Errorf("expected %v to be listed once", object)
+				}
+				inventoried[object] = true
+				if object == oidcCA {
+					found = true
+				}
+			}
+			if found != tt.wantOIDCCA {
+				t.Errorf("expected the OIDC provider CA in the inventory: %v, got: %v", tt.wantOIDCCA, found)
+			}
+			for _, name := range []string{api.CustomLogoLightConfigMapName, api.CustomLogoDarkConfigMapName, api.CustomFaviconConfigMapName} {
+				if !inventoried[inventoryObject{resource: configMapsResource, namespace: api.TargetNamespace, name: name}] {
+					t.Errorf("expected the %s branding copy in the inventory", name)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveInventory(t *testing.T) {
+	newObject := func(gvk schema.GroupVersionKind, namespace, name string, terminating bool) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		if terminating {
+			obj.SetFinalizers([]string{"test/finalizer"})
+			now := metav1.Now()
+			obj.SetDeletionTimestamp(&now)
+		}
+		return obj
+	}
+	objects := []runtime.Object{
+		newObject(configMapsResource.GroupVersion().WithKind("ConfigMap"), api.TargetNamespace, api.TrustedCAConfigMapName, false),
+		newObject(configMapsResource.GroupVersion().WithKind("ConfigMap"), api.TargetNamespace, "unrelated-config", false),
+		newObject(routesResource.GroupVersion().WithKind("Route"), api.TargetNamespace, api.OpenShiftConsoleRouteName, true),
+		newObject(notificationsResource.GroupVersion().WithKind("ConsoleNotification"), "", api.UpgradeConsoleNotification, false),
+	}
+
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			configMapsResource:    "ConfigMapList",
+			routesResource:        "RouteList",
+			notificationsResource: "ConsoleNotificationList",
+		},
+		objects...,
+	)
+	indexers := map[schema.GroupVersionResource]cache.Indexer{}
+	listers := map[schema.GroupVersionResource]cache.GenericLister{}
+	for _, resource := range []schema.GroupVersionResource{configMapsResource, routesResource, notificationsResource} {
+		indexers[resource] = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		listers[resource] = cache.NewGenericLister(indexers[resource], resource.GroupResource())
+	}
+	for i, resource := range []schema.GroupVersionResource{configMapsResource, configMapsResource, routesResource, notificationsResource} {
+		if err := indexers[resource].Add(objects[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	inventory := []inventoryObject{
+		{resource: configMapsResource, namespace: api.TargetNamespace, name: api.TrustedCAConfigMapName},
+		{resource: configMapsResource, namespace: api.TargetNamespace, name: api.ServiceCAConfigMapName},
+		{resource: routesResource, namespace: api.TargetNamespace, name: api.OpenShiftConsoleRouteName},
+		{resource: notificationsResource, name: api.UpgradeConsoleNotification},
+	}
+	remaining, err := removeInventory(context.TODO(), dynamicClient, listers, inventory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the objects deleted remain until the caches observe it, the route is held back
+	// by its finalizer and the configmap outside of the inventory is left alone
+	if want := []inventoryObject{inventory[0], inventory[2], inventory[3]}; !reflect.DeepEqual(remaining, want) {
+		t.Errorf("expected %v to remain, got: %v", want, remaining)
+	}
+	// only the cached objects which are not terminating yet are deleted
+	deleted := []string{}
+	for _, action := range dynamicClient.Actions() {
+		if action.GetVerb() != "delete" {
+			t.Errorf("expected the objects to be read from the caches, got a %s of %s", action.GetVerb(), action.GetResource().Resource)
+			continue
+		}
+		deleted = append(deleted, action.GetResource().Resource)
+	}
+	if want := []string{"configmaps", "consolenotifications"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("expected the deletion of %v, got: %v", want, deleted)
+	}
+	if _, err := dynamicClient.Resource(configMapsResource).Namespace(api.TargetNamespace).Get(context.TODO(), "unrelated-config", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the object outside of the inventory to be kept, got: %v", err)
+	}
+}
+
+func TestRemoveConsoleClearsSyncSources(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	configMap := func(namespace, name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Data: map[string]string{"ca-bundle.crt": "bundle"}}
+	}
+	kubeClient := fake.NewSimpleClientset(
+		configMap(api.OpenShiftConfigManagedNamespace, api.OAuthServingCertConfigMapName),
+		configMap(api.OpenShiftConfigManagedNamespace, api.DefaultIngressCertConfigMapName),
+		configMap(api.OpenShiftConfigNamespace, "logo"),
+	)
+	// the resourceSyncer runs as long as the operator is Managed, e.g. once the
+	// console is Managed again before the sync loop has set the sources back
+	operatorClient := v1helpers.NewFakeOperatorClient(&operatorv1.OperatorSpec{ManagementState: operatorv1.Managed}, &operatorv1.OperatorStatus{}, nil)
+	recorder := events.NewInMemoryRecorder("console-operator")
+	kubeInformers := v1helpers.NewKubeInformersForNamespaces(kubeClient, api.OpenShiftConfigNamespace, api.OpenShiftConsoleNamespace, api.OpenShiftConfigManagedNamespace)
+	resourceSyncer := resourcesynccontroller.NewResourceSyncController(operatorClient, kubeInformers, kubeClient.CoreV1(), kubeClient.CoreV1(), recorder)
+	targetNSConfigMaps := kubeInformers.InformersFor(api.OpenShiftConsoleNamespace).Core().V1().ConfigMaps()
+	targetNSConfigMaps.Informer()
+	kubeInformers.Start(ctx.Done())
+	for namespace := range kubeInformers.Namespaces() {
+		kubeInformers.InformersFor(namespace).WaitForCacheSync(ctx.Done())
+	}
+
+	syncCopies := func() {
+		if err := resourceSyncer.Sync(ctx, factory.NewSyncContext("ResourceSyncController", recorder)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	copies := []string{api.OAuthServingCertConfigMapName, api.DefaultIngressCertConfigMapName, api.OpenShiftCustomLogoConfigMapName}
+
+	if err := UpdateStaticSyncSources(resourceSyncer, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := resourceSyncer.SyncConfigMap(
+		resourcesynccontroller.ResourceLocation{Name: api.OpenShiftCustomLogoConfigMapName, Namespace: api.OpenShiftConsoleNamespace},
+		resourcesynccontroller.ResourceLocation{Name: "logo", Namespace: api.OpenShiftConfigNamespace},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	syncCopies()
+	// the resourceSyncer only deletes the copies its cache has observed
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		for _, name := range copies {
+			if _, err := targetNSConfigMaps.Lister().ConfigMaps(api.OpenShiftConsoleNamespace).Get(name); err != nil {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("expected the copies before the removal, got: %v", err)
+	}
+
+	listers := map[schema.GroupVersionResource]cache.GenericLister{}
+	for _, object := range getInventory(&operatorv1.Console{}, nil) {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		if object.resource == configMapsResource {
+			indexer = targetNSConfigMaps.Informer().GetIndexer()
+		}
+		listers[object.resource] = cache.NewGenericLister(indexer, object.resource.GroupResource())
+	}
+	c := &consoleOperator{
+		operatorClient:          operatorClient,
+		targetNSConfigMapLister: targetNSConfigMaps.Lister(),
+		inventoryListers:        listers,
+		dynamicClient:           fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{configMapsResource: "ConfigMapList"}),
+		resourceSyncer:          resourceSyncer,
+	}
+	if err := c.removeConsole(ctx, &operatorv1.Console{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the resourceSyncer deletes the copies rather than recreating them
+	syncCopies()
+	for _, name := range copies {
+		if _, err := kubeClient.CoreV1().ConfigMaps(api.OpenShiftConsoleNamespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			t.Errorf("expected the %s copy to be deleted once the console is Removed", name)
+		}
+	}
+}
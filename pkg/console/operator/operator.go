@@ -4,105 +4,86 @@ import (
 	// standard lib
 	"context"
 	"fmt"
-	"syscall"
+	"strings"
 	"time"
 
 	// kube
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/dynamic/dynamicinformer"
+	appsinformersv1 "k8s.io/client-go/informers/apps/v1"
+	autoscalinginformersv2 "k8s.io/client-go/informers/autoscaling/v2"
 	corev1 "k8s.io/client-go/informers/core/v1"
+	policyinformersv1 "k8s.io/client-go/informers/policy/v1"
 	appsclientv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	autoscalingclientv2 "k8s.io/client-go/kubernetes/typed/autoscaling/v2"
 	coreclientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	// openshift
 	configv1 "github.com/openshift/api/config/v1"
 	operatorsv1 "github.com/openshift/api/operator/v1"
-	configclientv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	configinformer "github.com/openshift/client-go/config/informers/externalversions"
 	configlistersv1 "github.com/openshift/client-go/config/listers/config/v1"
 	consoleinformersv1 "github.com/openshift/client-go/console/informers/externalversions/console/v1"
-	listerv1 "github.com/openshift/client-go/console/listers/console/v1"
-	oauthlistersv1 "github.com/openshift/client-go/oauth/listers/oauth/v1"
-	operatorclientv1 "github.com/openshift/client-go/operator/clientset/versioned/typed/operator/v1"
 	operatorinformerv1 "github.com/openshift/client-go/operator/informers/externalversions/operator/v1"
 	operatorlistersv1 "github.com/openshift/client-go/operator/listers/operator/v1"
-	routeclientv1 "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
 	routesinformersv1 "github.com/openshift/client-go/route/informers/externalversions/route/v1"
-	routev1listers "github.com/openshift/client-go/route/listers/route/v1"
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
+	"github.com/openshift/console-operator/pkg/console/metrics"
 	consolestatus "github.com/openshift/console-operator/pkg/console/status"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
-	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
 	"github.com/openshift/library-go/pkg/operator/resourcesynccontroller"
 	"github.com/openshift/library-go/pkg/operator/status"
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
-	appsinformersv1 "k8s.io/client-go/informers/apps/v1"
 
 	// operator
-
-	"github.com/openshift/console-operator/pkg/console/subresource/configmap"
+	customerrors "github.com/openshift/console-operator/pkg/console/errors"
 	"github.com/openshift/console-operator/pkg/console/subresource/deployment"
-	"github.com/openshift/console-operator/pkg/console/subresource/secret"
 )
 
 type consoleOperator struct {
 	// configs
 	operatorClient       v1helpers.OperatorClient
-	consoleConfigClient  configclientv1.ConsoleInterface
-	consoleConfigLister  configlistersv1.ConsoleLister
 	infrastructureLister configlistersv1.InfrastructureLister
-	ingressConfigLister  configlistersv1.IngressLister
 	proxyConfigLister    configlistersv1.ProxyLister
-	oauthConfigLister    configlistersv1.OAuthLister
 	authnConfigLister    configlistersv1.AuthenticationLister
-	dynamicClient        dynamic.Interface
 	// core kube
-	secretsClient            coreclientv1.SecretsGetter
-	secretsLister            corev1listers.SecretLister
-	configMapClient          coreclientv1.ConfigMapsGetter
-	targetNSConfigMapLister  corev1listers.ConfigMapLister // for openshift-console namespace
-	managedNSConfigMapLister corev1listers.ConfigMapLister // for openshift-config-managed namespace
-	serviceClient            coreclientv1.ServicesGetter
-	nodeClient               coreclientv1.NodesGetter
-	deploymentClient         appsclientv1.DeploymentsGetter
+	secretsClient           coreclientv1.SecretsGetter
+	secretsLister           corev1listers.SecretLister
+	configMapClient         coreclientv1.ConfigMapsGetter
+	targetNSConfigMapLister corev1listers.ConfigMapLister // for openshift-console namespace
+	deploymentClient        appsclientv1.DeploymentsGetter
+	deploymentLister        appsv1listers.DeploymentLister
+	autoscalerClient        autoscalingclientv2.HorizontalPodAutoscalersGetter
+	autoscalerLister        autoscalingv2listers.HorizontalPodAutoscalerLister
+	// removal of everything in the inventory, whatever its kind
+	inventoryListers map[schema.GroupVersionResource]cache.GenericLister
+	dynamicClient    dynamic.Interface
 	// openshift
 	configNSConfigMapLister corev1listers.ConfigMapLister //for openshift-config namespace
-	oauthClientLister       oauthlistersv1.OAuthClientLister
 	consoleOperatorLister   operatorlistersv1.ConsoleLister
-	routeClient             routeclientv1.RoutesGetter
-	routeLister             routev1listers.RouteLister
 	versionGetter           status.VersionGetter
-	// lister
-	consolePluginLister listerv1.ConsolePluginLister
 
 	resourceSyncer resourcesynccontroller.ResourceSyncer
-
-	// used to keep track of OLM capability
-	isOLMDisabled bool
-
-	monitoringDeploymentLister appsv1listers.DeploymentLister
+	// holds the console rollouts back for the settle window of the operator config
+	rolloutSettler rolloutSettler
 }
 
+// NewConsoleOperator returns the controller rolling out the console deployment.
+// The console-config it mounts is rendered by the ConsoleConfigMapController,
+// the console URL is published by the ConsolePublicConfigController.
 func NewConsoleOperator(
-	ctx context.Context,
 	// top level config
-	configClient configclientv1.ConfigV1Interface,
 	configInformer configinformer.SharedInformerFactory,
-	dynamicClient dynamic.Interface,
-	dynamicInformers dynamicinformer.DynamicSharedInformerFactory,
 	// operator
 	operatorClient v1helpers.OperatorClient,
-	operatorConfigClient operatorclientv1.OperatorV1Interface,
 	operatorConfigInformer operatorinformerv1.ConsoleInformer,
 	// core resources
 	corev1Client coreclientv1.CoreV1Interface,
@@ -110,18 +91,17 @@ func NewConsoleOperator(
 	// deployments
 	deploymentClient appsclientv1.DeploymentsGetter,
 	deploymentInformer appsinformersv1.DeploymentInformer,
-	monitoringDeploymentInformer appsinformersv1.DeploymentInformer,
-	// oauth API
-	oauthClientSwitchedInformer *util.InformerWithSwitch,
-	// routes
-	routev1Client routeclientv1.RoutesGetter,
+	// autoscaler of the console deployment
+	autoscalerClient autoscalingclientv2.HorizontalPodAutoscalersGetter,
+	autoscalerInformer autoscalinginformersv2.HorizontalPodAutoscalerInformer,
+	// further objects of the inventory, deleted once the console is Removed
 	routeInformer routesinformersv1.RouteInformer,
-	// plugins
-	consolePluginInformer consoleinformersv1.ConsolePluginInformer,
+	pdbInformer policyinformersv1.PodDisruptionBudgetInformer,
+	cliDownloadsInformer consoleinformersv1.ConsoleCLIDownloadInformer,
+	notificationInformer consoleinformersv1.ConsoleNotificationInformer,
+	dynamicClient dynamic.Interface,
 	// openshift config
 	configNSConfigMapInformer corev1.ConfigMapInformer,
-	// openshift config managed
-	managedCoreV1 corev1.Interface,
 	// event handling
 	versionGetter status.VersionGetter,
 	recorder events.Recorder,
@@ -130,9 +110,7 @@ func NewConsoleOperator(
 
 	secretsInformer := coreV1.Secrets()
 	targetNSConfigMapInformer := coreV1.ConfigMaps()
-	managedNSConfigMapInformer := managedCoreV1.ConfigMaps()
-	serviceInformer := coreV1.Services()
-	nodeInformer := coreV1.Nodes()
+	servicesInformer := coreV1.Services()
 	configV1Informers := configInformer.Config().V1()
 	configNameFilter := util.IncludeNamesFilter(api.ConfigResourceName)
 	targetNameFilter := util.IncludeNamesFilter(api.OpenShiftConsoleName)
@@ -141,142 +119,70 @@ func NewConsoleOperator(
 		// configs
 		operatorClient:        operatorClient,
 		consoleOperatorLister: operatorConfigInformer.Lister(),
-		consoleConfigClient:   configClient.Consoles(),
-		consoleConfigLister:   configInformer.Config().V1().Consoles().Lister(),
-		infrastructureLister:  configInformer.Config().V1().Infrastructures().Lister(),
-		ingressConfigLister:   configInformer.Config().V1().Ingresses().Lister(),
-		proxyConfigLister:     configInformer.Config().V1().Proxies().Lister(),
-		oauthConfigLister:     configInformer.Config().V1().OAuths().Lister(),
+		infrastructureLister:  configV1Informers.Infrastructures().Lister(),
+		proxyConfigLister:     configV1Informers.Proxies().Lister(),
 		authnConfigLister:     configV1Informers.Authentications().Lister(),
-		// console resources
 		// core kube
 		secretsClient:   corev1Client,
 		secretsLister:   secretsInformer.Lister(),
 		configMapClient: corev1Client,
 
-		targetNSConfigMapLister:  targetNSConfigMapInformer.Lister(),
-		configNSConfigMapLister:  configNSConfigMapInformer.Lister(),
-		managedNSConfigMapLister: managedNSConfigMapInformer.Lister(),
+		targetNSConfigMapLister: targetNSConfigMapInformer.Lister(),
+		configNSConfigMapLister: configNSConfigMapInformer.Lister(),
 
-		serviceClient:    corev1Client,
-		nodeClient:       corev1Client,
 		deploymentClient: deploymentClient,
-		dynamicClient:    dynamicClient,
+		deploymentLister: deploymentInformer.Lister(),
+		autoscalerClient: autoscalerClient,
+		autoscalerLister: autoscalerInformer.Lister(),
+		inventoryListers: map[schema.GroupVersionResource]cache.GenericLister{
+			configMapsResource:          cache.NewGenericLister(targetNSConfigMapInformer.Informer().GetIndexer(), configMapsResource.GroupResource()),
+			secretsResource:             cache.NewGenericLister(secretsInformer.Informer().GetIndexer(), secretsResource.GroupResource()),
+			servicesResource:            cache.NewGenericLister(servicesInformer.Informer().GetIndexer(), servicesResource.GroupResource()),
+			deploymentsResource:         cache.NewGenericLister(deploymentInformer.Informer().GetIndexer(), deploymentsResource.GroupResource()),
+			autoscalersResource:         cache.NewGenericLister(autoscalerInformer.Informer().GetIndexer(), autoscalersResource.GroupResource()),
+			podDisruptionBudgetResource: cache.NewGenericLister(pdbInformer.Informer().GetIndexer(), podDisruptionBudgetResource.GroupResource()),
+			routesResource:              cache.NewGenericLister(routeInformer.Informer().GetIndexer(), routesResource.GroupResource()),
+			cliDownloadsResource:        cache.NewGenericLister(cliDownloadsInformer.Informer().GetIndexer(), cliDownloadsResource.GroupResource()),
+			notificationsResource:       cache.NewGenericLister(notificationInformer.Informer().GetIndexer(), notificationsResource.GroupResource()),
+		},
+		dynamicClient: dynamicClient,
 		// openshift
-		oauthClientLister: oauthClientSwitchedInformer.Lister(),
-		routeClient:       routev1Client,
-		routeLister:       routeInformer.Lister(),
-		versionGetter:     versionGetter,
-		// plugins
-		consolePluginLister: consolePluginInformer.Lister(),
+		versionGetter: versionGetter,
 
 		resourceSyncer: resourceSyncer,
-
-		monitoringDeploymentLister: monitoringDeploymentInformer.Lister(),
-	}
-
-	informers := []factory.Informer{
-		configV1Informers.Consoles().Informer(),
-		operatorConfigInformer.Informer(),
-		configV1Informers.Infrastructures().Informer(),
-		configV1Informers.Ingresses().Informer(),
-		configV1Informers.Proxies().Informer(),
-		configV1Informers.OAuths().Informer(),
-	}
-
-	olmGroupVersionResource := schema.GroupVersionResource{
-		Group:    api.OLMConfigGroup,
-		Version:  api.OLMConfigVersion,
-		Resource: api.OLMConfigResource,
-	}
-
-	if found, _ := isResourceEnabled(dynamicClient, olmGroupVersionResource); found {
-		olmConfigInformer := dynamicInformers.ForResource(olmGroupVersionResource)
-		informers = append(informers, olmConfigInformer.Informer())
-	} else {
-		klog.Info("olmconfigs resource does not exist in cluster, launching poll and disabling olmconfigs informer")
-		c.isOLMDisabled = true
-		c.startPollAndRestartIfResourceEnabled(olmGroupVersionResource)
 	}
 
 	return factory.New().
 		WithFilteredEventsInformers( // configs
 			configNameFilter,
-			informers...,
+			operatorConfigInformer.Informer(),
+			configV1Informers.Infrastructures().Informer(),
+			configV1Informers.Proxies().Informer(),
+			configV1Informers.Authentications().Informer(),
 		).WithFilteredEventsInformers( // console resources
 		targetNameFilter,
 		deploymentInformer.Informer(),
-		routeInformer.Informer(),
-		serviceInformer.Informer(),
-	).WithInformers(
-		nodeInformer.Informer(),
-		consolePluginInformer.Informer(),
+		autoscalerInformer.Informer(),
 	).WithInformers(
 		targetNSConfigMapInformer.Informer(),
+		configNSConfigMapInformer.Informer(),
 	).WithFilteredEventsInformers(
-		util.IncludeNamesFilter(api.OpenShiftConsoleConfigMapName, api.OpenShiftConsolePublicConfigMapName),
-		managedNSConfigMapInformer.Informer(),
-	).WithFilteredEventsInformers(
-		factory.NamesFilter(api.OAuthClientName),
-		oauthClientSwitchedInformer.Informer(),
-	).WithFilteredEventsInformers(
-		util.IncludeNamesFilter(deployment.ConsoleOauthConfigName),
+		util.IncludeNamesFilter(deployment.ConsoleOauthConfigName, api.SessionSecretName),
 		secretsInformer.Informer(),
-	).WithFilteredEventsInformers(
-		util.IncludeNamesFilter(deployment.TelemeterClientDeploymentName),
-		monitoringDeploymentInformer.Informer(),
-	).ResyncEvery(time.Minute).WithSync(c.Sync).
+	).WithBareInformers( // the inventory, removals are checked on resync
+		servicesInformer.Informer(),
+		pdbInformer.Informer(),
+		routeInformer.Informer(),
+		cliDownloadsInformer.Informer(),
+		notificationInformer.Informer(),
+	).ResyncEvery(time.Minute).WithSync(metrics.InstrumentSync("console-operator", c.Sync)).
 		ToController("ConsoleOperator", recorder.WithComponentSuffix("console-operator"))
 }
 
-// startPollAndRestartIfResourceEnabled is a helper function to watch for the re-creation of a resource that is initiated
-// at start up, for example the OLMConfigs resource, because OLM is an optional operator and we initiate an informer at start up
-// this method tries to offer a way of trigger a container restart.
-func (c *consoleOperator) startPollAndRestartIfResourceEnabled(resource schema.GroupVersionResource) {
-	go func() {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		var enabled bool
-		// Poll Resource to see if resource has been re-enabled
-		wait.PollInfiniteWithContext(ctx, time.Minute*5, func(ctx context.Context) (done bool, err error) {
-			enabled, err = isResourceEnabled(c.dynamicClient, resource)
-			if err != nil {
-				klog.Errorf("failed to find if resource is enabled, retrying in 5 minutes: %v", err)
-			}
-			return enabled, nil
-		})
-
-		// If we exit out of a poll and enabled is not set to true do not issue interrupt
-		if !enabled {
-			return
-		}
-
-		// This is a brute force technique that won't involve additional permissions
-		// TODO: investigate alternative approaches for re-attaching informer
-		klog.Info("OLM has been re-enabled, restarting container")
-		syscall.Kill(syscall.Getpid(), syscall.SIGINT)
-	}()
-}
-
-func isResourceEnabled(client dynamic.Interface, resource schema.GroupVersionResource) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
-	defer cancel()
-	_, err := client.Resource(resource).List(ctx, metav1.ListOptions{})
-	// If List returns NotFound, then we know the resource does not exist
-	if err != nil && apierrors.IsNotFound(err) {
-		return false, nil
-	}
-	return true, err
-}
-
 type configSet struct {
-	Console        *configv1.Console
 	Operator       *operatorsv1.Console
 	Infrastructure *configv1.Infrastructure
 	Proxy          *configv1.Proxy
-	OAuth          *configv1.OAuth
-	Ingress        *configv1.Ingress
 }
 
 func (c *consoleOperator) Sync(ctx context.Context, controllerContext factory.SyncContext) error {
@@ -292,13 +198,6 @@ func (c *consoleOperator) Sync(ctx context.Context, controllerContext factory.Sy
 		klog.V(4).Infof("finished syncing operator %q (%v)", operatorConfig.Name, time.Since(startTime))
 	}()
 
-	// ensure we have top level console config
-	consoleConfig, err := c.consoleConfigLister.Get(api.ConfigResourceName)
-	if err != nil {
-		klog.Errorf("console config error: %v", err)
-		return err
-	}
-
 	// we need infrastructure config for apiServerURL
 	infrastructureConfig, err := c.infrastructureLister.Get(api.ConfigResourceName)
 	if err != nil {
@@ -312,25 +211,10 @@ func (c *consoleOperator) Sync(ctx context.Context, controllerContext factory.Sy
 		return err
 	}
 
-	oauthConfig, err := c.oauthConfigLister.Get(api.ConfigResourceName)
-	if err != nil {
-		klog.Errorf("oauth config error: %v", err)
-		return err
-	}
-
-	ingressConfig, err := c.ingressConfigLister.Get(api.ConfigResourceName)
-	if err != nil {
-		klog.Errorf("ingress config error: %v", err)
-		return err
-	}
-
 	configs := configSet{
-		Console:        consoleConfig.DeepCopy(),
 		Operator:       operatorConfig.DeepCopy(),
 		Infrastructure: infrastructureConfig.DeepCopy(),
 		Proxy:          proxyConfig.DeepCopy(),
-		OAuth:          oauthConfig.DeepCopy(),
-		Ingress:        ingressConfig.DeepCopy(),
 	}
 
 	if err := c.handleSync(ctx, controllerContext, configs); err != nil {
@@ -352,7 +236,7 @@ func (c *consoleOperator) handleSync(ctx context.Context, controllerContext fact
 		return nil
 	case operatorsv1.Removed:
 		klog.V(4).Infoln("console has been removed.")
-		return c.removeConsole(ctx, updatedStatus, controllerContext.Recorder())
+		return c.removeConsole(ctx, updatedStatus)
 	default:
 		return fmt.Errorf("console is in an unknown state: %v", updatedStatus.Spec.ManagementState)
 	}
@@ -360,28 +244,38 @@ func (c *consoleOperator) handleSync(ctx context.Context, controllerContext fact
 	return c.sync_v400(ctx, controllerContext, updatedStatus, configs)
 }
 
-// this may need to move to sync_v400 if versions ever have custom delete logic
-func (c *consoleOperator) removeConsole(ctx context.Context, operatorConfig *operatorsv1.Console, recorder events.Recorder) error {
+// removeConsole deletes every object of the inventory, including those owned by
+// other controllers which clean up after themselves too, and reports the objects
+// which are left through the RemovalProgressing condition until none remains.
+// console-public is emptied by the ConsolePublicConfigController.
+func (c *consoleOperator) removeConsole(ctx context.Context, operatorConfig *operatorsv1.Console) error {
 	klog.V(2).Info("deleting console resources")
 	defer klog.V(2).Info("finished deleting console resources")
-	var errs []error
-	// configmaps
-	errs = append(errs, c.configMapClient.ConfigMaps(api.TargetNamespace).Delete(ctx, configmap.Stub().Name, metav1.DeleteOptions{}))
-	errs = append(errs, c.configMapClient.ConfigMaps(api.TargetNamespace).Delete(ctx, configmap.ServiceCAStub().Name, metav1.DeleteOptions{}))
-	// secret
-	errs = append(errs, c.secretsClient.Secrets(api.TargetNamespace).Delete(ctx, secret.Stub().Name, metav1.DeleteOptions{}))
 
-	// deployment
-	// NOTE: CVO controls the deployment for downloads, console-operator cannot delete it.
-	errs = append(errs, c.deploymentClient.Deployments(api.TargetNamespace).Delete(ctx, deployment.Stub().Name, metav1.DeleteOptions{}))
-	// clear the console URL from the public config map in openshift-config-managed
-	_, _, updateConfigErr := resourceapply.ApplyConfigMap(ctx, c.configMapClient, recorder, configmap.EmptyPublicConfig())
-	errs = append(errs, updateConfigErr)
+	// the resourceSyncer would otherwise recreate the copies deleted below
+	if err := c.clearSyncSources(); err != nil {
+		return err
+	}
+	targetNSConfigMaps, err := c.targetNSConfigMapLister.ConfigMaps(api.TargetNamespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	remaining, err := removeInventory(ctx, c.dynamicClient, c.inventoryListers, getInventory(operatorConfig, targetNSConfigMaps))
 
-	// filter out 404 errors, which indicate that resource is already deleted
-	err := utilerrors.FilterOut(utilerrors.NewAggregate(errs), apierrors.IsNotFound)
+	var removalErr error
+	if len(remaining) > 0 {
+		names := make([]string, 0, len(remaining))
+		for _, object := range remaining {
+			names = append(names, object.String())
+		}
+		removalErr = customerrors.NewSyncError(fmt.Sprintf("waiting for the removal of: %s", strings.Join(names, ", ")))
+	}
 
 	statusHandler := consolestatus.NewStatusHandler(c.operatorClient)
 	statusHandler.AddConditions(statusHandler.ResetConditions(operatorConfig.Status.Conditions))
-	return statusHandler.FlushAndReturn(err)
+	statusHandler.AddCondition(consolestatus.HandleProgressing("Removal", "ObjectsRemaining", removalErr))
+	if err != nil {
+		return statusHandler.FlushAndReturn(err)
+	}
+	return statusHandler.FlushAndReturn(removalErr)
 }
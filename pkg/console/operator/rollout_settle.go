@@ -0,0 +1,86 @@
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	// kube
+	appsv1 "k8s.io/api/apps/v1"
+
+	// openshift
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	// operator
+	"github.com/openshift/console-operator/pkg/api"
+	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
+)
+
+// maxRolloutSettleWindow bounds how long the console can be held back.
+const maxRolloutSettleWindow = time.Hour
+
+// getRolloutSettleWindow returns the settle window set on the operator config,
+// or nothing if the console rolls out on every change of its inputs.
+func getRolloutSettleWindow(operatorConfig *operatorv1.Console) (time.Duration, error) {
+	value, ok := operatorConfig.GetAnnotations()[api.RolloutSettleWindowAnnotation]
+	if !ok || len(value) == 0 {
+		return 0, nil
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation %q: %w", api.RolloutSettleWindowAnnotation, value, err)
+	}
+	if window < 0 || window > maxRolloutSettleWindow {
+		return 0, fmt.Errorf("invalid %s annotation %q: must be between 0 and %s", api.RolloutSettleWindowAnnotation, value, maxRolloutSettleWindow)
+	}
+	return window, nil
+}
+
+// rolloutSettler holds the rollouts of the console deployment back while its
+// inputs keep changing, e.g. while an admin applies a logo, then the operator
+// config, then a componentRoute, so that they roll the console out once.
+// Each change pushes the rollout back by the settle window, up to twice the
+// window after the first one.
+type rolloutSettler struct {
+	firstChange   time.Time
+	lastChange    time.Time
+	pendingHashes map[string]string
+}
+
+// settle returns when the rollout of the required deployment is scheduled, or
+// nothing if it can roll out right away. Upgrades and first rollouts are not
+// held back.
+func (s *rolloutSettler) settle(window time.Duration, existing, required *appsv1.Deployment, now time.Time) time.Time {
+	if window <= 0 || existing == nil || deploymentsub.IsImageChanged(existing, required) {
+		s.reset()
+		return time.Time{}
+	}
+	hashes := deploymentsub.InputHashes(required)
+	if reflect.DeepEqual(hashes, deploymentsub.InputHashes(existing)) {
+		s.reset()
+		return time.Time{}
+	}
+
+	switch {
+	case s.pendingHashes == nil:
+		s.firstChange, s.lastChange = now, now
+	case !reflect.DeepEqual(hashes, s.pendingHashes):
+		s.lastChange = now
+	}
+	s.pendingHashes = hashes
+
+	scheduledAt := s.lastChange.Add(window)
+	if deadline := s.firstChange.Add(2 * window); scheduledAt.After(deadline) {
+		scheduledAt = deadline
+	}
+	// the pending changes are kept until the rollout is applied, a failed
+	// apply is retried right away
+	if !now.Before(scheduledAt) {
+		return time.Time{}
+	}
+	return scheduledAt
+}
+
+func (s *rolloutSettler) reset() {
+	s.firstChange, s.lastChange, s.pendingHashes = time.Time{}, time.Time{}, nil
+}
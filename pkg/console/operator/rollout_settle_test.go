@@ -0,0 +1,161 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+func TestGetRolloutSettleWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        time.Duration
+		wantErr     bool
+	}{
+		{
+			name: "Test no settle window",
+			want: 0,
+		},
+		{
+			name:        "Test settle window",
+			annotations: map[string]string{api.RolloutSettleWindowAnnotation: "2m"},
+			want:        2 * time.Minute,
+		},
+		{
+			name:        "Test invalid settle window",
+			annotations: map[string]string{api.RolloutSettleWindowAnnotation: "two minutes"},
+			wantErr:     true,
+		},
+		{
+			name:        "Test negative settle window",
+			annotations: map[string]string{api.RolloutSettleWindowAnnotation: "-1m"},
+			wantErr:     true,
+		},
+		{
+			name:        "Test settle window longer than allowed",
+			annotations: map[string]string{api.RolloutSettleWindowAnnotation: "2h"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got, err := getRolloutSettleWindow(operatorConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getRolloutSettleWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getRolloutSettleWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRolloutSettlerSettle(t *testing.T) {
+	newDeployment := func(configHash, image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"console.openshift.io/console-config-hash": configHash,
+							"console.openshift.io/image":               image,
+						},
+					},
+				},
+			},
+		}
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := time.Minute
+	existing := newDeployment("a", "console:1")
+
+	type step struct {
+		required *appsv1.Deployment
+		at       time.Duration
+		want     time.Duration
+	}
+	tests := []struct {
+		name     string
+		window   time.Duration
+		existing *appsv1.Deployment
+		steps    []step
+	}{
+		{
+			name:     "Test rollout without settle window",
+			existing: existing,
+			steps:    []step{{required: newDeployment("b", "console:1")}},
+		},
+		{
+			name:   "Test first rollout",
+			window: window,
+			steps:  []step{{required: newDeployment("b", "console:1")}},
+		},
+		{
+			name:     "Test upgrade",
+			window:   window,
+			existing: existing,
+			steps:    []step{{required: newDeployment("b", "console:2")}},
+		},
+		{
+			name:     "Test unchanged inputs",
+			window:   window,
+			existing: existing,
+			steps:    []step{{required: newDeployment("a", "console:1")}},
+		},
+		{
+			name:     "Test changes coalesced until the window elapses",
+			window:   window,
+			existing: existing,
+			steps: []step{
+				{required: newDeployment("b", "console:1"), at: 0, want: time.Minute},
+				{required: newDeployment("b", "console:1"), at: 30 * time.Second, want: time.Minute},
+				{required: newDeployment("c", "console:1"), at: 40 * time.Second, want: 100 * time.Second},
+				{required: newDeployment("c", "console:1"), at: 100 * time.Second},
+			},
+		},
+		{
+			name:     "Test changes held back up to twice the window",
+			window:   window,
+			existing: existing,
+			steps: []step{
+				{required: newDeployment("b", "console:1"), at: 0, want: time.Minute},
+				{required: newDeployment("c", "console:1"), at: 50 * time.Second, want: 110 * time.Second},
+				{required: newDeployment("d", "console:1"), at: 100 * time.Second, want: 2 * time.Minute},
+				{required: newDeployment("e", "console:1"), at: 2 * time.Minute},
+			},
+		},
+		{
+			name:     "Test reverted change",
+			window:   window,
+			existing: existing,
+			steps: []step{
+				{required: newDeployment("b", "console:1"), at: 0, want: time.Minute},
+				{required: newDeployment("a", "console:1"), at: 10 * time.Second},
+				{required: newDeployment("b", "console:1"), at: 20 * time.Second, want: 80 * time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settler := &rolloutSettler{}
+			for i, step := range tt.steps {
+				want := time.Time{}
+				if step.want > 0 {
+					want = start.Add(step.want)
+				}
+				if got := settler.settle(tt.window, tt.existing, step.required, start.Add(step.at)); !got.Equal(want) {
+					t.Errorf("step %d: settle() = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
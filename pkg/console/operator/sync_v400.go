@@ -5,22 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	// kube
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	// openshift
 	configv1 "github.com/openshift/api/config/v1"
-	v1 "github.com/openshift/api/console/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
-	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
@@ -29,15 +26,15 @@ import (
 	"github.com/openshift/library-go/pkg/operator/resourcesynccontroller"
 
 	// operator
+	"github.com/openshift/console-operator/pkg/console/controllers/util"
 	customerrors "github.com/openshift/console-operator/pkg/console/errors"
 	"github.com/openshift/console-operator/pkg/console/metrics"
 	"github.com/openshift/console-operator/pkg/console/status"
 	configmapsub "github.com/openshift/console-operator/pkg/console/subresource/configmap"
 	deploymentsub "github.com/openshift/console-operator/pkg/console/subresource/deployment"
-	oauthsub "github.com/openshift/console-operator/pkg/console/subresource/oauthclient"
-	routesub "github.com/openshift/console-operator/pkg/console/subresource/route"
 	secretsub "github.com/openshift/console-operator/pkg/console/subresource/secret"
 	utilsub "github.com/openshift/console-operator/pkg/console/subresource/util"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
 )
 
 // The sync loop starts from zero and works its way through the requirements for a running console.
@@ -49,26 +46,15 @@ func (co *consoleOperator) sync_v400(ctx context.Context, controllerContext fact
 	klog.V(4).Infoln("running sync loop 4.0.0")
 	statusHandler := status.NewStatusHandler(co.operatorClient)
 
-	// track changes, may trigger ripples & update operator config or console config status
+	// track changes, may trigger ripples & update operator config
 	toUpdate := false
 
-	routeName := api.OpenShiftConsoleRouteName
-	routeConfig := routesub.NewRouteConfig(updatedOperatorConfig, set.Ingress, routeName)
-	if routeConfig.IsCustomHostnameSet() {
-		routeName = api.OpenshiftConsoleCustomRouteName
-	}
+	// the components the invalid annotations manage are left alone by their controllers
+	statusHandler.AddCondition(status.HandleDegraded("ManagementStateAnnotations", "InvalidManagementState", util.ValidateManagementStateAnnotations(updatedOperatorConfig)))
 
-	route, consoleURL, routeReasoneErr, routeErr := routesub.GetActiveRouteInfo(co.routeLister, routeName)
-	// TODO: this controller is no longer responsible for syncing the route.
-	//   however, the route is essential for several of the components below.
-	//   - the loop should exit early and wait until the RouteSyncController creates the route.
-	//     there is nothing new in this flow, other than 2 controllers now look
-	//     at the same resource.
-	//     - RouteSyncController is responsible for updates
-	//     - ConsoleOperatorController (future ConsoleDeploymentController) is responsible for reads only.
-	statusHandler.AddConditions(status.HandleProgressingOrDegraded("SyncLoopRefresh", routeReasoneErr, routeErr))
-	if routeErr != nil {
-		return statusHandler.FlushAndReturn(routeErr)
+	// the sources are cleared while the console is Removed
+	if err := UpdateStaticSyncSources(co.resourceSyncer, false); err != nil {
+		return statusHandler.FlushAndReturn(err)
 	}
 
 	authnConfig, err := co.authnConfigLister.Get(api.ConfigResourceName)
@@ -82,45 +68,68 @@ func (co *consoleOperator) sync_v400(ctx context.Context, controllerContext fact
 	)
 	switch authnConfig.Spec.Type {
 	case configv1.AuthenticationTypeOIDC:
-		if len(authnConfig.Spec.OIDCProviders) > 0 {
-			oidcProvider := authnConfig.Spec.OIDCProviders[0]
+		// the OIDCSetupController reports an ambiguous OIDC config
+		oidcProvider, _, oidcErr := utilsub.GetOIDCClientConfig(authnConfig)
+		if oidcErr != nil {
+			return statusHandler.FlushAndReturn(oidcErr)
+		}
+		if oidcProvider != nil && len(oidcProvider.Issuer.CertificateAuthority.Name) > 0 {
 			authServerCAConfig, err = co.configNSConfigMapLister.ConfigMaps(api.OpenShiftConfigNamespace).Get(oidcProvider.Issuer.CertificateAuthority.Name)
 			if err != nil && !apierrors.IsNotFound(err) {
 				return statusHandler.FlushAndReturn(err)
 			}
 		}
 
-		sessionSecret, err = co.syncSessionSecret(ctx, updatedOperatorConfig, controllerContext.Recorder())
+		sessionSecretRotationInterval, rotationIntervalErr := secretsub.GetRotationInterval(updatedOperatorConfig, api.SessionSecretRotationIntervalAnnotation, sessionSecretGracePeriod)
+		sessionSecretRotationRequest := updatedOperatorConfig.GetAnnotations()[api.RotateSessionSecretAnnotation]
+		rotationErrReason := "InvalidRotationInterval"
+		if rotationIntervalErr == nil && (sessionSecretRotationInterval > 0 || len(sessionSecretRotationRequest) > 0) && !utilsub.PreviousSessionKeysEnabled(updatedOperatorConfig) {
+			// rotating the keys would drop every session of a console that does
+			// not accept the sessions of the previous keys
+			rotationErrReason = "PreviousSessionKeysNotRead"
+			rotationIntervalErr = fmt.Errorf("the session secret is not rotated until the %s annotation is set to \"true\" for a console which reads the previous session keys", api.SessionSecretPreviousKeysAnnotation)
+		}
+		if rotationIntervalErr != nil {
+			sessionSecretRotationInterval, sessionSecretRotationRequest = 0, ""
+		}
+		statusHandler.AddCondition(status.HandleDegraded("SessionSecretRotation", rotationErrReason, rotationIntervalErr))
+
+		var nextSessionSecretChange time.Time
+		sessionSecret, nextSessionSecretChange, err = co.syncSessionSecret(ctx, updatedOperatorConfig, sessionSecretRotationInterval, sessionSecretRotationRequest, controllerContext.Recorder())
 		if err != nil {
 			return statusHandler.FlushAndReturn(err)
 		}
+		statusHandler.AddCondition(status.HandleRotated("SessionSecret", secretsub.GetRotatedAt(sessionSecret)))
+		if !nextSessionSecretChange.IsZero() {
+			controllerContext.Queue().AddAfter(controllerContext.QueueKey(), time.Until(nextSessionSecretChange))
+		}
+	default:
+		// the session secret is only rotated for OIDC, drop what was reported
+		// about it before switching the authentication type
+		statusHandler.AddCondition(status.HandleDegraded("SessionSecretRotation", "", nil))
+		statusHandler.AddCondition(status.ClearRotated("SessionSecret"))
 	}
 
-	cm, cmChanged, cmErrReason, cmErr := co.SyncConfigMap(
-		ctx,
-		set.Operator,
-		set.Console,
-		set.Infrastructure,
-		set.OAuth,
-		authServerCAConfig,
-		authnConfig,
-		route,
-		controllerContext.Recorder(),
-	)
-	toUpdate = toUpdate || cmChanged
-	statusHandler.AddConditions(status.HandleProgressingOrDegraded("ConfigMapSync", cmErrReason, cmErr))
+	// console-config is rendered by the ConsoleConfigMapController, the deployment
+	// waits for it and rolls out whichever version of it is currently in place.
+	cm, cmErrReason, cmErr := co.GetConsoleConfigMap()
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("SyncLoopRefresh", cmErrReason, cmErr))
 	if cmErr != nil {
 		return statusHandler.FlushAndReturn(cmErr)
 	}
 
+	serviceCAStep := metrics.StartSyncStep("ServiceCASync")
 	serviceCAConfigMap, serviceCAChanged, serviceCAErrReason, serviceCAErr := co.SyncServiceCAConfigMap(ctx, set.Operator)
+	serviceCAStep.Done(serviceCAErrReason, serviceCAErr)
 	toUpdate = toUpdate || serviceCAChanged
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("ServiceCASync", serviceCAErrReason, serviceCAErr))
 	if serviceCAErr != nil {
 		return statusHandler.FlushAndReturn(serviceCAErr)
 	}
 
+	trustedCAStep := metrics.StartSyncStep("TrustedCASync")
 	trustedCAConfigMap, trustedCAConfigMapChanged, trustedCAErrReason, trustedCAErr := co.SyncTrustedCAConfigMap(ctx, set.Operator)
+	trustedCAStep.Done(trustedCAErrReason, trustedCAErr)
 	toUpdate = toUpdate || trustedCAConfigMapChanged
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("TrustedCASync", trustedCAErrReason, trustedCAErr))
 	if trustedCAErr != nil {
@@ -128,13 +137,32 @@ func (co *consoleOperator) sync_v400(ctx context.Context, controllerContext fact
 	}
 
 	// TODO: why is this missing a toUpdate change?
+	customLogoStep := metrics.StartSyncStep("CustomLogoSync")
 	customLogoCanMount, customLogoErrReason, customLogoError := co.SyncCustomLogoConfigMap(ctx, updatedOperatorConfig)
+	customLogoStep.Done(customLogoErrReason, customLogoError)
 	// If the custom logo sync fails for any reason, we are degraded, not progressing.
 	// The sync loop may not settle, we are unable to honor it in current state.
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("CustomLogoSync", customLogoErrReason, customLogoError))
 	if customLogoError != nil {
 		return statusHandler.FlushAndReturn(customLogoError)
 	}
+	// copied from openshift-config by the resourceSyncer, its content is tracked by the deployment
+	var customLogoConfigMap *corev1.ConfigMap
+	if customLogoCanMount {
+		customLogoConfigMap, err = co.targetNSConfigMapLister.ConfigMaps(api.OpenShiftConsoleNamespace).Get(api.OpenShiftCustomLogoConfigMapName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return statusHandler.FlushAndReturn(err)
+		}
+	}
+
+	customBrandingStep := metrics.StartSyncStep("CustomBrandingSync")
+	customBrandingConfigMaps, customBrandingErrReason, customBrandingErr := co.SyncCustomBrandingConfigMaps(ctx, updatedOperatorConfig)
+	customBrandingStep.Done(customBrandingErrReason, customBrandingErr)
+	// like the custom logo, the console is not rolled out with a broken logo or favicon
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("CustomBrandingSync", customBrandingErrReason, customBrandingErr))
+	if customBrandingErr != nil {
+		return statusHandler.FlushAndReturn(customBrandingErr)
+	}
 
 	var oauthServingCertConfigMap *corev1.ConfigMap
 	switch authnConfig.Spec.Type {
@@ -142,20 +170,42 @@ func (co *consoleOperator) sync_v400(ctx context.Context, controllerContext fact
 		var oauthServingCertErrReason string
 		var oauthServingCertErr error
 
+		oauthServingCertStep := metrics.StartSyncStep("OAuthServingCertValidation")
 		oauthServingCertConfigMap, oauthServingCertErrReason, oauthServingCertErr = co.ValidateOAuthServingCertConfigMap(ctx)
+		oauthServingCertStep.Done(oauthServingCertErrReason, oauthServingCertErr)
 		statusHandler.AddConditions(status.HandleProgressingOrDegraded("OAuthServingCertValidation", oauthServingCertErrReason, oauthServingCertErr))
 		if oauthServingCertErr != nil {
 			return statusHandler.FlushAndReturn(oauthServingCertErr)
 		}
 	}
 
+	workloadConfig, workloadConfigErr := workload.GetConfig(co.configNSConfigMapLister, workload.ConsoleSection, workload.AutoscalingSection)
+	statusHandler.AddCondition(status.HandleDegraded("WorkloadConfig", "InvalidWorkloadConfig", workloadConfigErr))
+	if workloadConfigErr != nil {
+		return statusHandler.FlushAndReturn(workloadConfigErr)
+	}
+
+	autoscalerStep := metrics.StartSyncStep("AutoscalerSync")
+	autoscalerChanged, autoscalerErrReason, autoscalerErr := co.SyncHorizontalPodAutoscaler(ctx, set.Operator, workloadConfig.Autoscaling, controllerContext.Recorder())
+	autoscalerStep.Done(autoscalerErrReason, autoscalerErr)
+	toUpdate = toUpdate || autoscalerChanged
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("AutoscalerSync", autoscalerErrReason, autoscalerErr))
+	if autoscalerErr != nil {
+		return statusHandler.FlushAndReturn(autoscalerErr)
+	}
+
 	clientSecret, secErr := co.secretsLister.Secrets(api.TargetNamespace).Get(secretsub.Stub().Name)
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("OAuthClientSecretGet", "FailedGet", secErr))
 	if secErr != nil {
 		return statusHandler.FlushAndReturn(secErr)
 	}
 
-	actualDeployment, depChanged, depErrReason, depErr := co.SyncDeployment(
+	// an invalid settle window is reported, the console then rolls out right away
+	rolloutSettleWindow, rolloutSettleWindowErr := getRolloutSettleWindow(set.Operator)
+	statusHandler.AddCondition(status.HandleDegraded("RolloutSettleWindow", "InvalidSettleWindow", rolloutSettleWindowErr))
+
+	deploymentStep := metrics.StartSyncStep("DeploymentSync")
+	actualDeployment, rolloutScheduledAt, depChanged, depErrReason, depErr := co.SyncDeployment(
 		ctx,
 		set.Operator,
 		cm,
@@ -165,17 +215,39 @@ func (co *consoleOperator) sync_v400(ctx context.Context, controllerContext fact
 		trustedCAConfigMap,
 		clientSecret,
 		sessionSecret,
+		customLogoConfigMap,
+		customBrandingConfigMaps,
 		set.Proxy,
 		set.Infrastructure,
 		customLogoCanMount,
+		workloadConfig,
+		rolloutSettleWindow,
 		controllerContext.Recorder(),
 	)
+	deploymentStep.Done(depErrReason, depErr)
 	toUpdate = toUpdate || depChanged
 	statusHandler.AddConditions(status.HandleProgressingOrDegraded("DeploymentSync", depErrReason, depErr))
 	if depErr != nil {
 		return statusHandler.FlushAndReturn(depErr)
 	}
 
+	statusHandler.AddCondition(status.HandleProgressing("RolloutSettle", "RolloutScheduled", func() error {
+		if rolloutScheduledAt.IsZero() {
+			return nil
+		}
+		// the resync would roll the console out up to a minute late
+		controllerContext.Queue().AddAfter(controllerContext.QueueKey(), time.Until(rolloutScheduledAt))
+		return fmt.Errorf("changes to the console configuration are coalesced, the console rolls out at %s", rolloutScheduledAt.UTC().Format(time.RFC3339))
+	}()))
+
+	configHistoryStep := metrics.StartSyncStep("ConfigHistorySync")
+	configHistoryErrReason, configHistoryErr := co.SyncConfigHistory(ctx, set.Operator, cm, actualDeployment, controllerContext.Recorder())
+	configHistoryStep.Done(configHistoryErrReason, configHistoryErr)
+	statusHandler.AddConditions(status.HandleProgressingOrDegraded("ConfigHistorySync", configHistoryErrReason, configHistoryErr))
+	if configHistoryErr != nil {
+		return statusHandler.FlushAndReturn(configHistoryErr)
+	}
+
 	statusHandler.UpdateDeploymentGeneration(actualDeployment)
 	statusHandler.UpdateReadyReplicas(actualDeployment.Status.ReadyReplicas)
 	statusHandler.UpdateObservedGeneration(set.Operator.ObjectMeta.Generation)
@@ -207,30 +279,9 @@ func (co *consoleOperator) sync_v400(ctx context.Context, controllerContext fact
 		return prefix, "", nil
 	}()))
 
-	// if we survive the gauntlet, we need to update the console config with the
-	// public hostname so that the world can know the console is ready to roll
-	klog.V(4).Infoln("sync_v400: updating console status")
-
-	_, consoleConfigErr := co.SyncConsoleConfig(ctx, set.Console, consoleURL.String())
-	statusHandler.AddCondition(status.HandleDegraded("ConsoleConfig", "FailedUpdate", consoleConfigErr))
-	if consoleConfigErr != nil {
-		klog.Errorf("could not update console config status: %v", consoleConfigErr)
-		return statusHandler.FlushAndReturn(consoleConfigErr)
-	}
-
-	_, _, consolePublicConfigErr := co.SyncConsolePublicConfig(ctx, consoleURL.String(), controllerContext.Recorder())
-	statusHandler.AddCondition(status.HandleDegraded("ConsolePublicConfigMap", "FailedApply", consolePublicConfigErr))
-	if consolePublicConfigErr != nil {
-		klog.Errorf("could not update public console config status: %v", consolePublicConfigErr)
-		return statusHandler.FlushAndReturn(consolePublicConfigErr)
-	}
-
 	defer func() {
 		klog.V(4).Infof("sync loop 4.0.0 complete")
 
-		if cmChanged {
-			klog.V(4).Infof("\t configmap changed: %v", cm.GetResourceVersion())
-		}
 		if serviceCAChanged {
 			klog.V(4).Infof("\t service-ca configmap changed: %v", serviceCAConfigMap.GetResourceVersion())
 		}
@@ -242,23 +293,6 @@ func (co *consoleOperator) sync_v400(ctx context.Context, controllerContext fact
 	return statusHandler.FlushAndReturn(nil)
 }
 
-func (co *consoleOperator) SyncConsoleConfig(ctx context.Context, consoleConfig *configv1.Console, consoleURL string) (*configv1.Console, error) {
-	oldURL := consoleConfig.Status.ConsoleURL
-	metrics.HandleConsoleURL(oldURL, consoleURL)
-	if oldURL != consoleURL {
-		klog.V(4).Infof("updating console.config.openshift.io with url: %v", consoleURL)
-		updated := consoleConfig.DeepCopy()
-		updated.Status.ConsoleURL = consoleURL
-		return co.consoleConfigClient.UpdateStatus(ctx, updated, metav1.UpdateOptions{})
-	}
-	return consoleConfig, nil
-}
-
-func (co *consoleOperator) SyncConsolePublicConfig(ctx context.Context, consoleURL string, recorder events.Recorder) (*corev1.ConfigMap, bool, error) {
-	requiredConfigMap := configmapsub.DefaultPublicConfig(consoleURL)
-	return resourceapply.ApplyConfigMap(ctx, co.configMapClient, recorder, requiredConfigMap)
-}
-
 func (co *consoleOperator) SyncDeployment(
 	ctx context.Context,
 	operatorConfig *operatorv1.Console,
@@ -269,11 +303,15 @@ func (co *consoleOperator) SyncDeployment(
 	trustedCAConfigMap *corev1.ConfigMap,
 	sec *corev1.Secret,
 	sessionSecret *corev1.Secret,
+	customLogoConfigMap *corev1.ConfigMap,
+	customBrandingConfigMaps []*corev1.ConfigMap,
 	proxyConfig *configv1.Proxy,
 	infrastructureConfig *configv1.Infrastructure,
 	canMountCustomLogo bool,
+	workloadConfig *workload.Config,
+	rolloutSettleWindow time.Duration,
 	recorder events.Recorder,
-) (consoleDeployment *appsv1.Deployment, changed bool, reason string, err error) {
+) (consoleDeployment *appsv1.Deployment, rolloutScheduledAt time.Time, changed bool, reason string, err error) {
 	updatedOperatorConfig := operatorConfig.DeepCopy()
 	requiredDeployment := deploymentsub.DefaultDeployment(
 		operatorConfig,
@@ -284,16 +322,29 @@ func (co *consoleOperator) SyncDeployment(
 		trustedCAConfigMap,
 		sec,
 		sessionSecret,
+		customLogoConfigMap,
+		customBrandingConfigMaps,
 		proxyConfig,
 		infrastructureConfig,
 		canMountCustomLogo,
+		workloadConfig.Console,
+		workloadConfig.Autoscaling,
 	)
+	existingDeployment, err := co.deploymentLister.Deployments(requiredDeployment.Namespace).Get(requiredDeployment.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, time.Time{}, false, "FailedGet", err
+	}
+	deploymentsub.WithAutoscaledReplicas(requiredDeployment, existingDeployment, workloadConfig.Autoscaling)
+	if rolloutScheduledAt = co.rolloutSettler.settle(rolloutSettleWindow, existingDeployment, requiredDeployment, time.Now()); !rolloutScheduledAt.IsZero() {
+		klog.V(4).Infof("deployment input hashes have changed, rollout scheduled at %v", rolloutScheduledAt)
+		return existingDeployment, rolloutScheduledAt, false, "", nil
+	}
 	genChanged := operatorConfig.ObjectMeta.Generation != operatorConfig.Status.ObservedGeneration
 
 	if genChanged {
 		klog.V(4).Infof("deployment generation changed from %d to %d", operatorConfig.ObjectMeta.Generation, operatorConfig.Status.ObservedGeneration)
 	}
-	deploymentsub.LogDeploymentAnnotationChanges(co.deploymentClient, requiredDeployment, ctx)
+	deploymentsub.LogDeploymentAnnotationChanges(co.deploymentLister, requiredDeployment)
 
 	deployment, deploymentChanged, applyDepErr := resourceapply.ApplyDeployment(
 		ctx,
@@ -304,111 +355,114 @@ func (co *consoleOperator) SyncDeployment(
 	)
 
 	if applyDepErr != nil {
-		return nil, false, "FailedApply", applyDepErr
+		return nil, time.Time{}, false, "FailedApply", applyDepErr
 	}
-	return deployment, deploymentChanged, "", nil
+	return deployment, time.Time{}, deploymentChanged, "", nil
 }
 
-// apply configmap (needs route)
-// by the time we get to the configmap, we can assume the route exits & is configured properly
-// therefore no additional error handling is needed here.
-func (co *consoleOperator) SyncConfigMap(
-	ctx context.Context,
-	operatorConfig *operatorv1.Console,
-	consoleConfig *configv1.Console,
-	infrastructureConfig *configv1.Infrastructure,
-	oauthConfig *configv1.OAuth,
-	authServerCAConfig *corev1.ConfigMap,
-	authConfig *configv1.Authentication,
-	activeConsoleRoute *routev1.Route,
-	recorder events.Recorder,
-) (consoleConfigMap *corev1.ConfigMap, changed bool, reason string, err error) {
-
-	managedConfig, mcErr := co.managedNSConfigMapLister.ConfigMaps(api.OpenShiftConfigManagedNamespace).Get(api.OpenShiftConsoleConfigMapName)
-	if mcErr != nil {
-		if !apierrors.IsNotFound(mcErr) {
-			return nil, false, "FailedGetManagedConfig", mcErr
-		}
-		managedConfig = &corev1.ConfigMap{}
-	}
-
-	nodeList, nodeListErr := co.nodeClient.Nodes().List(ctx, metav1.ListOptions{})
-	if nodeListErr != nil {
-		return nil, false, "FailedListNodes", nodeListErr
+// SyncHorizontalPodAutoscaler applies the autoscaler of the console deployment,
+// or deletes it once autoscaling is turned off.
+func (co *consoleOperator) SyncHorizontalPodAutoscaler(ctx context.Context, operatorConfig *operatorv1.Console, autoscaling *workload.Autoscaling, recorder events.Recorder) (changed bool, reason string, err error) {
+	existing, err := co.autoscalerLister.HorizontalPodAutoscalers(api.OpenShiftConsoleNamespace).Get(api.OpenShiftConsoleDeploymentName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, "FailedGet", err
 	}
-	nodeArchitectures, nodeOperatingSystems := getNodeComputeEnvironments(nodeList)
 
-	// TODO: currently there's no way to get this for authentication type OIDC
-	inactivityTimeoutSeconds := 0
-	switch authConfig.Spec.Type {
-	case "", configv1.AuthenticationTypeIntegratedOAuth:
-		oauthClient, oacErr := co.oauthClientLister.Get(oauthsub.Stub().Name)
-		if oacErr != nil {
-			return nil, false, "FailedGetOAuthClient", oacErr
+	if autoscaling == nil {
+		if existing == nil {
+			return false, "", nil
 		}
-		if oauthClient.AccessTokenInactivityTimeoutSeconds != nil {
-			inactivityTimeoutSeconds = int(*oauthClient.AccessTokenInactivityTimeoutSeconds)
-		} else {
-			if oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout != nil {
-				inactivityTimeoutSeconds = int(oauthConfig.Spec.TokenConfig.AccessTokenInactivityTimeout.Seconds())
-			}
+		err = co.autoscalerClient.HorizontalPodAutoscalers(existing.Namespace).Delete(ctx, existing.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, "FailedDelete", err
 		}
+		recorder.Eventf("HorizontalPodAutoscalerDeleted", "Deleted %s/%s, the console deployment is no longer autoscaled", existing.Namespace, existing.Name)
+		return true, "", nil
 	}
 
-	availablePlugins := co.GetAvailablePlugins(operatorConfig.Spec.Plugins)
-
-	monitoringSharedConfig, mscErr := co.managedNSConfigMapLister.ConfigMaps(api.OpenShiftConfigManagedNamespace).Get(api.OpenShiftMonitoringConfigMapName)
-	if mscErr != nil {
-		if !apierrors.IsNotFound(mscErr) {
-			return nil, false, "FailedGetMonitoringSharedConfig", mscErr
+	required := deploymentsub.DefaultHorizontalPodAutoscaler(operatorConfig, autoscaling)
+	if existing == nil {
+		_, err = co.autoscalerClient.HorizontalPodAutoscalers(required.Namespace).Create(ctx, required, metav1.CreateOptions{})
+		if err != nil {
+			return false, "FailedCreate", err
 		}
-		monitoringSharedConfig = &corev1.ConfigMap{}
+		recorder.Eventf("HorizontalPodAutoscalerCreated", "Created %s/%s", required.Namespace, required.Name)
+		return true, "", nil
 	}
 
-	telemeterClientIsAvailable, err := deploymentsub.IsTelemeterClientAvailable(co.monitoringDeploymentLister)
+	modified := resourcemerge.BoolPtr(false)
+	toWrite := existing.DeepCopy()
+	resourcemerge.EnsureObjectMeta(modified, &toWrite.ObjectMeta, required.ObjectMeta)
+	if !*modified && equality.Semantic.DeepEqual(toWrite.Spec, required.Spec) {
+		return false, "", nil
+	}
+	toWrite.Spec = required.Spec
+	_, err = co.autoscalerClient.HorizontalPodAutoscalers(toWrite.Namespace).Update(ctx, toWrite, metav1.UpdateOptions{})
 	if err != nil {
-		return nil, false, "FailedTelemeterClientCheck", err
+		return false, "FailedUpdate", err
 	}
+	recorder.Eventf("HorizontalPodAutoscalerUpdated", "Updated %s/%s", toWrite.Namespace, toWrite.Name)
+	return true, "", nil
+}
 
-	var (
-		copiedCSVsDisabled bool
-		ccdErr             error
-	)
-	if !co.isOLMDisabled {
-		copiedCSVsDisabled, ccdErr = co.isCopiedCSVsDisabled(ctx)
-		if ccdErr != nil {
-			return nil, false, "FailedGetOLMConfig", ccdErr
+// SyncConfigHistory records the console-config the deployment became available
+// with, or rejects the one it stopped making progress with. Only a rollout which
+// changed console-config alone from the last good one is put down to it, the
+// rollouts which changed other inputs as well may have stalled for any of them.
+// A rejected console-config is replaced with the last good one by the
+// ConsoleConfigMapController.
+func (co *consoleOperator) SyncConfigHistory(ctx context.Context, operatorConfig *operatorv1.Console, consoleConfigMap *corev1.ConfigMap, deployment *appsv1.Deployment, recorder events.Recorder) (reason string, err error) {
+	if !deploymentsub.IsRunningConfig(deployment, consoleConfigMap) {
+		return "", nil
+	}
+	existing, err := co.targetNSConfigMapLister.ConfigMaps(api.TargetNamespace).Get(api.ConsoleConfigHistoryName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "FailedGet", err
+	}
+	history, err := configmapsub.GetConfigHistory(existing)
+	if err != nil {
+		return "FailedDecode", err
+	}
+
+	config := consoleConfigMap.Data[configmapsub.ConsoleConfigYamlFile]
+	changed := false
+	switch {
+	case deploymentsub.IsAvailableAndUpdated(deployment):
+		changed = history.RecordGood(config, deploymentsub.RolloutHashes(deployment))
+	case deploymentsub.IsProgressDeadlineExceeded(deployment):
+		if lastGood := history.LastGood(); lastGood == nil || !deploymentsub.IsConfigOnlyRollout(deployment, lastGood.RolloutHashes) {
+			klog.V(4).Infoln("console deployment did not become available, but not with console-config as the only change from the last good rollout")
+			return "", nil
 		}
+		changed = history.Reject(config)
+		if changed {
+			recorder.Warningf("ConsoleConfigRejected", "console deployment did not become available with console-config %s, rolling back to the last good one", consoleConfigMap.GetResourceVersion())
+		}
+	}
+	if !changed {
+		return "", nil
 	}
 
-	defaultConfigmap, _, err := configmapsub.DefaultConfigMap(
-		operatorConfig,
-		consoleConfig,
-		authConfig,
-		authServerCAConfig,
-		managedConfig,
-		monitoringSharedConfig,
-		infrastructureConfig,
-		activeConsoleRoute,
-		inactivityTimeoutSeconds,
-		availablePlugins,
-		nodeArchitectures,
-		nodeOperatingSystems,
-		copiedCSVsDisabled,
-		telemeterClientIsAvailable,
-	)
+	required, err := configmapsub.DefaultConfigHistoryConfigMap(operatorConfig, history)
 	if err != nil {
-		return nil, false, "FailedConsoleConfigBuilder", err
+		return "FailedEncode", err
 	}
-	cm, cmChanged, cmErr := resourceapply.ApplyConfigMap(ctx, co.configMapClient, recorder, defaultConfigmap)
-	if cmErr != nil {
-		return nil, false, "FailedApply", cmErr
+	if _, _, err := resourceapply.ApplyConfigMap(ctx, co.configMapClient, recorder, required); err != nil {
+		return "FailedApply", err
+	}
+	return "", nil
+}
+
+// GetConsoleConfigMap returns the console-config rendered by the ConsoleConfigMapController.
+func (co *consoleOperator) GetConsoleConfigMap() (consoleConfigMap *corev1.ConfigMap, reason string, err error) {
+	cm, err := co.targetNSConfigMapLister.ConfigMaps(api.TargetNamespace).Get(api.OpenShiftConsoleConfigMapName)
+	if apierrors.IsNotFound(err) {
+		return nil, "WaitingForConsoleConfig", customerrors.NewSyncError("waiting for the console-config configmap to be rendered")
 	}
-	if cmChanged {
-		klog.V(4).Infoln("new console config yaml:")
-		klog.V(4).Infof("%s", cm.Data)
+	if err != nil {
+		return nil, "FailedGet", err
 	}
-	return cm, cmChanged, "ConsoleConfigBuilder", cmErr
+	return cm, "", nil
 }
 
 // apply service-ca configmap
@@ -536,95 +590,138 @@ func (co *consoleOperator) ValidateCustomLogo(ctx context.Context, operatorConfi
 		klog.V(4).Infoln("no custom logo configured")
 		return false, "", nil
 	}
-	logoConfigMap, err := co.configNSConfigMapLister.ConfigMaps(api.OpenShiftConfigNamespace).Get(logoConfigMapName)
-	// If we 404, the logo file may not have been created yet.
+	if reason, err := co.validateCustomImage("custom logo", logoConfigMapName, logoImageKey, configmapsub.ValidateCustomLogoImage); err != nil {
+		return false, reason, err
+	}
+
+	klog.V(4).Infoln("custom logo ok to mount")
+	return true, "", nil
+}
+
+// validateCustomImage checks that the image referenced in openshift-config exists
+// and passes the given validation, so that it can be mounted.
+func (co *consoleOperator) validateCustomImage(image string, configMapName string, key string, validate func([]byte) (string, error)) (reason string, err error) {
+	imageConfigMap, err := co.configNSConfigMapLister.ConfigMaps(api.OpenShiftConfigNamespace).Get(configMapName)
+	// If we 404, the image file may not have been created yet.
 	if err != nil {
-		klog.V(4).Infof("custom logo file %v not found", logoConfigMapName)
-		return false, "FailedGet", customerrors.NewCustomLogoError(fmt.Sprintf("custom logo file %v not found", logoConfigMapName))
+		klog.V(4).Infof("%s file %v not found", image, configMapName)
+		return "FailedGet", customerrors.NewCustomLogoError(fmt.Sprintf("%s file %v not found", image, configMapName))
 	}
 
-	_, imageDataFound := logoConfigMap.BinaryData[logoImageKey]
+	imageData, imageDataFound := imageConfigMap.BinaryData[key]
 	if !imageDataFound {
-		_, imageDataFound = logoConfigMap.Data[logoImageKey]
+		var textData string
+		textData, imageDataFound = imageConfigMap.Data[key]
+		imageData = []byte(textData)
 	}
 	if !imageDataFound {
-		klog.V(4).Infoln("custom logo file exists but no image provided")
-		return false, "NoImageProvided", customerrors.NewCustomLogoError("custom logo file exists but no image provided")
+		klog.V(4).Infof("%s file exists but no image provided", image)
+		return "NoImageProvided", customerrors.NewCustomLogoError(fmt.Sprintf("%s file exists but no image provided", image))
 	}
-
-	klog.V(4).Infoln("custom logo ok to mount")
-	return true, "", nil
+	// a corrupt or oversized image breaks the masthead, it is not mounted
+	if reason, err := validate(imageData); err != nil {
+		klog.V(4).Infof("%s %s/%s rejected: %v", image, configMapName, key, err)
+		return reason, customerrors.NewCustomLogoError(fmt.Sprintf("%s %s/%s rejected: %v", image, configMapName, key, err))
+	}
+	return "", nil
 }
 
-func (co *consoleOperator) GetAvailablePlugins(enabledPluginsNames []string) []*v1.ConsolePlugin {
-	var availablePlugins []*v1.ConsolePlugin
-	for _, pluginName := range utilsub.RemoveDuplicateStr(enabledPluginsNames) {
-		plugin, err := co.consolePluginLister.Get(pluginName)
+// SyncCustomBrandingConfigMaps copies the logos per theme and the favicon referenced
+// by the operator config into openshift-console, once validated, and returns the
+// copies the console can mount.
+func (co *consoleOperator) SyncCustomBrandingConfigMaps(ctx context.Context, operatorConfig *operatorv1.Console) (customBrandingConfigMaps []*corev1.ConfigMap, reason string, err error) {
+	customBrandingConfigMaps = []*corev1.ConfigMap{}
+	for _, file := range configmapsub.CustomBrandingFiles {
+		reference, err := file.Reference(operatorConfig)
 		if err != nil {
-			klog.Errorf("failed to get %q plugin: %v", pluginName, err)
-			continue
+			return nil, "KeyOrFilenameInvalid", customerrors.NewCustomLogoError(err.Error())
 		}
-		availablePlugins = append(availablePlugins, plugin)
-	}
-	return availablePlugins
-}
 
-func getNodeComputeEnvironments(nodes *corev1.NodeList) ([]string, []string) {
-	nodeArchitecturesSet := sets.NewString()
-	nodeOperatingSystemSet := sets.NewString()
-	for _, node := range nodes.Items {
-		nodeArch := node.Labels[api.NodeArchitectureLabel]
-		if nodeArch == "" {
-			klog.Warningf("Missing architecture label %q on node %q.", api.NodeArchitectureLabel, node.GetName())
-		} else {
-			nodeArchitecturesSet.Insert(nodeArch)
+		// if the image is not referenced, sync an empty source to delete its copy
+		source := resourcesynccontroller.ResourceLocation{}
+		if len(reference.Name) > 0 {
+			validate := configmapsub.ValidateCustomLogoImage
+			if file.Favicon {
+				validate = configmapsub.ValidateCustomFaviconImage
+			}
+			if reason, err := co.validateCustomImage(file.ConfigMapName+" image", reference.Name, reference.Key, validate); err != nil {
+				return nil, reason, err
+			}
+			source.Name = reference.Name
+			source.Namespace = api.OpenShiftConfigNamespace
+		}
+		err = co.resourceSyncer.SyncConfigMap(
+			resourcesynccontroller.ResourceLocation{Namespace: api.OpenShiftConsoleNamespace, Name: file.ConfigMapName},
+			source,
+		)
+		if err != nil {
+			return nil, "FailedSyncSource", customerrors.NewCustomLogoError(fmt.Sprintf("%s sync source update error", file.ConfigMapName))
+		}
+		if len(reference.Name) == 0 {
+			continue
 		}
 
-		nodeOperatingSystem := node.Labels[api.NodeOperatingSystemLabel]
-		if nodeOperatingSystem == "" {
-			klog.Warningf("Missing operating system label %q on node %q", api.NodeOperatingSystemLabel, node.GetName())
-		} else {
-			nodeOperatingSystemSet.Insert(nodeOperatingSystem)
+		// the copy shows up once the resourceSyncer has run
+		customBrandingConfigMap, err := co.targetNSConfigMapLister.ConfigMaps(api.OpenShiftConsoleNamespace).Get(file.ConfigMapName)
+		if apierrors.IsNotFound(err) {
+			continue
 		}
+		if err != nil {
+			return nil, "FailedGet", err
+		}
+		customBrandingConfigMaps = append(customBrandingConfigMaps, customBrandingConfigMap)
 	}
-	return nodeArchitecturesSet.List(), nodeOperatingSystemSet.List()
+	return customBrandingConfigMaps, "", nil
 }
 
-func (co *consoleOperator) isCopiedCSVsDisabled(ctx context.Context) (bool, error) {
-	olmConfig, err := co.dynamicClient.Resource(schema.GroupVersionResource{Group: api.OLMConfigGroup, Version: api.OLMConfigVersion, Resource: api.OLMConfigResource}).Get(ctx, api.ConfigResourceName, metav1.GetOptions{})
-	if err != nil {
-		return false, err
-	}
-	copiedCSVsDisabled, found, err := unstructured.NestedBool(olmConfig.Object, "spec", "features", "disableCopiedCSVs")
-	if err != nil || !found {
-		return false, err
-	}
-
-	return copiedCSVsDisabled, nil
-}
+// sessionSecretGracePeriod is how long the console keeps accepting the sessions
+// secured by the session keys before their last rotation. Scheduled rotations
+// are at least as far apart, so that no session is dropped mid-day.
+const sessionSecretGracePeriod = 24 * time.Hour
 
+// syncSessionSecret generates the session keys of the console, rotates them on
+// schedule or, when the request token differs from the last one handled, on
+// demand and expires the previous keys after the grace period.
+// It returns when the secret is next due to change, if it is.
 func (co *consoleOperator) syncSessionSecret(
 	ctx context.Context,
 	operatorConfig *operatorv1.Console,
+	rotationInterval time.Duration,
+	rotationRequest string,
 	recorder events.Recorder,
-) (*corev1.Secret, error) {
+) (*corev1.Secret, time.Time, error) {
 
 	sessionSecret, err := co.secretsLister.Secrets(api.TargetNamespace).Get(api.SessionSecretName)
 	if err != nil && !apierrors.IsNotFound(err) {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 
+	now := time.Now()
 	var required *corev1.Secret
+	changed := true
 	if sessionSecret == nil {
 		required = secretsub.DefaultSessionSecret(operatorConfig)
+		secretsub.SetRotated(required, rotationRequest, now)
 	} else {
 		required = sessionSecret.DeepCopy()
-		changed := secretsub.ResetSessionSecretKeysIfNeeded(required)
-		if !changed {
-			return required, nil
+		changed = secretsub.ResetSessionSecretKeysIfNeeded(required)
+		if rotationReason, _ := secretsub.RotationDue(required, rotationRequest, rotationInterval, now); len(rotationReason) > 0 {
+			klog.V(2).Infof("rotating the session secret: %s", rotationReason)
+			secretsub.RotateSessionSecretKeys(required)
+			secretsub.SetRotated(required, rotationRequest, now)
+			changed = true
 		}
+		changed = secretsub.ExpirePreviousSessionSecretKeys(required, sessionSecretGracePeriod, now) || changed
+	}
+
+	_, nextChange := secretsub.RotationDue(required, rotationRequest, rotationInterval, now)
+	if previousKeysExpireAt := secretsub.PreviousSessionSecretKeysExpireAt(required, sessionSecretGracePeriod); !previousKeysExpireAt.IsZero() && (nextChange.IsZero() || previousKeysExpireAt.Before(nextChange)) {
+		nextChange = previousKeysExpireAt
+	}
+	if !changed {
+		return required, nextChange, nil
 	}
 
 	secret, _, err := resourceapply.ApplySecret(ctx, co.secretsClient, recorder, required)
-	return secret, err
+	return secret, nextChange, err
 }
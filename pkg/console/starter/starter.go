@@ -12,7 +12,6 @@ import (
 	apiexensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	policyv1client "k8s.io/client-go/kubernetes/typed/policy/v1"
@@ -24,18 +23,20 @@ import (
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/clientwrapper"
 	"github.com/openshift/console-operator/pkg/console/controllers/clidownloads"
+	"github.com/openshift/console-operator/pkg/console/controllers/consoleconfigmap"
 	"github.com/openshift/console-operator/pkg/console/controllers/downloadsdeployment"
 	"github.com/openshift/console-operator/pkg/console/controllers/healthcheck"
 	"github.com/openshift/console-operator/pkg/console/controllers/oauthclients"
 	"github.com/openshift/console-operator/pkg/console/controllers/oauthclientsecret"
+	"github.com/openshift/console-operator/pkg/console/controllers/oidcissuer"
 	"github.com/openshift/console-operator/pkg/console/controllers/oidcsetup"
 	pdb "github.com/openshift/console-operator/pkg/console/controllers/poddisruptionbudget"
+	"github.com/openshift/console-operator/pkg/console/controllers/publicconfig"
 	"github.com/openshift/console-operator/pkg/console/controllers/route"
 	"github.com/openshift/console-operator/pkg/console/controllers/service"
 	upgradenotification "github.com/openshift/console-operator/pkg/console/controllers/upgradenotification"
 	"github.com/openshift/console-operator/pkg/console/controllers/util"
 	"github.com/openshift/console-operator/pkg/console/operatorclient"
-	"github.com/openshift/console-operator/pkg/console/subresource/deployment"
 	"github.com/openshift/library-go/pkg/controller/controllercmd"
 	"github.com/openshift/library-go/pkg/operator/managementstatecontroller"
 	"github.com/openshift/library-go/pkg/operator/resourcesynccontroller"
@@ -120,12 +121,6 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		informers.WithNamespace(api.OpenShiftConfigNamespace),
 	)
 
-	kubeInformersMonitoringNamespaced := informers.NewSharedInformerFactoryWithOptions(
-		kubeClient,
-		resync,
-		informers.WithNamespace(deployment.TelemeterClientDeploymentNamespace),
-	)
-
 	//configs are all named "cluster", but our clusteroperator is named "console"
 	configInformers := configinformers.NewSharedInformerFactoryWithOptions(
 		configClient,
@@ -148,7 +143,9 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		resync,
 	)
 
-	dynamicInformers := dynamicinformer.NewDynamicSharedInformerFactory(
+	// informers for resources of optional capabilities, started and stopped as the resources come and go
+	optionalInformers := util.NewOptionalInformers(
+		kubeClient.Discovery(),
 		dynamicClient,
 		resync,
 	)
@@ -172,47 +169,73 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		recorder,
 	)
 
-	err = startStaticResourceSyncing(resourceSyncer)
+	err = operator.UpdateStaticSyncSources(resourceSyncer, false)
 	if err != nil {
 		return err
 	}
 
 	// TODO: rearrange these into informer,client pairs, NOT separated.
 	consoleOperator := operator.NewConsoleOperator(
-		ctx,
 		// top level config
-		configClient.ConfigV1(),
 		configInformers,
-		dynamicClient,
-		dynamicInformers,
 		// operator
 		operatorClient,
-		operatorConfigClient.OperatorV1(),
 		operatorConfigInformers.Operator().V1().Consoles(), // OperatorConfig
 		// core resources
-		kubeClient.CoreV1(),                 // Secrets, ConfigMaps, Service
-		kubeInformersNamespaced.Core().V1(), // Secrets, ConfigMaps, Service
+		kubeClient.CoreV1(),                 // Secrets, ConfigMaps
+		kubeInformersNamespaced.Core().V1(), // Secrets, ConfigMaps
 		// deployments
 		kubeClient.AppsV1(),
 		kubeInformersNamespaced.Apps().V1().Deployments(), // Deployments
-		kubeInformersMonitoringNamespaced.Apps().V1().Deployments(),
-		// oauth
-		oauthClientsSwitchedInformer,
-		// routes
-		routesClient.RouteV1(),
-		routesInformersNamespaced.Route().V1().Routes(), // Route
-		// plugins
-		consoleInformers.Console().V1().ConsolePlugins(),
+		kubeClient.AutoscalingV2(),
+		kubeInformersNamespaced.Autoscaling().V2().HorizontalPodAutoscalers(), // HorizontalPodAutoscalers
+		routesInformersNamespaced.Route().V1().Routes(),                       // Routes
+		kubeInformersNamespaced.Policy().V1().PodDisruptionBudgets(),          // PodDisruptionBudgets
+		consoleInformers.Console().V1().ConsoleCLIDownloads(),                 // ConsoleCliDownloads
+		consoleInformers.Console().V1().ConsoleNotifications(),                // ConsoleNotifications
+		dynamicClient,
 		// openshift
 		kubeInformersConfigNamespaced.Core().V1().ConfigMaps(), // openshift-config configMaps
-		// openshift managed
-		kubeInformersManagedNamespaced.Core().V1(), // Managed ConfigMaps
 		// event handling
 		versionGetter,
 		recorder,
 		resourceSyncer,
 	)
 
+	consoleConfigMapController := consoleconfigmap.NewConsoleConfigMapSyncController(
+		// top level config
+		configInformers,
+		optionalInformers, // OLMConfig, telemeter-client Deployment
+		// clients
+		operatorClient,
+		kubeClient.CoreV1(), // ConfigMaps, Nodes
+		// informers
+		operatorConfigInformers.Operator().V1().Consoles(),     // OperatorConfig
+		kubeInformersNamespaced.Core().V1(),                    // ConfigMaps, Nodes
+		kubeInformersConfigNamespaced.Core().V1().ConfigMaps(), // openshift-config configMaps
+		kubeInformersManagedNamespaced.Core().V1(),             // Managed ConfigMaps
+		oauthClientsSwitchedInformer,
+		routesInformersNamespaced.Route().V1().Routes(),
+		consoleInformers.Console().V1().ConsolePlugins(),
+		// events
+		recorder,
+	)
+
+	publicConfigController := publicconfig.NewPublicConfigSyncController(
+		// top level config
+		configClient.ConfigV1(),
+		configInformers,
+		// clients
+		operatorClient,
+		kubeClient.CoreV1(), // ConfigMaps
+		// informers
+		operatorConfigInformers.Operator().V1().Consoles(),      // OperatorConfig
+		kubeInformersManagedNamespaced.Core().V1().ConfigMaps(), // Managed ConfigMaps
+		routesInformersNamespaced.Route().V1().Routes(),         // Routes
+		// events
+		recorder,
+	)
+
 	apiextensionsClient, err := apiextensionsclient.NewForConfig(controllerContext.KubeConfig)
 	if err != nil {
 		return err
@@ -227,6 +250,7 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		routesInformersNamespaced.Route().V1().Routes(),
 		configInformers.Config().V1().Ingresses(),
 		kubeInformersNamespaced.Core().V1().Secrets(),
+		kubeInformersNamespaced.Apps().V1().Deployments(),
 		oauthClientsSwitchedInformer,
 		recorder,
 	)
@@ -255,6 +279,18 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		recorder,
 	)
 
+	oidcIssuerController := oidcissuer.NewOIDCIssuerController(
+		operatorClient,
+		configClient.ConfigV1().Authentications(),
+		consoleClient.ConsoleV1().ConsoleNotifications(),
+		configInformers,
+		operatorConfigInformers.Operator().V1().Consoles(),
+		apiextensionsInformers.Apiextensions().V1().CustomResourceDefinitions(),
+		kubeInformersConfigNamespaced.Core().V1().ConfigMaps(),
+		kubeInformersNamespaced.Core().V1().ConfigMaps(),
+		recorder,
+	)
+
 	downloadsDeploymentController := downloadsdeployment.NewDownloadsDeploymentSyncController(
 		// clients
 		operatorClient,
@@ -264,16 +300,14 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 
 		kubeClient.AppsV1(), // Deployments
 		kubeInformersNamespaced.Apps().V1().Deployments(), // Deployments
+		kubeInformersConfigNamespaced.Core().V1().ConfigMaps(),
 		recorder,
 	)
 
 	cliDownloadsController := clidownloads.NewCLIDownloadsSyncController(
-		// top level config
-		configClient.ConfigV1(),
 		// clients
 		operatorClient,
 		consoleClient.ConsoleV1().ConsoleCLIDownloads(),
-		routesClient.RouteV1(),
 		// informers
 		operatorConfigInformers.Operator().V1().Consoles(), // OperatorConfig
 		configInformers, // Config
@@ -286,7 +320,6 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 	consoleServiceController := service.NewServiceSyncController(
 		api.OpenShiftConsoleServiceName,
 		// top level config
-		configClient.ConfigV1(),
 		configInformers,
 		// clients
 		operatorClient,
@@ -301,7 +334,6 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 	downloadsServiceController := service.NewServiceSyncController(
 		api.DownloadsResourceName,
 		// top level config
-		configClient.ConfigV1(),
 		configInformers,
 		operatorClient,
 		// clients
@@ -318,12 +350,10 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		// enable health check for console route
 		true,
 		// top level config
-		configClient.ConfigV1(),
 		configInformers,
 		// clients
 		operatorClient,
 		routesClient.RouteV1(),
-		kubeClient.CoreV1(),
 		// route
 		operatorConfigInformers.Operator().V1().Consoles(),
 		kubeInformersConfigNamespaced.Core().V1().Secrets(), // `openshift-config` namespace informers
@@ -337,12 +367,10 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		// disable health check for console route
 		false,
 		// top level config
-		configClient.ConfigV1(),
 		configInformers,
 		// clients
 		operatorClient,
 		routesClient.RouteV1(),
-		kubeClient.CoreV1(),
 		// route
 		operatorConfigInformers.Operator().V1().Consoles(),
 		kubeInformersConfigNamespaced.Core().V1().Secrets(), // `openshift-config` namespace informers
@@ -352,12 +380,8 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 	)
 
 	consoleRouteHealthCheckController := healthcheck.NewHealthCheckController(
-		// top level config
-		configClient.ConfigV1(),
 		// clients
 		operatorClient,
-		routesClient.RouteV1(),
-		kubeClient.CoreV1(),
 		// route
 		operatorConfigInformers.Operator().V1().Consoles(),
 		configInformers,                     // Config
@@ -424,6 +448,9 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 	})
 
 	// NOTE: be sure to uncomment the .Run() below if using this
+	// The controller comes from library-go, which does not expose its sync function,
+	// so unlike the controllers of the operator its syncs are not instrumented, see
+	// metrics.InstrumentSync. It only drops the conditions listed below.
 	staleConditionsController := staleconditions.NewRemoveStaleConditionsController(
 		[]string{
 			// If a condition is removed, we need to add it here for at least
@@ -465,6 +492,7 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		policyClient,
 		// informers
 		kubeInformersNamespaced.Policy().V1().PodDisruptionBudgets(),
+		kubeInformersConfigNamespaced.Core().V1().ConfigMaps(),
 		//events
 		recorder,
 	)
@@ -477,6 +505,7 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		policyClient,
 		// informers
 		kubeInformersNamespaced.Policy().V1().PodDisruptionBudgets(),
+		kubeInformersConfigNamespaced.Core().V1().ConfigMaps(),
 		//events
 		recorder,
 	)
@@ -492,13 +521,12 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		kubeInformersNamespaced,
 		kubeInformersConfigNamespaced,
 		kubeInformersManagedNamespaced,
-		kubeInformersMonitoringNamespaced,
 		resourceSyncerInformers,
 		operatorConfigInformers,
 		consoleInformers,
 		configInformers,
 		routesInformersNamespaced,
-		dynamicInformers,
+		optionalInformers,
 		oauthClientsSwitchedInformer,
 	} {
 		informer.Start(ctx.Done())
@@ -517,6 +545,8 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		downloadsServiceController,
 		downloadsRouteController,
 		consoleOperator,
+		consoleConfigMapController,
+		publicConfigController,
 		cliDownloadsController,
 		downloadsDeploymentController,
 		consoleRouteHealthCheckController,
@@ -525,6 +555,7 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 		oauthClientController,
 		oauthClientSecretController,
 		oidcSetupController,
+		oidcIssuerController,
 		upgradeNotificationController,
 		staleConditionsController,
 	} {
@@ -536,27 +567,6 @@ func RunOperator(ctx context.Context, controllerContext *controllercmd.Controlle
 }
 
 // startResourceSyncing should start syncing process of all secrets and configmaps that need to be synced.
-func startStaticResourceSyncing(resourceSyncer *resourcesynccontroller.ResourceSyncController) error {
-	// sync: 'oauth-serving-cert' configmap
-	// from: 'openshift-config-managed' namespace
-	// to:   'openshift-console' namespace
-	err := resourceSyncer.SyncConfigMap(
-		resourcesynccontroller.ResourceLocation{Name: api.OAuthServingCertConfigMapName, Namespace: api.OpenShiftConsoleNamespace},
-		resourcesynccontroller.ResourceLocation{Name: api.OAuthServingCertConfigMapName, Namespace: api.OpenShiftConfigManagedNamespace},
-	)
-	if err != nil {
-		return err
-	}
-
-	// sync: 'default-ingress-cert' configmap
-	// from: 'openshift-config-managed' namespace
-	// to:   'openshift-console' namespace
-	return resourceSyncer.SyncConfigMap(
-		resourcesynccontroller.ResourceLocation{Name: api.DefaultIngressCertConfigMapName, Namespace: api.OpenShiftConsoleNamespace},
-		resourcesynccontroller.ResourceLocation{Name: api.DefaultIngressCertConfigMapName, Namespace: api.OpenShiftConfigManagedNamespace},
-	)
-}
-
 func getResourceSyncer(controllerContext *controllercmd.ControllerContext, kubeClient kubernetes.Interface, operatorClient v1helpers.OperatorClient) (v1helpers.KubeInformersForNamespaces, *resourcesynccontroller.ResourceSyncController) {
 	resourceSyncerInformers := v1helpers.NewKubeInformersForNamespaces(
 		kubeClient,
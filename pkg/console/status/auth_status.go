@@ -16,6 +16,8 @@ const (
 	conditionTypeDegraded    = "Degraded"
 	conditionTypeProgressing = "Progressing"
 	conditionTypeAvailable   = "Available"
+
+	conditionTypeIssuerReachable = "IssuerReachable"
 )
 
 type AuthStatusHandler struct {
@@ -23,8 +25,12 @@ type AuthStatusHandler struct {
 	componentName      string
 	componentNamespace string
 	fieldManager       string
+	conditionTypes     []string
 	conditionsToApply  map[string]*metav1.Condition
 	currentClientID    string
+	// the provider of the current client, which is not necessarily the first one
+	currentProviderName      string
+	currentProviderIssuerURL string
 }
 
 // NewAuthStatusHandler creates a handler for updating the Authentication.config.openshift.io
@@ -36,10 +42,21 @@ func NewAuthStatusHandler(authnClient configv1client.AuthenticationInterface, co
 		componentName:      componentName,
 		componentNamespace: componentNamespace,
 		fieldManager:       fieldManager,
+		conditionTypes:     []string{conditionTypeDegraded, conditionTypeProgressing, conditionTypeAvailable},
 		conditionsToApply:  map[string]*metav1.Condition{},
 	}
 }
 
+// NewIssuerStatusHandler creates a handler which only reports whether the OIDC
+// issuer is reachable by the console. It must use a field manager of its own, so
+// that it leaves the conditions of the handler of the OIDC client alone.
+// Not thread safe, only use in controllers with a single worker!
+func NewIssuerStatusHandler(authnClient configv1client.AuthenticationInterface, componentName, componentNamespace, fieldManager string) *AuthStatusHandler {
+	handler := NewAuthStatusHandler(authnClient, componentName, componentNamespace, fieldManager)
+	handler.conditionTypes = []string{conditionTypeIssuerReachable}
+	return handler
+}
+
 // Degraded sets the Degraded condition to True and Progressing to False
 func (c *AuthStatusHandler) Degraded(reason, message string) {
 	now := metav1.Now()
@@ -70,6 +87,16 @@ func (c *AuthStatusHandler) Available(reason, message string) {
 	c.setCondition(conditionTypeDegraded, metav1.ConditionFalse, reason, message, now)
 }
 
+// IssuerReachable sets the IssuerReachable condition to True
+func (c *AuthStatusHandler) IssuerReachable(reason, message string) {
+	c.setCondition(conditionTypeIssuerReachable, metav1.ConditionTrue, reason, message, metav1.Now())
+}
+
+// IssuerUnreachable sets the IssuerReachable condition to False
+func (c *AuthStatusHandler) IssuerUnreachable(reason, message string) {
+	c.setCondition(conditionTypeIssuerReachable, metav1.ConditionFalse, reason, message, metav1.Now())
+}
+
 func (c *AuthStatusHandler) setCondition(conditionType string, status metav1.ConditionStatus, reason, message string, ts metav1.Time) {
 	if c.conditionsToApply[conditionType] == nil {
 		c.conditionsToApply[conditionType] = &metav1.Condition{Type: string(conditionType)}
@@ -81,8 +108,13 @@ func (c *AuthStatusHandler) setCondition(conditionType string, status metav1.Con
 	c.conditionsToApply[conditionType].LastTransitionTime = ts
 }
 
-func (c *AuthStatusHandler) WithCurrentOIDCClient(currentClientID string) {
+func (c *AuthStatusHandler) WithCurrentOIDCClient(oidcProvider *configv1.OIDCProvider, currentClientID string) {
 	c.currentClientID = currentClientID
+	c.currentProviderName, c.currentProviderIssuerURL = "", ""
+	if oidcProvider != nil {
+		c.currentProviderName = oidcProvider.Name
+		c.currentProviderIssuerURL = oidcProvider.Issuer.URL
+	}
 }
 
 func (c *AuthStatusHandler) Apply(ctx context.Context, authnConfig *configv1.Authentication) error {
@@ -101,26 +133,24 @@ func (c *AuthStatusHandler) Apply(ctx context.Context, authnConfig *configv1.Aut
 	}
 
 	if len(c.currentClientID) > 0 {
-		var providerName, providerIssuerURL string
-		if len(authnConfig.Spec.OIDCProviders) > 0 {
-			providerName = authnConfig.Spec.OIDCProviders[0].Name
-			providerIssuerURL = authnConfig.Spec.OIDCProviders[0].Issuer.URL
-		}
-
 		clientStatus.WithCurrentOIDCClients(
 			&configv1ac.OIDCClientReferenceApplyConfiguration{
-				OIDCProviderName: &providerName,
-				IssuerURL:        &providerIssuerURL,
+				OIDCProviderName: &c.currentProviderName,
+				IssuerURL:        &c.currentProviderIssuerURL,
 				ClientID:         &c.currentClientID,
 			},
 		)
 	}
 
 	if authnConfig.Spec.Type == configv1.AuthenticationTypeOIDC {
-		for _, conditionType := range []string{conditionTypeDegraded, conditionTypeProgressing, conditionTypeAvailable} {
+		for _, conditionType := range c.conditionTypes {
+			existing := existingOrNewCondition(applyConfig, conditionType)
 			condition := c.conditionsToApply[conditionType]
 			if condition == nil {
-				condition = existingOrNewCondition(applyConfig, conditionType)
+				condition = existing
+			} else if condition.Status == existing.Status {
+				// the transition time only moves when the status flips
+				condition.LastTransitionTime = existing.LastTransitionTime
 			}
 			clientStatus.WithConditions(*condition)
 		}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/klog/v2"
@@ -76,6 +77,71 @@ func HandleUpgradable(typePrefix string, reason string, err error) ConditionUpda
 	}
 }
 
+// HandleRotated records when the secret of the given category was last rotated
+// on a condition of type <typePrefix>Rotated. The condition is informational, it
+// is not aggregated on the console ClusterOperator.
+func HandleRotated(typePrefix string, rotatedAt time.Time) ConditionUpdate {
+	conditionType := typePrefix + "Rotated"
+	condition := operatorsv1.OperatorCondition{
+		Type:    conditionType,
+		Status:  operatorsv1.ConditionTrue,
+		Reason:  "Rotated",
+		Message: fmt.Sprintf("last rotated at %s", rotatedAt.UTC().Format(time.RFC3339)),
+	}
+	return ConditionUpdate{
+		ConditionType:  conditionType,
+		StatusUpdateFn: v1helpers.UpdateConditionFn(condition),
+	}
+}
+
+// ClearRotated removes the <typePrefix>Rotated condition once the secret of the
+// given category is no longer rotated.
+func ClearRotated(typePrefix string) ConditionUpdate {
+	conditionType := typePrefix + "Rotated"
+	return ConditionUpdate{
+		ConditionType: conditionType,
+		StatusUpdateFn: func(status *operatorsv1.OperatorStatus) error {
+			v1helpers.RemoveOperatorCondition(&status.Conditions, conditionType)
+			return nil
+		},
+	}
+}
+
+// HandleReachable reports whether the endpoint of the given category is reachable
+// on a condition of type <typePrefix>Reachable. The endpoint being down is not an
+// error of the operator, so the condition is not aggregated on the console
+// ClusterOperator either.
+func HandleReachable(typePrefix string, reason string, err error) ConditionUpdate {
+	conditionType := typePrefix + "Reachable"
+	condition := operatorsv1.OperatorCondition{
+		Type:   conditionType,
+		Status: operatorsv1.ConditionTrue,
+		Reason: "AsExpected",
+	}
+	if err != nil {
+		condition.Status = operatorsv1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = err.Error()
+	}
+	return ConditionUpdate{
+		ConditionType:  conditionType,
+		StatusUpdateFn: v1helpers.UpdateConditionFn(condition),
+	}
+}
+
+// ClearReachable removes the <typePrefix>Reachable condition once the endpoint of
+// the given category is no longer in use.
+func ClearReachable(typePrefix string) ConditionUpdate {
+	conditionType := typePrefix + "Reachable"
+	return ConditionUpdate{
+		ConditionType: conditionType,
+		StatusUpdateFn: func(status *operatorsv1.OperatorStatus) error {
+			v1helpers.RemoveOperatorCondition(&status.Conditions, conditionType)
+			return nil
+		},
+	}
+}
+
 func (c *StatusHandler) ResetConditions(conditions []operatorsv1.OperatorCondition) []ConditionUpdate {
 	updateStatusFuncs := []ConditionUpdate{}
 	for _, condition := range conditions {
@@ -1,6 +1,35 @@
 package configmap
 
-import v1 "github.com/openshift/api/operator/v1"
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+	v1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+const (
+	// the masthead shows the logo scaled down, anything bigger is a mistake
+	CustomLogoMaxBytes     = 512 * 1024
+	CustomLogoMaxDimension = 2048
+
+	// reasons of the CustomLogoSync condition when the image is rejected
+	CustomLogoUnsupportedFormatReason = "UnsupportedFormat"
+	CustomLogoTooLargeReason          = "TooLarge"
+	CustomLogoCorruptImageReason      = "CorruptImage"
+	CustomLogoUnsafeSVGReason         = "UnsafeSVG"
+)
 
 // borrowed from the image package
 // image.RegisterFormat()
@@ -11,6 +40,87 @@ var commonImageHeaders = []string{
 	"GIF89a",            // "image/gif"
 }
 
+// the header of ICO files, served as image/x-icon or image/vnd.microsoft.icon:
+// a reserved zero word followed by the type of the file, 1 for icons
+const icoHeader = "\x00\x00\x01\x00"
+
+// ValidateCustomLogoImage checks that the logo is a PNG, JPEG, GIF or SVG image the
+// masthead can render, and returns the reason it is rejected otherwise.
+func ValidateCustomLogoImage(logo []byte) (reason string, err error) {
+	if len(logo) > CustomLogoMaxBytes {
+		return CustomLogoTooLargeReason, fmt.Errorf("custom logo is %d bytes, it must not exceed %d bytes", len(logo), CustomLogoMaxBytes)
+	}
+	for _, header := range commonImageHeaders {
+		if bytes.HasPrefix(logo, []byte(header)) {
+			return validateRasterImage(logo)
+		}
+	}
+	if isSVG(logo) {
+		return validateSVGImage(logo)
+	}
+	return CustomLogoUnsupportedFormatReason, errors.New("custom logo must be a PNG, JPEG, GIF or SVG image")
+}
+
+// ValidateCustomFaviconImage checks that the favicon is an ICO image, or any image
+// a custom logo can be, and returns the reason it is rejected otherwise.
+func ValidateCustomFaviconImage(favicon []byte) (reason string, err error) {
+	if len(favicon) > CustomLogoMaxBytes {
+		return CustomLogoTooLargeReason, fmt.Errorf("custom favicon is %d bytes, it must not exceed %d bytes", len(favicon), CustomLogoMaxBytes)
+	}
+	if bytes.HasPrefix(favicon, []byte(icoHeader)) {
+		return validateICOImage(favicon)
+	}
+	reason, err = ValidateCustomLogoImage(favicon)
+	if reason == CustomLogoUnsupportedFormatReason {
+		return reason, errors.New("custom favicon must be an ICO, PNG, JPEG, GIF or SVG image")
+	}
+	return reason, err
+}
+
+// validateICOImage checks that the directory of the ICO file lists at least one
+// image and that every image it lists lies within the file. The images are PNG
+// or BMP data, browsers skip the ones they can not decode.
+func validateICOImage(favicon []byte) (reason string, err error) {
+	const (
+		directoryBytes = 6
+		entryBytes     = 16
+	)
+	if len(favicon) < directoryBytes {
+		return CustomLogoCorruptImageReason, errors.New("custom ICO favicon is truncated")
+	}
+	count := int(binary.LittleEndian.Uint16(favicon[4:directoryBytes]))
+	if count == 0 {
+		return CustomLogoCorruptImageReason, errors.New("custom ICO favicon holds no image")
+	}
+	if len(favicon) < directoryBytes+count*entryBytes {
+		return CustomLogoCorruptImageReason, errors.New("custom ICO favicon is truncated")
+	}
+	for i := 0; i < count; i++ {
+		entry := favicon[directoryBytes+i*entryBytes : directoryBytes+(i+1)*entryBytes]
+		size := uint64(binary.LittleEndian.Uint32(entry[8:12]))
+		offset := uint64(binary.LittleEndian.Uint32(entry[12:16]))
+		if size == 0 || offset+size > uint64(len(favicon)) {
+			return CustomLogoCorruptImageReason, fmt.Errorf("image %d of the custom ICO favicon lies outside of the file", i)
+		}
+	}
+	return "", nil
+}
+
+func validateRasterImage(logo []byte) (reason string, err error) {
+	// the header is read first, so that huge images are not decoded
+	config, format, err := image.DecodeConfig(bytes.NewReader(logo))
+	if err != nil {
+		return CustomLogoCorruptImageReason, fmt.Errorf("custom logo can not be decoded: %w", err)
+	}
+	if config.Width > CustomLogoMaxDimension || config.Height > CustomLogoMaxDimension {
+		return CustomLogoTooLargeReason, fmt.Errorf("custom logo is %dx%d pixels, it must not exceed %dx%d pixels", config.Width, config.Height, CustomLogoMaxDimension, CustomLogoMaxDimension)
+	}
+	if _, _, err := image.Decode(bytes.NewReader(logo)); err != nil {
+		return CustomLogoCorruptImageReason, fmt.Errorf("custom %s logo can not be decoded: %w", format, err)
+	}
+	return "", nil
+}
+
 func FileNameOrKeyInconsistentlySet(operatorConfig *v1.Console) bool {
 	logoConfigMapName := operatorConfig.Spec.Customization.CustomLogoFile.Name
 	logoImageKey := operatorConfig.Spec.Customization.CustomLogoFile.Key
@@ -27,3 +137,92 @@ func IsRemoved(operatorConfig *v1.Console) bool {
 	logoImageKey := operatorConfig.Spec.Customization.CustomLogoFile.Key
 	return logoConfigMapName == "" && logoImageKey == ""
 }
+
+// themes of the console the branding images are set for
+const (
+	ThemeLight = "Light"
+	ThemeDark  = "Dark"
+)
+
+// CustomBrandingFile is a further image of the console branding, next to the
+// custom logo of the operator config: a masthead logo per theme, or a favicon.
+// It is referenced by an annotation of the operator config, copied from
+// openshift-config into the named ConfigMap of openshift-console and mounted
+// in the console pods.
+type CustomBrandingFile struct {
+	Annotation    string
+	ConfigMapName string
+	Favicon       bool
+	Themes        []string
+}
+
+var CustomBrandingFiles = []CustomBrandingFile{
+	{
+		Annotation:    api.CustomLogoLightAnnotation,
+		ConfigMapName: api.CustomLogoLightConfigMapName,
+		Themes:        []string{ThemeLight},
+	},
+	{
+		Annotation:    api.CustomLogoDarkAnnotation,
+		ConfigMapName: api.CustomLogoDarkConfigMapName,
+		Themes:        []string{ThemeDark},
+	},
+	{
+		// browsers show the favicon whatever the theme
+		Annotation:    api.CustomFaviconAnnotation,
+		ConfigMapName: api.CustomFaviconConfigMapName,
+		Favicon:       true,
+		Themes:        []string{ThemeLight, ThemeDark},
+	},
+}
+
+// Reference returns the ConfigMap of openshift-config and the key the annotation
+// references, or nothing if the annotation is unset.
+func (f CustomBrandingFile) Reference(operatorConfig *v1.Console) (configv1.ConfigMapFileReference, error) {
+	value := operatorConfig.GetAnnotations()[f.Annotation]
+	if len(value) == 0 {
+		return configv1.ConfigMapFileReference{}, nil
+	}
+	name, key, found := strings.Cut(value, "/")
+	if !found || len(name) == 0 || len(key) == 0 || strings.Contains(key, "/") {
+		return configv1.ConfigMapFileReference{}, fmt.Errorf("invalid %s annotation %q: must be <configmap>/<key>", f.Annotation, value)
+	}
+	return configv1.ConfigMapFileReference{Name: name, Key: key}, nil
+}
+
+func (f CustomBrandingFile) isCopied(customBrandingConfigMaps []*corev1.ConfigMap) bool {
+	for _, configMap := range customBrandingConfigMaps {
+		if configMap != nil && configMap.Name == f.ConfigMapName {
+			return true
+		}
+	}
+	return false
+}
+
+// MountDir returns where the copy of the image is mounted in the console pods.
+func (f CustomBrandingFile) MountDir() string {
+	return api.CustomBrandingMountDir + f.ConfigMapName + "/"
+}
+
+// CustomBrandingFilePaths returns the paths of the logos and of the favicons the
+// console serves for each theme. Only the images whose copy in openshift-console
+// is among the given ConfigMaps are served, the operator copies an image once it
+// is validated and the console deployment mounts the copies it finds. Invalid
+// references are left out.
+func CustomBrandingFilePaths(operatorConfig *v1.Console, customBrandingConfigMaps []*corev1.ConfigMap) (logos map[string]string, favicons map[string]string) {
+	logos, favicons = map[string]string{}, map[string]string{}
+	for _, file := range CustomBrandingFiles {
+		reference, err := file.Reference(operatorConfig)
+		if err != nil || len(reference.Key) == 0 || !file.isCopied(customBrandingConfigMaps) {
+			continue
+		}
+		paths := logos
+		if file.Favicon {
+			paths = favicons
+		}
+		for _, theme := range file.Themes {
+			paths[theme] = file.MountDir() + reference.Key
+		}
+	}
+	return logos, favicons
+}
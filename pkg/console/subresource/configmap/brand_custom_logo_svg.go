@@ -0,0 +1,237 @@
+package configmap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	svgNamespace   = "http://www.w3.org/2000/svg"
+	xlinkNamespace = "http://www.w3.org/1999/xlink"
+	xmlNamespace   = "http://www.w3.org/XML/1998/namespace"
+)
+
+// svgElements are the elements an SVG logo may be drawn with, lowercased. Scripts,
+// animations, links and foreign content are left out, so are the elements which
+// load further documents.
+var svgElements = sets.NewString(
+	"svg", "g", "defs", "desc", "title", "metadata", "symbol", "use", "switch", "style",
+	"path", "rect", "circle", "ellipse", "line", "polyline", "polygon", "image",
+	"text", "tspan", "textpath",
+	"lineargradient", "radialgradient", "stop", "clippath", "mask", "pattern", "marker",
+	"filter", "feblend", "fecolormatrix", "fecomponenttransfer", "fecomposite",
+	"feconvolvematrix", "fediffuselighting", "fedisplacementmap", "fedistantlight",
+	"fedropshadow", "feflood", "fefunca", "fefuncb", "fefuncg", "fefuncr",
+	"fegaussianblur", "feimage", "femerge", "femergenode", "femorphology", "feoffset",
+	"fepointlight", "fespecularlighting", "fespotlight", "fetile", "feturbulence",
+)
+
+// svgAttributes are the attributes of the SVG namespace the elements above may be
+// set with, lowercased. Event handlers and animation timing are left out.
+var svgAttributes = sets.NewString(
+	// core and styling
+	"id", "class", "style", "lang", "version", "baseprofile", "systemlanguage", "requiredfeatures", "requiredextensions",
+	// geometry
+	"x", "y", "x1", "y1", "x2", "y2", "cx", "cy", "r", "rx", "ry", "fx", "fy", "fr",
+	"width", "height", "d", "points", "pathlength", "viewbox", "preserveaspectratio",
+	"transform", "gradienttransform", "gradientunits", "patterntransform", "patternunits",
+	"patterncontentunits", "spreadmethod", "offset", "clippathunits", "maskunits",
+	"maskcontentunits", "filterunits", "primitiveunits", "markerwidth", "markerheight",
+	"markerunits", "refx", "refy", "orient", "href",
+	// text
+	"dx", "dy", "rotate", "textlength", "lengthadjust", "text-anchor", "startoffset", "method", "spacing", "side",
+	"font-family", "font-size", "font-weight", "font-style", "font-variant", "font-stretch", "font-size-adjust",
+	"letter-spacing", "word-spacing", "text-decoration", "dominant-baseline", "alignment-baseline",
+	"baseline-shift", "writing-mode", "direction", "unicode-bidi",
+	// presentation
+	"fill", "fill-opacity", "fill-rule", "stroke", "stroke-width", "stroke-linecap", "stroke-linejoin",
+	"stroke-miterlimit", "stroke-dasharray", "stroke-dashoffset", "stroke-opacity", "opacity", "color",
+	"display", "visibility", "overflow", "clip", "clip-path", "clip-rule", "mask", "filter",
+	"marker-start", "marker-mid", "marker-end", "stop-color", "stop-opacity", "flood-color",
+	"flood-opacity", "lighting-color", "color-interpolation", "color-interpolation-filters",
+	"color-rendering", "shape-rendering", "text-rendering", "image-rendering", "vector-effect",
+	"paint-order", "mix-blend-mode", "isolation", "enable-background",
+	// filter primitives
+	"in", "in2", "result", "stddeviation", "mode", "type", "values", "operator", "k1", "k2", "k3", "k4",
+	"radius", "scale", "xchannelselector", "ychannelselector", "basefrequency", "numoctaves", "seed",
+	"stitchtiles", "tablevalues", "slope", "intercept", "amplitude", "exponent", "kernelmatrix", "order",
+	"divisor", "bias", "targetx", "targety", "edgemode", "preservealpha", "surfacescale",
+	"diffuseconstant", "specularconstant", "specularexponent", "kernelunitlength", "azimuth",
+	"elevation", "z", "pointsatx", "pointsaty", "pointsatz", "limitingconeangle",
+)
+
+// editorNamespaces hold the metadata vector editors save along with the drawing,
+// browsers do not render it.
+var editorNamespaces = sets.NewString(
+	"http://www.inkscape.org/namespaces/inkscape",
+	"http://sodipodi.sourceforge.net/DTD/sodipodi-0.dtd",
+	"http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"http://creativecommons.org/ns#",
+	"http://purl.org/dc/elements/1.1/",
+	"http://ns.adobe.com/AdobeIllustrator/10.0/",
+	"http://ns.adobe.com/Extensibility/1.0/",
+)
+
+var (
+	// CSS escapes, e.g. \6a or \:, see https://www.w3.org/TR/css-syntax-3/#consume-escaped-code-point
+	cssEscape  = regexp.MustCompile(`\\([0-9a-fA-F]{1,6}\s?|[^0-9a-fA-F\n])`)
+	cssComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	cssURL     = regexp.MustCompile(`url\(([^)]*)\)`)
+	// images embedded as data URLs, which browsers do not run scripts in
+	embeddedImage = regexp.MustCompile(`^data:image/(png|jpeg|gif)[;,]`)
+)
+
+// isSVG returns whether the root element of the document is an svg element.
+func isSVG(logo []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(logo))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			return element.Name.Local == "svg"
+		}
+	}
+}
+
+// validateSVGImage only accepts SVG images drawn with the elements and attributes
+// allowed above, whose links and styles only refer to the image itself or to
+// embedded raster images. The console serves the logo from its own origin.
+func validateSVGImage(logo []byte) (reason string, err error) {
+	decoder := xml.NewDecoder(bytes.NewReader(logo))
+	root := true
+	inStyle := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return CustomLogoCorruptImageReason, fmt.Errorf("custom SVG logo can not be parsed: %w", err)
+		}
+		switch token := token.(type) {
+		case xml.Directive:
+			// entities declared in the document are expanded by browsers only
+			if strings.Contains(strings.ToUpper(string(token)), "ENTITY") {
+				return CustomLogoUnsafeSVGReason, errors.New("custom SVG logo must not declare entities")
+			}
+		case xml.ProcInst:
+			// e.g. xml-stylesheet, which loads further documents
+			if token.Target != "xml" {
+				return CustomLogoUnsafeSVGReason, fmt.Errorf("custom SVG logo must not contain %s processing instructions", token.Target)
+			}
+		case xml.CharData:
+			if inStyle && !isSafeCSS(string(token)) {
+				return CustomLogoUnsafeSVGReason, errors.New("custom SVG logo must not style with scripts or external resources")
+			}
+		case xml.EndElement:
+			inStyle = false
+		case xml.StartElement:
+			if reason, err := validateSVGElement(token); err != nil {
+				return reason, err
+			}
+			inStyle = strings.EqualFold(token.Name.Local, "style")
+			if root {
+				root = false
+				if svgDimensionTooLarge(token, "width") || svgDimensionTooLarge(token, "height") {
+					return CustomLogoTooLargeReason, fmt.Errorf("custom SVG logo must not exceed %dx%d pixels", CustomLogoMaxDimension, CustomLogoMaxDimension)
+				}
+			}
+		}
+	}
+}
+
+func validateSVGElement(element xml.StartElement) (reason string, err error) {
+	switch {
+	case editorNamespaces.Has(element.Name.Space):
+	case element.Name.Space != "" && element.Name.Space != svgNamespace,
+		!svgElements.Has(strings.ToLower(element.Name.Local)):
+		return CustomLogoUnsafeSVGReason, fmt.Errorf("custom SVG logo must not contain %s elements", element.Name.Local)
+	}
+	for _, attr := range element.Attr {
+		name := strings.ToLower(attr.Name.Local)
+		switch {
+		case attr.Name.Space == "xmlns", attr.Name.Space == "" && name == "xmlns":
+			// namespace declarations
+			continue
+		case attr.Name.Space == xmlNamespace, editorNamespaces.Has(attr.Name.Space):
+		case attr.Name.Space == xlinkNamespace, attr.Name.Space == "" && svgAttributes.Has(name):
+		case attr.Name.Space == "" && strings.HasPrefix(name, "data-"):
+			// inert, e.g. the layer names saved by Illustrator
+		default:
+			return CustomLogoUnsafeSVGReason, fmt.Errorf("custom SVG logo must not contain %s attributes", attr.Name.Local)
+		}
+		if name == "href" && !isSafeSVGReference(attr.Value) {
+			return CustomLogoUnsafeSVGReason, errors.New("custom SVG logo must only link to its own elements or to embedded PNG, JPEG or GIF images")
+		}
+		if !isSafeCSS(attr.Value) {
+			return CustomLogoUnsafeSVGReason, fmt.Errorf("custom SVG logo must not set %s to scripts or external resources", attr.Name.Local)
+		}
+	}
+	return "", nil
+}
+
+// isSafeSVGReference returns whether the link refers to an element of the image
+// itself or to an embedded raster image.
+func isSafeSVGReference(reference string) bool {
+	// browsers strip whitespace and control characters from URLs
+	reference = strings.ToLower(strings.Map(dropSpace, reference))
+	return strings.HasPrefix(reference, "#") || embeddedImage.MatchString(reference)
+}
+
+// isSafeCSS returns whether the style sheet, or the value of an attribute, which
+// may be a CSS value, neither runs scripts nor loads external resources.
+func isSafeCSS(css string) bool {
+	css = cssEscape.ReplaceAllStringFunc(css, unescapeCSS)
+	css = cssComment.ReplaceAllString(css, "")
+	css = strings.ToLower(strings.Map(dropSpace, css))
+	for _, unsafe := range []string{"javascript:", "vbscript:", "expression(", "@import", "-moz-binding", "behavior:"} {
+		if strings.Contains(css, unsafe) {
+			return false
+		}
+	}
+	for _, match := range cssURL.FindAllStringSubmatch(css, -1) {
+		if !isSafeSVGReference(strings.Trim(match[1], `"'`)) {
+			return false
+		}
+	}
+	return true
+}
+
+func unescapeCSS(escape string) string {
+	escaped := strings.TrimSpace(escape[1:])
+	codePoint, err := strconv.ParseUint(escaped, 16, 32)
+	if err != nil {
+		return escaped
+	}
+	return string(rune(codePoint))
+}
+
+func dropSpace(r rune) rune {
+	if r <= ' ' || r == 0x7f {
+		return -1
+	}
+	return r
+}
+
+// svgDimensionTooLarge checks the width or height of the svg element when set in
+// pixels, relative units scale with the masthead.
+func svgDimensionTooLarge(element xml.StartElement, name string) bool {
+	for _, attr := range element.Attr {
+		if attr.Name.Local != name {
+			continue
+		}
+		dimension, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(attr.Value), "px"), 64)
+		return err == nil && dimension > CustomLogoMaxDimension
+	}
+	return false
+}
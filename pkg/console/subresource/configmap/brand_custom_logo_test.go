@@ -1,13 +1,21 @@
 package configmap
 
 import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
 	"testing"
 
 	v1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	operator "github.com/openshift/api/operator/v1"
 
 	"github.com/go-test/deep"
+
+	"github.com/openshift/console-operator/pkg/api"
 )
 
 func TestOnlyFileOrKeySet(t *testing.T) {
@@ -183,3 +191,371 @@ func TestIsRemoved(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCustomLogoImage(t *testing.T) {
+	encodePNG := func(width, height int) []byte {
+		buf := &bytes.Buffer{}
+		if err := png.Encode(buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	validPNG := encodePNG(200, 50)
+
+	tests := []struct {
+		name       string
+		logo       []byte
+		wantReason string
+	}{
+		{
+			name: "Test valid PNG logo",
+			logo: validPNG,
+		},
+		{
+			name: "Test valid SVG logo",
+			logo: []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" width="200" height="50"><rect width="200" height="50" fill="red"/></svg>`),
+		},
+		{
+			name: "Test SVG logo in relative units",
+			logo: []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="100%" height="5em"></svg>`),
+		},
+		{
+			name:       "Test unsupported format",
+			logo:       []byte("BM this is a bitmap"),
+			wantReason: CustomLogoUnsupportedFormatReason,
+		},
+		{
+			name:       "Test XML which is not an SVG",
+			logo:       []byte(`<html><body/></html>`),
+			wantReason: CustomLogoUnsupportedFormatReason,
+		},
+		{
+			name:       "Test logo above the byte size limit",
+			logo:       append(validPNG, make([]byte, CustomLogoMaxBytes)...),
+			wantReason: CustomLogoTooLargeReason,
+		},
+		{
+			name:       "Test PNG logo above the dimension limit",
+			logo:       encodePNG(CustomLogoMaxDimension+1, 1),
+			wantReason: CustomLogoTooLargeReason,
+		},
+		{
+			name:       "Test SVG logo above the dimension limit",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="10000px" height="50"></svg>`),
+			wantReason: CustomLogoTooLargeReason,
+		},
+		{
+			name:       "Test truncated PNG logo",
+			logo:       validPNG[:len(validPNG)/2],
+			wantReason: CustomLogoCorruptImageReason,
+		},
+		{
+			name:       "Test corrupt JPEG logo",
+			logo:       []byte("\xff\xd8\xff not really a jpeg"),
+			wantReason: CustomLogoCorruptImageReason,
+		},
+		{
+			name:       "Test malformed SVG logo",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><rect></svg>`),
+			wantReason: CustomLogoCorruptImageReason,
+		},
+		{
+			name:       "Test SVG logo with a script",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with an event handler",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with a javascript link",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a xlink:href=" JavaScript:alert(1)"><rect/></a></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name: "Test SVG logo as saved by vector editors",
+			logo: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:inkscape="http://www.inkscape.org/namespaces/inkscape" xmlns:sodipodi="http://sodipodi.sourceforge.net/DTD/sodipodi-0.dtd" xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:cc="http://creativecommons.org/ns#" xml:space="preserve" viewBox="0 0 200 50" inkscape:version="1.3">
+  <sodipodi:namedview id="view" inkscape:zoom="1"/>
+  <metadata><rdf:RDF><cc:Work rdf:about=""/></rdf:RDF></metadata>
+  <defs>
+    <style>.cls-1{fill:url(#gradient);} .cls-2{fill:#c00;font-family:"Red Hat Display"}</style>
+    <linearGradient id="gradient" x1="0" y1="0" x2="1" y2="0"><stop offset="0" stop-color="#fff"/><stop offset="1" stop-color="#000"/></linearGradient>
+  </defs>
+  <g id="Layer_1" data-name="Layer 1" inkscape:label="Layer 1">
+    <rect class="cls-1" width="200" height="50" style="opacity:0.5"/>
+    <use xlink:href="#Layer_1" x="10"/>
+    <image width="10" height="10" href="data:image/png;base64,iVBORw0KGgo="/>
+    <text class="cls-2" x="10" y="40">Console</text>
+  </g>
+</svg>`),
+		},
+		{
+			name:       "Test SVG logo animating a link to a script",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><animate attributeName="href" to="javascript:alert(1)"/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo setting a link to a script",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><set attributeName="href" values="javascript:alert(1)"/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with a script in a style element",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><style>rect { fill: url( "javascript:alert(1)" ) }</style><rect/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo importing an escaped external style sheet",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><style><![CDATA[@\69mport url(https://example.com/logo.css);]]></style></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with a script in a style attribute",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><rect style="fill:url(java/**/script:alert(1))"/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with a CSS escaped script in a presentation attribute",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><rect fill="url(\6a avascript:alert(1))"/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with an entity encoded javascript link",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><use xlink:href="&#x6A;ava&#9;script:alert(1)"/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo declaring entities",
+			logo:       []byte(`<!DOCTYPE svg [<!ENTITY js "javascript:">]><svg xmlns="http://www.w3.org/2000/svg"></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with a style sheet processing instruction",
+			logo:       []byte(`<?xml-stylesheet href="https://example.com/logo.css"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo linking to an external image",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><image href="https://example.com/logo.svg"/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with a data URL which is not an image",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><image href="data:text/html;base64,PHNjcmlwdD4="/></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+		{
+			name:       "Test SVG logo with foreign content",
+			logo:       []byte(`<svg xmlns="http://www.w3.org/2000/svg"><foreignObject><div xmlns="http://www.w3.org/1999/xhtml"/></foreignObject></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := ValidateCustomLogoImage(tt.logo)
+			if reason != tt.wantReason {
+				t.Errorf("ValidateCustomLogoImage() reason = %q, want %q (error: %v)", reason, tt.wantReason, err)
+			}
+			if (err != nil) != (len(tt.wantReason) > 0) {
+				t.Errorf("ValidateCustomLogoImage() error = %v, want reason %q", err, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestValidateCustomFaviconImage(t *testing.T) {
+	// an ICO directory listing a single 16x16 image of the given size at the given offset
+	encodeICO := func(size, offset uint32, image []byte) []byte {
+		ico := []byte{0, 0, 1, 0, 1, 0, 16, 16, 0, 0, 1, 0, 32, 0}
+		ico = binary.LittleEndian.AppendUint32(ico, size)
+		ico = binary.LittleEndian.AppendUint32(ico, offset)
+		return append(ico, image...)
+	}
+	pngBuf := &bytes.Buffer{}
+	if err := png.Encode(pngBuf, image.NewGray(image.Rect(0, 0, 16, 16))); err != nil {
+		t.Fatal(err)
+	}
+	validPNG := pngBuf.Bytes()
+
+	tests := []struct {
+		name       string
+		favicon    []byte
+		wantReason string
+	}{
+		{
+			name:    "Test valid ICO favicon",
+			favicon: encodeICO(uint32(len(validPNG)), 22, validPNG),
+		},
+		{
+			name:    "Test valid PNG favicon",
+			favicon: validPNG,
+		},
+		{
+			name:    "Test valid SVG favicon",
+			favicon: []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16"></svg>`),
+		},
+		{
+			name:       "Test ICO favicon without images",
+			favicon:    []byte{0, 0, 1, 0, 0, 0},
+			wantReason: CustomLogoCorruptImageReason,
+		},
+		{
+			name:       "Test ICO favicon with a truncated directory",
+			favicon:    []byte{0, 0, 1, 0, 2, 0, 16, 16},
+			wantReason: CustomLogoCorruptImageReason,
+		},
+		{
+			name:       "Test ICO favicon with an image past the end of the file",
+			favicon:    encodeICO(uint32(len(validPNG))+1, 22, validPNG),
+			wantReason: CustomLogoCorruptImageReason,
+		},
+		{
+			name:       "Test ICO favicon above the byte size limit",
+			favicon:    encodeICO(CustomLogoMaxBytes, 22, make([]byte, CustomLogoMaxBytes)),
+			wantReason: CustomLogoTooLargeReason,
+		},
+		{
+			name:       "Test unsupported format",
+			favicon:    []byte("BM this is a bitmap"),
+			wantReason: CustomLogoUnsupportedFormatReason,
+		},
+		{
+			name:       "Test SVG favicon with a script",
+			favicon:    []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+			wantReason: CustomLogoUnsafeSVGReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := ValidateCustomFaviconImage(tt.favicon)
+			if reason != tt.wantReason {
+				t.Errorf("ValidateCustomFaviconImage() reason = %q, want %q (error: %v)", reason, tt.wantReason, err)
+			}
+			if (err != nil) != (len(tt.wantReason) > 0) {
+				t.Errorf("ValidateCustomFaviconImage() error = %v, want reason %q", err, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCustomBrandingFilePaths(t *testing.T) {
+	allCopied := []*corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: api.CustomLogoLightConfigMapName}},
+		{ObjectMeta: metav1.ObjectMeta{Name: api.CustomLogoDarkConfigMapName}},
+		{ObjectMeta: metav1.ObjectMeta{Name: api.CustomFaviconConfigMapName}},
+	}
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		copied       []*corev1.ConfigMap
+		wantLogos    map[string]string
+		wantFavicons map[string]string
+	}{
+		{
+			name:         "No branding images",
+			wantLogos:    map[string]string{},
+			wantFavicons: map[string]string{},
+		},
+		{
+			name: "Logos per theme and a favicon",
+			annotations: map[string]string{
+				api.CustomLogoLightAnnotation: "branding/light.svg",
+				api.CustomLogoDarkAnnotation:  "branding/dark.svg",
+				api.CustomFaviconAnnotation:   "favicon/favicon.png",
+			},
+			copied: allCopied,
+			wantLogos: map[string]string{
+				ThemeLight: "/var/branding/custom-logo-light/light.svg",
+				ThemeDark:  "/var/branding/custom-logo-dark/dark.svg",
+			},
+			wantFavicons: map[string]string{
+				ThemeLight: "/var/branding/custom-favicon/favicon.png",
+				ThemeDark:  "/var/branding/custom-favicon/favicon.png",
+			},
+		},
+		{
+			name: "Invalid references are left out",
+			annotations: map[string]string{
+				api.CustomLogoLightAnnotation: "light.svg",
+				api.CustomLogoDarkAnnotation:  "branding/dark.svg",
+				api.CustomFaviconAnnotation:   "favicon/",
+			},
+			copied: allCopied,
+			wantLogos: map[string]string{
+				ThemeDark: "/var/branding/custom-logo-dark/dark.svg",
+			},
+			wantFavicons: map[string]string{},
+		},
+		{
+			name: "Images not copied yet are left out",
+			annotations: map[string]string{
+				api.CustomLogoLightAnnotation: "branding/light.svg",
+				api.CustomLogoDarkAnnotation:  "branding/dark.svg",
+				api.CustomFaviconAnnotation:   "favicon/favicon.ico",
+			},
+			copied: []*corev1.ConfigMap{
+				{ObjectMeta: metav1.ObjectMeta{Name: api.CustomLogoLightConfigMapName}},
+			},
+			wantLogos: map[string]string{
+				ThemeLight: "/var/branding/custom-logo-light/light.svg",
+			},
+			wantFavicons: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operator.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			logos, favicons := CustomBrandingFilePaths(operatorConfig, tt.copied)
+			if diff := deep.Equal(logos, tt.wantLogos); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(favicons, tt.wantFavicons); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCustomBrandingFileReference(t *testing.T) {
+	file := CustomBrandingFiles[0]
+	tests := []struct {
+		name    string
+		value   string
+		want    v1.ConfigMapFileReference
+		wantErr bool
+	}{
+		{
+			name: "Unset reference",
+		},
+		{
+			name:  "Valid reference",
+			value: "branding/logo.svg",
+			want:  v1.ConfigMapFileReference{Name: "branding", Key: "logo.svg"},
+		},
+		{
+			name:    "Reference without a key",
+			value:   "branding",
+			wantErr: true,
+		},
+		{
+			name:    "Reference with a nested key",
+			value:   "branding/logos/logo.svg",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operator.Console{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{file.Annotation: tt.value}}}
+			got, err := file.Reference(operatorConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
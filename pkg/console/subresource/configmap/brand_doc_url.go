@@ -0,0 +1,72 @@
+package configmap
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+
+	v1 "github.com/openshift/api/operator/v1"
+)
+
+// docURLTemplate is the documentation base URL of a brand, formatted with the
+// major and the minor version of the cluster, along with the generic URL used
+// when the version is unknown.
+type docURLTemplate struct {
+	versioned string
+	generic   string
+}
+
+var (
+	okdDocURL = docURLTemplate{
+		versioned: "https://docs.okd.io/%[1]d.%[2]d/",
+		generic:   "https://docs.okd.io/latest/",
+	}
+	ocpDocURL = docURLTemplate{
+		versioned: "https://access.redhat.com/documentation/en-us/openshift_container_platform/%[1]d.%[2]d/",
+		generic:   "https://access.redhat.com/documentation/en-us/openshift_container_platform/",
+	}
+	// the managed offerings are documented per major version only
+	dedicatedDocURL = docURLTemplate{
+		versioned: "https://access.redhat.com/documentation/en-us/openshift_dedicated/%[1]d/",
+		generic:   "https://access.redhat.com/documentation/en-us/openshift_dedicated/",
+	}
+	rosaDocURL = docURLTemplate{
+		versioned: "https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws/%[1]d/",
+		generic:   "https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws/",
+	}
+
+	docURLTemplates = map[v1.Brand]docURLTemplate{
+		v1.BrandOKD:             okdDocURL,
+		v1.BrandOKDLegacy:       okdDocURL,
+		v1.BrandOCP:             ocpDocURL,
+		v1.BrandOCPLegacy:       ocpDocURL,
+		v1.BrandOpenShift:       ocpDocURL,
+		v1.BrandOpenShiftLegacy: ocpDocURL,
+		v1.BrandOnline:          ocpDocURL,
+		v1.BrandOnlineLegacy:    ocpDocURL,
+		v1.BrandAzure:           ocpDocURL,
+		v1.BrandAzureLegacy:     ocpDocURL,
+		v1.BrandDedicated:       dedicatedDocURL,
+		v1.BrandDedicatedLegacy: dedicatedDocURL,
+		v1.BrandROSA:            rosaDocURL,
+	}
+)
+
+// GetDocURL returns the default documentation base URL of the brand for the
+// release version of the cluster, e.g. https://docs.okd.io/4.16/ for OKD 4.16.0.
+// Unknown brands are documented by the default brand of the build, and an
+// unparsable version gets the generic URL of the brand.
+func GetDocURL(brand v1.Brand, releaseVersion string) string {
+	template, ok := docURLTemplates[brand]
+	if !ok {
+		template, ok = docURLTemplates[v1.Brand(DEFAULT_BRAND)]
+	}
+	if !ok {
+		return DEFAULT_DOC_URL
+	}
+	version, err := semver.ParseTolerant(releaseVersion)
+	if err != nil || version.Major == 0 {
+		return template.generic
+	}
+	return fmt.Sprintf(template.versioned, version.Major, version.Minor)
+}
@@ -0,0 +1,78 @@
+package configmap
+
+import (
+	"testing"
+
+	v1 "github.com/openshift/api/operator/v1"
+)
+
+func TestGetDocURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		brand          v1.Brand
+		releaseVersion string
+		want           string
+	}{
+		{
+			name:           "Test OCP release",
+			brand:          v1.BrandOCP,
+			releaseVersion: "4.17.3",
+			want:           "https://access.redhat.com/documentation/en-us/openshift_container_platform/4.17/",
+		},
+		{
+			name:           "Test OCP nightly",
+			brand:          v1.BrandOCPLegacy,
+			releaseVersion: "4.18.0-0.nightly-2024-10-01-000000",
+			want:           "https://access.redhat.com/documentation/en-us/openshift_container_platform/4.18/",
+		},
+		{
+			name:           "Test OKD release",
+			brand:          v1.BrandOKD,
+			releaseVersion: "4.17.0-0.okd-2024-10-01-000000",
+			want:           "https://docs.okd.io/4.17/",
+		},
+		{
+			name:           "Test Azure documented as OCP",
+			brand:          v1.BrandAzure,
+			releaseVersion: "4.17.3",
+			want:           "https://access.redhat.com/documentation/en-us/openshift_container_platform/4.17/",
+		},
+		{
+			name:           "Test ROSA documented per major version",
+			brand:          v1.BrandROSA,
+			releaseVersion: "4.17.3",
+			want:           "https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws/4/",
+		},
+		{
+			name:           "Test Dedicated documented per major version",
+			brand:          v1.BrandDedicated,
+			releaseVersion: "4.17.3",
+			want:           "https://access.redhat.com/documentation/en-us/openshift_dedicated/4/",
+		},
+		{
+			name:           "Test unparsable version",
+			brand:          v1.BrandOKD,
+			releaseVersion: "testReleaseVersion",
+			want:           "https://docs.okd.io/latest/",
+		},
+		{
+			name:           "Test missing version",
+			brand:          v1.BrandOCP,
+			releaseVersion: "",
+			want:           "https://access.redhat.com/documentation/en-us/openshift_container_platform/",
+		},
+		{
+			name:           "Test unknown brand documented by the default brand",
+			brand:          v1.Brand("unknown"),
+			releaseVersion: "",
+			want:           DEFAULT_DOC_URL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetDocURL(tt.brand, tt.releaseVersion); got != tt.want {
+				t.Errorf("GetDocURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
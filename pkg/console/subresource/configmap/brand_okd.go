@@ -4,6 +4,7 @@
 package configmap
 
 const (
-	DEFAULT_BRAND   = "okd"
+	DEFAULT_BRAND = "okd"
+	// documentation base URL used when the release version is unknown
 	DEFAULT_DOC_URL = "https://docs.okd.io/latest/"
 )
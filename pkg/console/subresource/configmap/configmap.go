@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -20,7 +21,7 @@ import (
 )
 
 const (
-	consoleConfigYamlFile     = "console-config.yaml"
+	ConsoleConfigYamlFile     = "console-config.yaml"
 	defaultLogoutURL          = ""
 	pluginProxyEndpoint       = "/api/proxy/plugin/"
 	telemetryAnnotationPrefix = "telemetry.console.openshift.io/"
@@ -48,6 +49,7 @@ func DefaultConfigMap(
 	managedConfig *corev1.ConfigMap,
 	monitoringSharedConfig *corev1.ConfigMap,
 	infrastructureConfig *configv1.Infrastructure,
+	apiServerConfig *configv1.APIServer,
 	activeConsoleRoute *routev1.Route,
 	inactivityTimeoutSeconds int,
 	availablePlugins []*v1.ConsolePlugin,
@@ -55,24 +57,35 @@ func DefaultConfigMap(
 	nodeOperatingSystems []string,
 	copiedCSVsDisabled bool,
 	telemeterClientIsAvailable bool,
-) (consoleConfigMap *corev1.ConfigMap, unsupportedOverridesHaveMerged bool, err error) {
+	customBrandingConfigMaps []*corev1.ConfigMap,
+	releaseVersion string,
+) (consoleConfigMap *corev1.ConfigMap, provenance consoleserver.ConfigProvenance, unsupportedOverridesHaveMerged bool, err error) {
+
+	// the default documentation follows the brand and the version of the cluster,
+	// unless spec.customization.documentationBaseURL is set
+	brand := operatorConfig.Spec.Customization.Brand
+	if len(brand) == 0 {
+		brand = DEFAULT_BRAND
+	}
+
+	customLogoFiles, customFaviconFiles := CustomBrandingFilePaths(operatorConfig, customBrandingConfigMaps)
 
 	defaultBuilder := &consoleserver.ConsoleServerCLIConfigBuilder{}
 	defaultConfig, err := defaultBuilder.Host(activeConsoleRoute.Spec.Host).
 		LogoutURL(defaultLogoutURL).
 		Brand(DEFAULT_BRAND).
-		DocURL(DEFAULT_DOC_URL).
+		DocURL(GetDocURL(brand, releaseVersion)).
 		APIServerURL(getApiUrl(infrastructureConfig)).
 		Monitoring(monitoringSharedConfig).
 		InactivityTimeout(inactivityTimeoutSeconds).
-		ReleaseVersion().
+		ReleaseVersion(releaseVersion).
 		NodeArchitectures(nodeArchitectures).
 		NodeOperatingSystems(nodeOperatingSystems).
 		CopiedCSVsDisabled(copiedCSVsDisabled).
 		ConfigYAML()
 	if err != nil {
 		klog.Errorf("failed to generate default console-config config: %v", err)
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	extractedManagedConfig := extractYAML(managedConfig)
@@ -88,24 +101,28 @@ func DefaultConfigMap(
 		I18nNamespaces(pluginsWithI18nNamespace(availablePlugins)).
 		Proxy(getPluginsProxyServices(availablePlugins)).
 		CustomLogoFile(operatorConfig.Spec.Customization.CustomLogoFile.Key).
+		CustomLogoFiles(customLogoFiles).
+		CustomFaviconFiles(customFaviconFiles).
 		CustomProductName(operatorConfig.Spec.Customization.CustomProductName).
 		CustomDeveloperCatalog(operatorConfig.Spec.Customization.DeveloperCatalog).
 		ProjectAccess(operatorConfig.Spec.Customization.ProjectAccess).
 		QuickStarts(operatorConfig.Spec.Customization.QuickStarts).
 		CustomHostnameRedirectPort(isCustomRoute(activeConsoleRoute)).
+		TLSSecurityProfile(getTLSSecurityProfile(operatorConfig, apiServerConfig)).
 		AddPage(operatorConfig.Spec.Customization.AddPage).
 		Perspectives(operatorConfig.Spec.Customization.Perspectives).
 		StatusPageID(statusPageId(operatorConfig)).
 		InactivityTimeout(inactivityTimeoutSeconds).
 		TelemetryConfiguration(GetTelemetryConfiguration(operatorConfig, telemeterClientIsAvailable)).
-		ReleaseVersion().
+		ReleaseVersion(releaseVersion).
 		NodeArchitectures(nodeArchitectures).
 		NodeOperatingSystems(nodeOperatingSystems).
 		AuthConfig(authConfig).
+		PreviousSessionKeys(util.PreviousSessionKeysEnabled(operatorConfig)).
 		ConfigYAML()
 	if err != nil {
 		klog.Errorf("failed to generate user defined console-config config: %v", err)
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	unsupportedConfigOverride := operatorConfig.Spec.UnsupportedConfigOverrides.Raw
@@ -115,22 +132,33 @@ func DefaultConfigMap(
 	}
 
 	merger := &consoleserver.ConsoleYAMLMerger{}
-	mergedConfig, err := merger.Merge(
-		defaultConfig,
-		extractedManagedConfig,
-		userDefinedConfig,
-		unsupportedConfigOverride)
+	mergedConfig, provenance, err := merger.MergeWithProvenance(
+		consoleserver.ConfigLayer{Name: consoleserver.DefaultConfigLayer, YAML: defaultConfig},
+		consoleserver.ConfigLayer{Name: consoleserver.ManagedConfigLayer, YAML: extractedManagedConfig},
+		consoleserver.ConfigLayer{Name: consoleserver.UserDefinedConfigLayer, YAML: userDefinedConfig},
+		consoleserver.ConfigLayer{Name: consoleserver.UnsupportedConfigOverridesLayer, YAML: unsupportedConfigOverride},
+	)
 	if err != nil {
 		klog.Errorf("failed to generate configmap: %v", err)
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	configMap := Stub()
 	configMap.Data = map[string]string{}
-	configMap.Data[consoleConfigYamlFile] = string(mergedConfig)
+	configMap.Data[ConsoleConfigYamlFile] = string(mergedConfig)
 	util.AddOwnerRef(configMap, util.OwnerRefFrom(operatorConfig))
 
-	return configMap, willMergeConfigOverrides, nil
+	return configMap, provenance, willMergeConfigOverrides, nil
+}
+
+// getTLSSecurityProfile returns the TLS security profile of the cluster, or
+// nothing if the APIServer config does not set one or the console is not known
+// to read it.
+func getTLSSecurityProfile(operatorConfig *operatorv1.Console, apiServerConfig *configv1.APIServer) *configv1.TLSSecurityProfile {
+	if apiServerConfig == nil || !util.ServingTLSProfileEnabled(operatorConfig) {
+		return nil
+	}
+	return apiServerConfig.Spec.TLSSecurityProfile
 }
 
 func pluginsWithI18nNamespace(availablePlugins []*v1.ConsolePlugin) []string {
@@ -178,6 +206,19 @@ func getPluginsProxyServices(availablePlugins []*v1.ConsolePlugin) []consoleserv
 	return proxyServices
 }
 
+// CopiedCSVsDisabled returns whether the OLMConfig keeps OLM from copying the
+// ClusterServiceVersions of the operators installed for all namespaces.
+func CopiedCSVsDisabled(olmConfig *unstructured.Unstructured) (bool, error) {
+	if olmConfig == nil {
+		return false, nil
+	}
+	copiedCSVsDisabled, found, err := unstructured.NestedBool(olmConfig.Object, "spec", "features", "disableCopiedCSVs")
+	if err != nil || !found {
+		return false, err
+	}
+	return copiedCSVsDisabled, nil
+}
+
 func GetTelemetryConfiguration(operatorConfig *operatorv1.Console, telemeterClientIsAvailable bool) map[string]string {
 	telemetry := make(map[string]string)
 	if len(operatorConfig.Annotations) > 0 {
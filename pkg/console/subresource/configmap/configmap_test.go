@@ -58,6 +58,7 @@ func TestDefaultConfigMap(t *testing.T) {
 		clusterMonitoringConfig  *corev1.ConfigMap
 		authServerCAConfig       *corev1.ConfigMap
 		infrastructureConfig     *configv1.Infrastructure
+		apiServerConfig          *configv1.APIServer
 		rt                       *routev1.Route
 		inactivityTimeoutSeconds int
 		availablePlugins         []*v1.ConsolePlugin
@@ -65,7 +66,6 @@ func TestDefaultConfigMap(t *testing.T) {
 		nodeOperatingSystems     []string
 		copiedCSVsDisabled       bool
 	}
-	t.Setenv("RELEASE_VERSION", testReleaseVersion)
 	tests := []struct {
 		name string
 		args args
@@ -969,6 +969,162 @@ servingInfo:
   certFile: /var/serving-cert/tls.crt
   keyFile: /var/serving-cert/tls.key
 providers: {}
+`,
+				},
+			},
+		},
+		{
+			name: "Test configmap with the cluster TLS security profile",
+			args: args{
+				authConfig: &configv1.Authentication{},
+				operatorConfig: &operatorv1.Console{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{api.ServingTLSProfileAnnotation: "true"},
+					},
+				},
+				consoleConfig: &configv1.Console{},
+				managedConfig: &corev1.ConfigMap{},
+				infrastructureConfig: &configv1.Infrastructure{
+					Status: configv1.InfrastructureStatus{
+						APIServerURL:         mockAPIServer,
+						ControlPlaneTopology: configv1.HighlyAvailableTopologyMode,
+					},
+				},
+				apiServerConfig: &configv1.APIServer{
+					Spec: configv1.APIServerSpec{
+						TLSSecurityProfile: &configv1.TLSSecurityProfile{
+							Type:         configv1.TLSProfileIntermediateType,
+							Intermediate: &configv1.IntermediateTLSProfile{},
+						},
+					},
+				},
+				rt: &routev1.Route{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: api.OpenShiftConsoleName,
+					},
+					Spec: routev1.RouteSpec{
+						Host: host,
+					},
+				},
+				inactivityTimeoutSeconds: 0,
+			},
+			want: &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ConfigMap",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        api.OpenShiftConsoleConfigMapName,
+					Namespace:   api.OpenShiftConsoleNamespace,
+					Labels:      map[string]string{"app": api.OpenShiftConsoleName},
+					Annotations: map[string]string{},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "operator.openshift.io/v1",
+						Kind:       "Console",
+						Controller: ptr.To(true),
+					}},
+				},
+				Data: map[string]string{configKey: `kind: ConsoleConfig
+apiVersion: console.openshift.io/v1
+auth:
+  authType: openshift
+  clientID: console
+  clientSecretFile: /var/oauth-config/clientSecret
+  oauthEndpointCAFile: /var/oauth-serving-cert/ca-bundle.crt
+clusterInfo:
+  consoleBaseAddress: https://` + host + `
+  masterPublicURL: ` + mockAPIServer + `
+  controlPlaneTopology: HighlyAvailable
+  releaseVersion: ` + testReleaseVersion + `
+session: {}
+customization:
+  branding: ` + DEFAULT_BRAND + `
+  documentationBaseURL: ` + DEFAULT_DOC_URL + `
+servingInfo:
+  bindAddress: https://[::]:8443
+  certFile: /var/serving-cert/tls.crt
+  keyFile: /var/serving-cert/tls.key
+  minTLSVersion: VersionTLS12
+  cipherSuites:
+  - TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256
+  - TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+  - TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384
+  - TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384
+  - TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256
+  - TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256
+providers: {}
+`,
+				},
+			},
+		},
+		{
+			name: "Test configmap without the TLS settings the console is not known to read",
+			args: args{
+				authConfig:     &configv1.Authentication{},
+				operatorConfig: &operatorv1.Console{},
+				consoleConfig:  &configv1.Console{},
+				managedConfig:  &corev1.ConfigMap{},
+				infrastructureConfig: &configv1.Infrastructure{
+					Status: configv1.InfrastructureStatus{
+						APIServerURL:         mockAPIServer,
+						ControlPlaneTopology: configv1.HighlyAvailableTopologyMode,
+					},
+				},
+				apiServerConfig: &configv1.APIServer{
+					Spec: configv1.APIServerSpec{
+						TLSSecurityProfile: &configv1.TLSSecurityProfile{
+							Type:         configv1.TLSProfileIntermediateType,
+							Intermediate: &configv1.IntermediateTLSProfile{},
+						},
+					},
+				},
+				rt: &routev1.Route{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: api.OpenShiftConsoleName,
+					},
+					Spec: routev1.RouteSpec{
+						Host: host,
+					},
+				},
+				inactivityTimeoutSeconds: 0,
+			},
+			want: &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ConfigMap",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        api.OpenShiftConsoleConfigMapName,
+					Namespace:   api.OpenShiftConsoleNamespace,
+					Labels:      map[string]string{"app": api.OpenShiftConsoleName},
+					Annotations: map[string]string{},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "operator.openshift.io/v1",
+						Kind:       "Console",
+						Controller: ptr.To(true),
+					}},
+				},
+				Data: map[string]string{configKey: `kind: ConsoleConfig
+apiVersion: console.openshift.io/v1
+auth:
+  authType: openshift
+  clientID: console
+  clientSecretFile: /var/oauth-config/clientSecret
+  oauthEndpointCAFile: /var/oauth-serving-cert/ca-bundle.crt
+clusterInfo:
+  consoleBaseAddress: https://` + host + `
+  masterPublicURL: ` + mockAPIServer + `
+  controlPlaneTopology: HighlyAvailable
+  releaseVersion: ` + testReleaseVersion + `
+session: {}
+customization:
+  branding: ` + DEFAULT_BRAND + `
+  documentationBaseURL: ` + DEFAULT_DOC_URL + `
+servingInfo:
+  bindAddress: https://[::]:8443
+  certFile: /var/serving-cert/tls.crt
+  keyFile: /var/serving-cert/tls.key
+providers: {}
 `,
 				},
 			},
@@ -1049,7 +1205,7 @@ providers: {}
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cm, _, _ := DefaultConfigMap(
+			cm, _, _, _ := DefaultConfigMap(
 				tt.args.operatorConfig,
 				tt.args.consoleConfig,
 				tt.args.authConfig,
@@ -1057,6 +1213,7 @@ providers: {}
 				tt.args.managedConfig,
 				tt.args.monitoringSharedConfig,
 				tt.args.infrastructureConfig,
+				tt.args.apiServerConfig,
 				tt.args.rt,
 				tt.args.inactivityTimeoutSeconds,
 				tt.args.availablePlugins,
@@ -1064,6 +1221,8 @@ providers: {}
 				tt.args.nodeOperatingSystems,
 				tt.args.copiedCSVsDisabled,
 				true, // TODO add test cases for telemetry client
+				nil,
+				testReleaseVersion,
 			)
 
 			// marshall the exampleYaml to map[string]interface{} so we can use it in diff below
@@ -0,0 +1,130 @@
+package configmap
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+
+	yaml2 "github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/subresource/util"
+)
+
+const (
+	historyYamlFile = "history.yaml"
+	// number of known-good console-configs kept to roll back to
+	ConfigHistoryLimit = 3
+)
+
+// ConfigHistory records the last console-configs the console deployment became
+// available with, newest first, and the console-config whose rollout did not.
+type ConfigHistory struct {
+	Good     []ConfigHistoryEntry `json:"good,omitempty"`
+	Rejected *ConfigHistoryEntry  `json:"rejected,omitempty"`
+}
+
+// ConfigHistoryEntry is a console-config YAML document and its hash. A known-good
+// one also holds the hashes of the inputs of the last rollout of the console
+// deployment it became available with.
+type ConfigHistoryEntry struct {
+	Hash          string            `json:"hash"`
+	Config        string            `json:"config"`
+	RolloutHashes map[string]string `json:"rolloutHashes,omitempty"`
+}
+
+// ConfigHash returns the hash of a console-config YAML document.
+func ConfigHash(config string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(config)))
+}
+
+// GetConfigHistory decodes the history ConfigMap, a missing one has no history yet.
+func GetConfigHistory(configMap *corev1.ConfigMap) (*ConfigHistory, error) {
+	history := &ConfigHistory{}
+	if configMap == nil || len(configMap.Data[historyYamlFile]) == 0 {
+		return history, nil
+	}
+	if err := yaml2.Unmarshal([]byte(configMap.Data[historyYamlFile]), history); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", api.ConsoleConfigHistoryName, err)
+	}
+	return history, nil
+}
+
+// RecordGood moves the console-config to the top of the known-good ones, along
+// with the hashes of the inputs of the rollout it became available with. A new
+// good console-config supersedes the rejected one, which is cleared, while the
+// last good one rolled back to keeps it. It returns whether the history changed.
+func (h *ConfigHistory) RecordGood(config string, rolloutHashes map[string]string) bool {
+	hash := ConfigHash(config)
+	if last := h.LastGood(); last != nil && last.Hash == hash {
+		if reflect.DeepEqual(last.RolloutHashes, rolloutHashes) {
+			return false
+		}
+		last.RolloutHashes = rolloutHashes
+		return true
+	}
+	good := []ConfigHistoryEntry{{Hash: hash, Config: config, RolloutHashes: rolloutHashes}}
+	for _, entry := range h.Good {
+		if entry.Hash != hash && len(good) < ConfigHistoryLimit {
+			good = append(good, entry)
+		}
+	}
+	h.Good = good
+	h.Rejected = nil
+	return true
+}
+
+// Reject marks the console-config as rejected, unless it is known to be good or
+// there is no good one to roll back to. It returns whether the history changed.
+func (h *ConfigHistory) Reject(config string) bool {
+	hash := ConfigHash(config)
+	if h.LastGood() == nil || h.IsRejected(config) {
+		return false
+	}
+	for _, entry := range h.Good {
+		if entry.Hash == hash {
+			return false
+		}
+	}
+	h.Rejected = &ConfigHistoryEntry{Hash: hash, Config: config}
+	return true
+}
+
+// IsRejected returns whether the console-config has been rejected.
+func (h *ConfigHistory) IsRejected(config string) bool {
+	return h.Rejected != nil && h.Rejected.Hash == ConfigHash(config)
+}
+
+// LastGood returns the newest known-good console-config, if any.
+func (h *ConfigHistory) LastGood() *ConfigHistoryEntry {
+	if len(h.Good) == 0 {
+		return nil
+	}
+	return &h.Good[0]
+}
+
+// DefaultConfigHistoryConfigMap creates the ConfigMap holding the console-config history.
+func DefaultConfigHistoryConfigMap(cr *operatorv1.Console, history *ConfigHistory) (*corev1.ConfigMap, error) {
+	historyYAML, err := yaml2.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+	configMap := ConfigHistoryStub()
+	configMap.Data = map[string]string{
+		historyYamlFile: string(historyYAML),
+	}
+	util.AddOwnerRef(configMap, util.OwnerRefFrom(cr))
+	return configMap, nil
+}
+
+func ConfigHistoryStub() *corev1.ConfigMap {
+	meta := util.SharedMeta()
+	meta.Name = api.ConsoleConfigHistoryName
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: meta,
+	}
+	return configMap
+}
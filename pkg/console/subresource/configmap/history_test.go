@@ -0,0 +1,111 @@
+package configmap
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestConfigHistory(t *testing.T) {
+	entry := func(config string) ConfigHistoryEntry {
+		return ConfigHistoryEntry{Hash: ConfigHash(config), Config: config}
+	}
+
+	tests := []struct {
+		name        string
+		history     *ConfigHistory
+		record      func(*ConfigHistory) bool
+		wantChanged bool
+		want        *ConfigHistory
+	}{
+		{
+			name:        "Test first good config",
+			history:     &ConfigHistory{},
+			record:      func(h *ConfigHistory) bool { return h.RecordGood("a", nil) },
+			wantChanged: true,
+			want:        &ConfigHistory{Good: []ConfigHistoryEntry{entry("a")}},
+		},
+		{
+			name:        "Test last good config again",
+			history:     &ConfigHistory{Good: []ConfigHistoryEntry{entry("a")}, Rejected: &ConfigHistoryEntry{Hash: ConfigHash("b"), Config: "b"}},
+			record:      func(h *ConfigHistory) bool { return h.RecordGood("a", nil) },
+			wantChanged: false,
+			want:        &ConfigHistory{Good: []ConfigHistoryEntry{entry("a")}, Rejected: &ConfigHistoryEntry{Hash: ConfigHash("b"), Config: "b"}},
+		},
+		{
+			name:    "Test last good config with another rollout",
+			history: &ConfigHistory{Good: []ConfigHistoryEntry{entry("a")}, Rejected: &ConfigHistoryEntry{Hash: ConfigHash("b"), Config: "b"}},
+			record: func(h *ConfigHistory) bool {
+				return h.RecordGood("a", map[string]string{"console.openshift.io/session-secret-hash": "x"})
+			},
+			wantChanged: true,
+			want: &ConfigHistory{
+				Good:     []ConfigHistoryEntry{{Hash: ConfigHash("a"), Config: "a", RolloutHashes: map[string]string{"console.openshift.io/session-secret-hash": "x"}}},
+				Rejected: &ConfigHistoryEntry{Hash: ConfigHash("b"), Config: "b"},
+			},
+		},
+		{
+			name:        "Test new good config clears the rejected one and trims the history",
+			history:     &ConfigHistory{Good: []ConfigHistoryEntry{entry("c"), entry("b"), entry("a")}, Rejected: &ConfigHistoryEntry{Hash: ConfigHash("x"), Config: "x"}},
+			record:      func(h *ConfigHistory) bool { return h.RecordGood("d", nil) },
+			wantChanged: true,
+			want:        &ConfigHistory{Good: []ConfigHistoryEntry{entry("d"), entry("c"), entry("b")}},
+		},
+		{
+			name:        "Test older good config moves to the top",
+			history:     &ConfigHistory{Good: []ConfigHistoryEntry{entry("b"), entry("a")}},
+			record:      func(h *ConfigHistory) bool { return h.RecordGood("a", nil) },
+			wantChanged: true,
+			want:        &ConfigHistory{Good: []ConfigHistoryEntry{entry("a"), entry("b")}},
+		},
+		{
+			name:        "Test reject a new config",
+			history:     &ConfigHistory{Good: []ConfigHistoryEntry{entry("a")}},
+			record:      func(h *ConfigHistory) bool { return h.Reject("b") },
+			wantChanged: true,
+			want:        &ConfigHistory{Good: []ConfigHistoryEntry{entry("a")}, Rejected: &ConfigHistoryEntry{Hash: ConfigHash("b"), Config: "b"}},
+		},
+		{
+			name:        "Test reject a known good config",
+			history:     &ConfigHistory{Good: []ConfigHistoryEntry{entry("b"), entry("a")}},
+			record:      func(h *ConfigHistory) bool { return h.Reject("a") },
+			wantChanged: false,
+			want:        &ConfigHistory{Good: []ConfigHistoryEntry{entry("b"), entry("a")}},
+		},
+		{
+			name:        "Test reject without a good config to roll back to",
+			history:     &ConfigHistory{},
+			record:      func(h *ConfigHistory) bool { return h.Reject("a") },
+			wantChanged: false,
+			want:        &ConfigHistory{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changed := tt.record(tt.history); changed != tt.wantChanged {
+				t.Errorf("expected changed: %v, got: %v", tt.wantChanged, changed)
+			}
+			if diff := deep.Equal(tt.history, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGetConfigHistory(t *testing.T) {
+	history := &ConfigHistory{}
+	history.RecordGood("clusterInfo:\n  consoleBaseAddress: https://console\n", map[string]string{"console.openshift.io/console-config-hash": "a"})
+	history.Reject("clusterInfo:\n  consoleBaseAddress: https://other\n")
+
+	configMap, err := DefaultConfigHistoryConfigMap(nil, history)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := GetConfigHistory(configMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := deep.Equal(got, history); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,41 @@
+package configmap
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/subresource/consoleserver"
+	"github.com/openshift/console-operator/pkg/console/subresource/util"
+)
+
+const (
+	provenanceYamlFile = "provenance.yaml"
+)
+
+// DefaultProvenanceConfigMap creates a companion config map for console-config which
+// records, for each key of the merged console-config, the layer it was set by:
+// the operator defaults, the managed config, the operator config or the
+// unsupportedConfigOverrides, or the rollback to the last good console-config.
+func DefaultProvenanceConfigMap(cr *operatorv1.Console, provenance consoleserver.ConfigProvenance) (*corev1.ConfigMap, error) {
+	provenanceYAML, err := provenance.YAML()
+	if err != nil {
+		return nil, err
+	}
+	configMap := ProvenanceStub()
+	configMap.Data = map[string]string{
+		provenanceYamlFile: string(provenanceYAML),
+	}
+	util.AddOwnerRef(configMap, util.OwnerRefFrom(cr))
+	return configMap, nil
+}
+
+func ProvenanceStub() *corev1.ConfigMap {
+	meta := util.SharedMeta()
+	meta.Name = api.ConsoleConfigProvenanceName
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: meta,
+	}
+	return configMap
+}
@@ -0,0 +1,65 @@
+package configmap
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/subresource/consoleserver"
+)
+
+func TestDefaultProvenanceConfigMap(t *testing.T) {
+	type args struct {
+		cr         *operatorv1.Console
+		provenance consoleserver.ConfigProvenance
+	}
+	tests := []struct {
+		name string
+		args args
+		want *corev1.ConfigMap
+	}{
+		{
+			name: "Test provenance config map",
+			args: args{
+				cr: &operatorv1.Console{},
+				provenance: consoleserver.ConfigProvenance{
+					"customization.branding":     consoleserver.UserDefinedConfigLayer,
+					"clusterInfo.releaseVersion": consoleserver.DefaultConfigLayer,
+				},
+			},
+			want: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        api.ConsoleConfigProvenanceName,
+					Namespace:   api.OpenShiftConsoleNamespace,
+					Labels:      map[string]string{"app": api.OpenShiftConsoleName},
+					Annotations: map[string]string{},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "operator.openshift.io/v1",
+						Kind:       "Console",
+						Controller: ptr.To(true),
+					}},
+				},
+				Data: map[string]string{
+					provenanceYamlFile: "clusterInfo.releaseVersion: default\ncustomization.branding: user-defined\n",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configMap, err := DefaultProvenanceConfigMap(tt.args.cr, tt.args.provenance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(configMap, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package consoleserver
 
 import (
-	"os"
 	"path"
 	"strings"
 
@@ -9,6 +8,7 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/subresource/util"
+	"github.com/openshift/library-go/pkg/crypto"
 	"gopkg.in/yaml.v2"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -54,9 +54,13 @@ type ConsoleServerCLIConfigBuilder struct {
 	addPage                    operatorv1.AddPage
 	perspectives               []operatorv1.Perspective
 	customLogoFile             string
+	customLogoFiles            map[string]string
+	customFaviconFiles         map[string]string
 	CAFile                     string
 	monitoring                 map[string]string
 	customHostnameRedirectPort int
+	minTLSVersion              string
+	cipherSuites               []string
 	inactivityTimeoutSeconds   int
 	pluginsList                map[string]string
 	i18nNamespaceList          []string
@@ -72,6 +76,7 @@ type ConsoleServerCLIConfigBuilder struct {
 	authType                   string
 	sessionEncryptionFile      string
 	sessionAuthenticationFile  string
+	previousSessionKeys        bool
 }
 
 func (b *ConsoleServerCLIConfigBuilder) Host(host string) *ConsoleServerCLIConfigBuilder {
@@ -128,6 +133,14 @@ func (b *ConsoleServerCLIConfigBuilder) CustomLogoFile(customLogoFile string) *C
 	}
 	return b
 }
+func (b *ConsoleServerCLIConfigBuilder) CustomLogoFiles(customLogoFiles map[string]string) *ConsoleServerCLIConfigBuilder {
+	b.customLogoFiles = customLogoFiles
+	return b
+}
+func (b *ConsoleServerCLIConfigBuilder) CustomFaviconFiles(customFaviconFiles map[string]string) *ConsoleServerCLIConfigBuilder {
+	b.customFaviconFiles = customFaviconFiles
+	return b
+}
 func (b *ConsoleServerCLIConfigBuilder) CustomHostnameRedirectPort(redirect bool) *ConsoleServerCLIConfigBuilder {
 	// If custom hostname is set on the console operator config,
 	// set the port under which the console backend will listen
@@ -137,6 +150,29 @@ func (b *ConsoleServerCLIConfigBuilder) CustomHostnameRedirectPort(redirect bool
 	}
 	return b
 }
+
+// TLSSecurityProfile sets the minimum TLS version and the cipher suites the
+// console serves with to the ones of the cluster TLS security profile. Without
+// a profile the console keeps its defaults.
+func (b *ConsoleServerCLIConfigBuilder) TLSSecurityProfile(profile *configv1.TLSSecurityProfile) *ConsoleServerCLIConfigBuilder {
+	if profile == nil {
+		return b
+	}
+	spec := configv1.TLSProfiles[profile.Type]
+	if profile.Type == configv1.TLSProfileCustomType && profile.Custom != nil {
+		spec = &profile.Custom.TLSProfileSpec
+	}
+	if spec == nil {
+		return b
+	}
+	b.minTLSVersion = string(spec.MinTLSVersion)
+	// the profiles list OpenSSL cipher names, the console expects IANA ones.
+	// TLS 1.3 suites are not configurable and are left out.
+	if ciphers := crypto.OpenSSLToIANACipherSuites(spec.Ciphers); len(ciphers) > 0 {
+		b.cipherSuites = ciphers
+	}
+	return b
+}
 func (b *ConsoleServerCLIConfigBuilder) StatusPageID(id string) *ConsoleServerCLIConfigBuilder {
 	b.statusPageID = id
 	return b
@@ -160,8 +196,9 @@ func (b *ConsoleServerCLIConfigBuilder) AuthConfig(authnConfig *configv1.Authent
 			return b
 		}
 
-		oidcProvider, oidcConfig := util.GetOIDCClientConfig(authnConfig)
-		if oidcConfig == nil {
+		// an ambiguous config is reported by the controllers before the console-config is built
+		oidcProvider, oidcConfig, err := util.GetOIDCClientConfig(authnConfig)
+		if err != nil || oidcConfig == nil {
 			b.authType = "disabled"
 			return b
 		}
@@ -188,6 +225,14 @@ func (b *ConsoleServerCLIConfigBuilder) Monitoring(monitoringConfig *corev1.Conf
 	return b
 }
 
+// PreviousSessionKeys points the console at the previous session keys, which it
+// accepts the sessions of for a grace period after a rotation, once the console
+// is known to read them.
+func (b *ConsoleServerCLIConfigBuilder) PreviousSessionKeys(enabled bool) *ConsoleServerCLIConfigBuilder {
+	b.previousSessionKeys = enabled
+	return b
+}
+
 func (b *ConsoleServerCLIConfigBuilder) InactivityTimeout(timeout int) *ConsoleServerCLIConfigBuilder {
 	b.inactivityTimeoutSeconds = timeout
 	return b
@@ -213,8 +258,8 @@ func (b *ConsoleServerCLIConfigBuilder) TelemetryConfiguration(telemetry map[str
 	return b
 }
 
-func (b *ConsoleServerCLIConfigBuilder) ReleaseVersion() *ConsoleServerCLIConfigBuilder {
-	b.releaseVersion = os.Getenv("RELEASE_VERSION")
+func (b *ConsoleServerCLIConfigBuilder) ReleaseVersion(version string) *ConsoleServerCLIConfigBuilder {
+	b.releaseVersion = version
 	return b
 }
 
@@ -271,6 +316,12 @@ func (b *ConsoleServerCLIConfigBuilder) servingInfo() ServingInfo {
 	if b.customHostnameRedirectPort != 0 {
 		conf.RedirectPort = b.customHostnameRedirectPort
 	}
+	if len(b.minTLSVersion) > 0 {
+		conf.MinTLSVersion = b.minTLSVersion
+	}
+	if len(b.cipherSuites) > 0 {
+		conf.CipherSuites = b.cipherSuites
+	}
 
 	return conf
 }
@@ -356,6 +407,10 @@ func (b *ConsoleServerCLIConfigBuilder) session() Session {
 		CookieAuthenticationKeyFile: b.sessionAuthenticationFile,
 		CookieEncryptionKeyFile:     b.sessionEncryptionFile,
 	}
+	if b.previousSessionKeys && len(b.sessionEncryptionFile) > 0 {
+		conf.PreviousCookieAuthenticationKeyFile = "/var/session-secret/previousSessionAuthenticationKey"
+		conf.PreviousCookieEncryptionKeyFile = "/var/session-secret/previousSessionEncryptionKey"
+	}
 	return conf
 }
 
@@ -376,6 +431,12 @@ func (b *ConsoleServerCLIConfigBuilder) customization() Customization {
 	if len(b.customLogoFile) > 0 {
 		conf.CustomLogoFile = b.customLogoFile
 	}
+	if len(b.customLogoFiles) > 0 {
+		conf.CustomLogoFiles = b.customLogoFiles
+	}
+	if len(b.customFaviconFiles) > 0 {
+		conf.CustomFaviconFiles = b.customFaviconFiles
+	}
 
 	if b.devCatalogCustomization.Categories != nil {
 		if conf.DeveloperCatalog == nil {
@@ -46,6 +46,38 @@ func TestConsoleServerCLIConfigBuilder(t *testing.T) {
 				Customization: Customization{},
 				Providers:     Providers{},
 			},
+		}, {
+			name: "Config builder should handle logos and favicons per theme",
+			input: func() Config {
+				b := &ConsoleServerCLIConfigBuilder{}
+				return b.
+					CustomLogoFile("logo.svg").
+					CustomLogoFiles(map[string]string{"Light": "/var/branding/custom-logo-light/light.svg", "Dark": "/var/branding/custom-logo-dark/dark.svg"}).
+					CustomFaviconFiles(map[string]string{"Light": "/var/branding/custom-favicon/favicon.png", "Dark": "/var/branding/custom-favicon/favicon.png"}).
+					Config()
+			},
+			output: Config{
+				Kind:       "ConsoleConfig",
+				APIVersion: "console.openshift.io/v1",
+				ServingInfo: ServingInfo{
+					BindAddress: "https://[::]:8443",
+					CertFile:    certFilePath,
+					KeyFile:     keyFilePath,
+				},
+				ClusterInfo: ClusterInfo{
+					ConsoleBasePath: "",
+				},
+				Auth: Auth{
+					ClientID:         api.OpenShiftConsoleName,
+					ClientSecretFile: clientSecretFilePath,
+				},
+				Customization: Customization{
+					CustomLogoFile:     "/var/logo/logo.svg",
+					CustomLogoFiles:    map[string]string{"Light": "/var/branding/custom-logo-light/light.svg", "Dark": "/var/branding/custom-logo-dark/dark.svg"},
+					CustomFaviconFiles: map[string]string{"Light": "/var/branding/custom-favicon/favicon.png", "Dark": "/var/branding/custom-favicon/favicon.png"},
+				},
+				Providers: Providers{},
+			},
 		}, {
 			name: "Config builder should handle cluster info with internal OAuth",
 			input: func() Config {
@@ -81,7 +113,7 @@ func TestConsoleServerCLIConfigBuilder(t *testing.T) {
 				Providers:     Providers{},
 			},
 		}, {
-			name: "Config builder should handle cluster info with external OIDC",
+			name: "Config builder should handle cluster info with external OIDC and the previous session keys",
 			input: func() Config {
 				b := &ConsoleServerCLIConfigBuilder{}
 				return b.
@@ -105,6 +137,7 @@ func TestConsoleServerCLIConfigBuilder(t *testing.T) {
 							},
 						},
 					}).
+					PreviousSessionKeys(true).
 					Config()
 			},
 			output: Config{
@@ -128,8 +161,10 @@ func TestConsoleServerCLIConfigBuilder(t *testing.T) {
 					LogoutRedirect:      "https://foobar.com/logout",
 				},
 				Session: Session{
-					CookieEncryptionKeyFile:     "/var/session-secret/sessionEncryptionKey",
-					CookieAuthenticationKeyFile: "/var/session-secret/sessionAuthenticationKey",
+					CookieEncryptionKeyFile:             "/var/session-secret/sessionEncryptionKey",
+					CookieAuthenticationKeyFile:         "/var/session-secret/sessionAuthenticationKey",
+					PreviousCookieEncryptionKeyFile:     "/var/session-secret/previousSessionEncryptionKey",
+					PreviousCookieAuthenticationKeyFile: "/var/session-secret/previousSessionAuthenticationKey",
 				},
 				Customization: Customization{},
 				Providers:     Providers{},
@@ -745,6 +780,95 @@ func TestConsoleServerCLIConfigBuilder(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Config builder should keep the console TLS defaults without a TLS security profile",
+			input: func() Config {
+				b := &ConsoleServerCLIConfigBuilder{}
+				return b.TLSSecurityProfile(nil).Config()
+			},
+			output: Config{
+				Kind:       "ConsoleConfig",
+				APIVersion: "console.openshift.io/v1",
+				ServingInfo: ServingInfo{
+					BindAddress: "https://[::]:8443",
+					CertFile:    certFilePath,
+					KeyFile:     keyFilePath,
+				},
+				ClusterInfo: ClusterInfo{
+					ConsoleBasePath: "",
+				},
+				Auth: Auth{
+					ClientID:         api.OpenShiftConsoleName,
+					ClientSecretFile: clientSecretFilePath,
+				},
+				Customization: Customization{},
+				Providers:     Providers{},
+			},
+		},
+		{
+			name: "Config builder should serve the modern TLS security profile",
+			input: func() Config {
+				b := &ConsoleServerCLIConfigBuilder{}
+				return b.TLSSecurityProfile(&configv1.TLSSecurityProfile{
+					Type:   configv1.TLSProfileModernType,
+					Modern: &configv1.ModernTLSProfile{},
+				}).Config()
+			},
+			output: Config{
+				Kind:       "ConsoleConfig",
+				APIVersion: "console.openshift.io/v1",
+				ServingInfo: ServingInfo{
+					BindAddress:   "https://[::]:8443",
+					CertFile:      certFilePath,
+					KeyFile:       keyFilePath,
+					MinTLSVersion: "VersionTLS13",
+				},
+				ClusterInfo: ClusterInfo{
+					ConsoleBasePath: "",
+				},
+				Auth: Auth{
+					ClientID:         api.OpenShiftConsoleName,
+					ClientSecretFile: clientSecretFilePath,
+				},
+				Customization: Customization{},
+				Providers:     Providers{},
+			},
+		},
+		{
+			name: "Config builder should serve a custom TLS security profile",
+			input: func() Config {
+				b := &ConsoleServerCLIConfigBuilder{}
+				return b.TLSSecurityProfile(&configv1.TLSSecurityProfile{
+					Type: configv1.TLSProfileCustomType,
+					Custom: &configv1.CustomTLSProfile{
+						TLSProfileSpec: configv1.TLSProfileSpec{
+							Ciphers:       []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256", "DHE-RSA-AES128-GCM-SHA256"},
+							MinTLSVersion: configv1.VersionTLS12,
+						},
+					},
+				}).Config()
+			},
+			output: Config{
+				Kind:       "ConsoleConfig",
+				APIVersion: "console.openshift.io/v1",
+				ServingInfo: ServingInfo{
+					BindAddress:   "https://[::]:8443",
+					CertFile:      certFilePath,
+					KeyFile:       keyFilePath,
+					MinTLSVersion: "VersionTLS12",
+					CipherSuites:  []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				},
+				ClusterInfo: ClusterInfo{
+					ConsoleBasePath: "",
+				},
+				Auth: Auth{
+					ClientID:         api.OpenShiftConsoleName,
+					ClientSecretFile: clientSecretFilePath,
+				},
+				Customization: Customization{},
+				Providers:     Providers{},
+			},
+		},
 		{
 			name: "Config builder should pass monitoring info",
 			input: func() Config {
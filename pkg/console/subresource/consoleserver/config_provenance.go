@@ -0,0 +1,192 @@
+package consoleserver
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	yaml2 "github.com/ghodss/yaml"
+)
+
+// Names of the layers the console-config is merged from, in merge order.
+// A key set in a later layer wins over the same key in an earlier one.
+const (
+	DefaultConfigLayer              = "default"
+	ManagedConfigLayer              = "managed"
+	UserDefinedConfigLayer          = "user-defined"
+	UnsupportedConfigOverridesLayer = "unsupportedConfigOverrides"
+)
+
+// RolledBackConfigLayer attributes, in the provenance of a console-config rolled
+// back to the last good one, the values which none of the layers sets anymore.
+const RolledBackConfigLayer = "rolled-back"
+
+// ConfigLayer is a named console-config YAML fragment fed to the merger.
+type ConfigLayer struct {
+	Name string
+	YAML []byte
+}
+
+// ConfigProvenance maps the dotted path of every leaf key in the merged
+// console-config to the name of the layer its value came from.
+type ConfigProvenance map[string]string
+
+// MergeWithProvenance merges the layers the same way Merge does and also
+// records which layer each key of the merged config comes from.
+func (b *ConsoleYAMLMerger) MergeWithProvenance(layers ...ConfigLayer) (converted []byte, provenance ConfigProvenance, err error) {
+	configYAMLs := make([][]byte, 0, len(layers))
+	parsedLayers := make([]map[string]interface{}, 0, len(layers))
+	for _, layer := range layers {
+		configYAMLs = append(configYAMLs, layer.YAML)
+		parsed, err := parseConfig(layer.YAML)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s config layer: %w", layer.Name, err)
+		}
+		parsedLayers = append(parsedLayers, parsed)
+	}
+
+	converted, err = b.Merge(configYAMLs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	merged, err := parseConfig(converted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A leaf of the merged config always comes from the last layer which sets
+	// that exact path: later maps are merged into earlier ones key by key, while
+	// any other value replaces whatever was there before.
+	provenance = ConfigProvenance{}
+	for path, segments := range leafPaths(merged, nil) {
+		for i := len(layers) - 1; i >= 0; i-- {
+			if _, found := lookup(parsedLayers[i], segments); found {
+				provenance[path] = layers[i].Name
+				break
+			}
+		}
+	}
+	return converted, provenance, nil
+}
+
+// YAML returns the provenance as a YAML document sorted by key.
+func (p ConfigProvenance) YAML() ([]byte, error) {
+	return yaml2.Marshal(map[string]string(p))
+}
+
+// RolledBack returns the provenance of the console-config rolled back to in place
+// of the rendered one: the values both share keep the layer they were rendered
+// from, the others come from the rollback.
+func (p ConfigProvenance) RolledBack(rolledBackConfigYAML, renderedConfigYAML []byte) (ConfigProvenance, error) {
+	rolledBackConfig, err := parseConfig(rolledBackConfigYAML)
+	if err != nil {
+		return nil, err
+	}
+	renderedConfig, err := parseConfig(renderedConfigYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	provenance := ConfigProvenance{}
+	for path, segments := range leafPaths(rolledBackConfig, nil) {
+		rolledBackValue, _ := lookup(rolledBackConfig, segments)
+		renderedValue, found := lookup(renderedConfig, segments)
+		if layer, ok := p[path]; ok && found && reflect.DeepEqual(rolledBackValue, renderedValue) {
+			provenance[path] = layer
+			continue
+		}
+		provenance[path] = RolledBackConfigLayer
+	}
+	return provenance, nil
+}
+
+// Diff returns a human readable, sorted list of the keys whose values differ
+// between two console-config YAML documents, each annotated with the layer
+// that set the new value.
+func (p ConfigProvenance) Diff(oldConfigYAML, newConfigYAML []byte) ([]string, error) {
+	oldConfig, err := parseConfig(oldConfigYAML)
+	if err != nil {
+		return nil, err
+	}
+	newConfig, err := parseConfig(newConfigYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	oldLeaves := leafPaths(oldConfig, nil)
+	newLeaves := leafPaths(newConfig, nil)
+	changes := []string{}
+	for path, segments := range newLeaves {
+		newValue, _ := lookup(newConfig, segments)
+		oldValue, found := lookup(oldConfig, segments)
+		switch {
+		case !found:
+			changes = append(changes, fmt.Sprintf("+ %s: %s (%s)", path, formatValue(newValue), p[path]))
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s (%s)", path, formatValue(oldValue), formatValue(newValue), p[path]))
+		}
+	}
+	for path, segments := range oldLeaves {
+		if _, found := newLeaves[path]; found {
+			continue
+		}
+		oldValue, _ := lookup(oldConfig, segments)
+		changes = append(changes, fmt.Sprintf("- %s: %s", path, formatValue(oldValue)))
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i][2:] < changes[j][2:]
+	})
+	return changes, nil
+}
+
+func parseConfig(configYAML []byte) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	if len(configYAML) == 0 {
+		return config, nil
+	}
+	if err := yaml2.Unmarshal(configYAML, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// leafPaths returns the dotted path of every non-map value, and of every empty
+// map, of the given config, along with the path segments it is made of.
+func leafPaths(config map[string]interface{}, prefix []string) map[string][]string {
+	paths := map[string][]string{}
+	for key, value := range config {
+		segments := append(append([]string{}, prefix...), key)
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			for path, nestedSegments := range leafPaths(nested, segments) {
+				paths[path] = nestedSegments
+			}
+			continue
+		}
+		paths[strings.Join(segments, ".")] = segments
+	}
+	return paths
+}
+
+func lookup(config map[string]interface{}, segments []string) (interface{}, bool) {
+	var current interface{} = config
+	for _, segment := range segments {
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = currentMap[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func formatValue(value interface{}) string {
+	formatted, err := yaml2.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.Replace(strings.TrimSpace(string(formatted)), "\n", " ", -1)
+}
@@ -0,0 +1,154 @@
+package consoleserver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeWithProvenance(t *testing.T) {
+	tests := []struct {
+		name   string
+		layers []ConfigLayer
+		want   ConfigProvenance
+	}{
+		{
+			name: "Keys are attributed to the last layer that sets them",
+			layers: []ConfigLayer{
+				{Name: DefaultConfigLayer, YAML: []byte(`
+customization:
+  branding: okd
+  documentationBaseURL: https://docs.okd.io/latest/
+session: {}
+`)},
+				{Name: ManagedConfigLayer, YAML: []byte(`
+customization:
+  documentationBaseURL: https://managed.example.com/docs
+`)},
+				{Name: UserDefinedConfigLayer, YAML: []byte(`
+customization:
+  branding: ocp
+`)},
+				{Name: UnsupportedConfigOverridesLayer, YAML: []byte(`{"session":{"cookieEncryptionKeyFile":"/var/key"}}`)},
+			},
+			want: ConfigProvenance{
+				"customization.branding":             UserDefinedConfigLayer,
+				"customization.documentationBaseURL": ManagedConfigLayer,
+				"session.cookieEncryptionKeyFile":    UnsupportedConfigOverridesLayer,
+			},
+		},
+		{
+			name: "A scalar replaces a whole section set by an earlier layer",
+			layers: []ConfigLayer{
+				{Name: DefaultConfigLayer, YAML: []byte(`
+plugins:
+  foo: https://foo.example.com
+`)},
+				{Name: ManagedConfigLayer, YAML: []byte{}},
+				{Name: UnsupportedConfigOverridesLayer, YAML: []byte(`
+plugins: []
+`)},
+			},
+			want: ConfigProvenance{
+				"plugins": UnsupportedConfigOverridesLayer,
+			},
+		},
+		{
+			name: "Empty sections are attributed to the layer that created them",
+			layers: []ConfigLayer{
+				{Name: DefaultConfigLayer, YAML: []byte(`
+providers: {}
+`)},
+				{Name: UserDefinedConfigLayer, YAML: []byte(`
+providers: {}
+`)},
+			},
+			want: ConfigProvenance{
+				"providers": UserDefinedConfigLayer,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merger := ConsoleYAMLMerger{}
+			_, provenance, err := merger.MergeWithProvenance(tt.layers...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, provenance); len(diff) > 0 {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestConfigProvenanceDiff(t *testing.T) {
+	provenance := ConfigProvenance{
+		"customization.branding":     UserDefinedConfigLayer,
+		"clusterInfo.releaseVersion": DefaultConfigLayer,
+	}
+	oldConfig := []byte(`
+customization:
+  branding: okd
+  customProductName: foo
+clusterInfo: {}
+`)
+	newConfig := []byte(`
+customization:
+  branding: ocp
+clusterInfo:
+  releaseVersion: 4.16.0
+`)
+	want := []string{
+		"+ clusterInfo.releaseVersion: 4.16.0 (default)",
+		"- clusterInfo: {}",
+		"~ customization.branding: okd -> ocp (user-defined)",
+		"- customization.customProductName: foo",
+	}
+
+	changes, err := provenance.Diff(oldConfig, newConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, changes); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
+
+func TestConfigProvenanceRolledBack(t *testing.T) {
+	provenance := ConfigProvenance{
+		"customization.branding":             UserDefinedConfigLayer,
+		"customization.documentationBaseURL": ManagedConfigLayer,
+		"clusterInfo.releaseVersion":         DefaultConfigLayer,
+	}
+	renderedConfig := []byte(`
+customization:
+  branding: ocp
+  documentationBaseURL: https://managed.example.com/docs
+clusterInfo:
+  releaseVersion: 4.16.0
+`)
+	rolledBackConfig := []byte(`
+customization:
+  branding: okd
+  customProductName: foo
+  documentationBaseURL: https://managed.example.com/docs
+clusterInfo:
+  releaseVersion: 4.16.0
+`)
+	want := ConfigProvenance{
+		"customization.branding":             RolledBackConfigLayer,
+		"customization.customProductName":    RolledBackConfigLayer,
+		"customization.documentationBaseURL": ManagedConfigLayer,
+		"clusterInfo.releaseVersion":         DefaultConfigLayer,
+	}
+
+	got, err := provenance.RolledBack(rolledBackConfig, renderedConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); len(diff) > 0 {
+		t.Error(diff)
+	}
+}
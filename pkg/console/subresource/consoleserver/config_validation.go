@@ -0,0 +1,156 @@
+package consoleserver
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	yaml2 "github.com/ghodss/yaml"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigValidationError describes a key of the console-config which the
+// console would not be able to read.
+type ConfigValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ConfigValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidateConfig strictly decodes the console-config YAML into Config, failing
+// on unknown fields and on values of the wrong type. The returned
+// *ConfigValidationError names the first offending key.
+func ValidateConfig(configYAML []byte) error {
+	config := map[string]interface{}{}
+	if err := yaml2.Unmarshal(configYAML, &config); err != nil {
+		return &ConfigValidationError{Path: ".", Message: err.Error()}
+	}
+	if err := validateValue("", config, reflect.TypeOf(Config{})); err != nil {
+		return err
+	}
+	// the walk above mirrors the decoding rules of the console, this is a safety net
+	if err := yaml.UnmarshalStrict(configYAML, &Config{}); err != nil {
+		return &ConfigValidationError{Path: ".", Message: err.Error()}
+	}
+	return nil
+}
+
+func validateValue(path string, value interface{}, t reflect.Type) error {
+	// null decodes into the zero value of any type
+	if value == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return nil
+	case reflect.String:
+		// like the console's decoder, accept any scalar for a string
+		switch value.(type) {
+		case string, bool, float64:
+		default:
+			return typeError(path, "string", value)
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return typeError(path, "boolean", value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return typeError(path, "integer", value)
+		}
+	case reflect.Slice:
+		items, ok := value.([]interface{})
+		if !ok {
+			return typeError(path, "list", value)
+		}
+		for i, item := range items {
+			if err := validateValue(fmt.Sprintf("%s[%d]", path, i), item, t.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			return typeError(path, "map", value)
+		}
+		for _, key := range sortedKeys(entries) {
+			if err := validateValue(joinPath(path, key), entries[key], t.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			return typeError(path, "map", value)
+		}
+		fields := yamlFields(t)
+		for _, key := range sortedKeys(entries) {
+			fieldType, known := fields[key]
+			if !known {
+				return &ConfigValidationError{Path: joinPath(path, key), Message: "unknown field"}
+			}
+			if err := validateValue(joinPath(path, key), entries[key], fieldType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// yamlFields returns the keys the yaml decoder maps onto the fields of the
+// given struct type, following inline structs.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if strings.Contains(options, "inline") {
+			for key, fieldType := range yamlFields(field.Type) {
+				fields[key] = fieldType
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func typeError(path, expected string, value interface{}) error {
+	return &ConfigValidationError{Path: path, Message: fmt.Sprintf("expected %s, got %v", expected, formatValue(value))}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(entries map[string]interface{}) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
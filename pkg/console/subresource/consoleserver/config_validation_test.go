@@ -0,0 +1,110 @@
+package consoleserver
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+)
+
+func TestValidateConfig(t *testing.T) {
+	builder := &ConsoleServerCLIConfigBuilder{}
+	builtConfig, _ := builder.
+		Host("console-openshift-console.apps.example.com").
+		Brand(operatorv1.BrandOKD).
+		InactivityTimeout(300).
+		Perspectives([]operatorv1.Perspective{{
+			ID: "dev",
+			Visibility: operatorv1.PerspectiveVisibility{
+				State: operatorv1.PerspectiveAccessReview,
+				AccessReview: &operatorv1.ResourceAttributesAccessReview{
+					Required: []authorizationv1.ResourceAttributes{{Resource: "namespaces", Verb: "list"}},
+				},
+			},
+		}}).
+		ConfigYAML()
+
+	tests := []struct {
+		name   string
+		config string
+		want   error
+	}{
+		{
+			name:   "Test config generated by the builder",
+			config: string(builtConfig),
+		},
+		{
+			name: "Test scalars are accepted for strings",
+			config: `
+clusterInfo:
+  releaseVersion: 4.16
+customization:
+  customProductName: true
+`,
+		},
+		{
+			name: "Test unknown top level field",
+			config: `
+kind: ConsoleConfig
+customizations:
+  branding: ocp
+`,
+			want: &ConfigValidationError{Path: "customizations", Message: "unknown field"},
+		},
+		{
+			name: "Test unknown nested field",
+			config: `
+customization:
+  brand: ocp
+`,
+			want: &ConfigValidationError{Path: "customization.brand", Message: "unknown field"},
+		},
+		{
+			name: "Test unknown field of inlined struct in a list",
+			config: `
+customization:
+  developerCatalog:
+    categories:
+    - id: foo
+      label: Foo
+      tag: bar
+`,
+			want: &ConfigValidationError{Path: "customization.developerCatalog.categories[0].tag", Message: "unknown field"},
+		},
+		{
+			name: "Test wrong integer type",
+			config: `
+auth:
+  inactivityTimeoutSeconds: "300"
+`,
+			want: &ConfigValidationError{Path: "auth.inactivityTimeoutSeconds", Message: `expected integer, got "300"`},
+		},
+		{
+			name: "Test wrong list type",
+			config: `
+clusterInfo:
+  nodeArchitectures: amd64
+`,
+			want: &ConfigValidationError{Path: "clusterInfo.nodeArchitectures", Message: "expected list, got amd64"},
+		},
+		{
+			name: "Test wrong map value type",
+			config: `
+plugins:
+  foo:
+    endpoint: https://foo.example.com
+`,
+			want: &ConfigValidationError{Path: "plugins.foo", Message: "expected string, got endpoint: https://foo.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(ValidateConfig([]byte(tt.config)), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -49,14 +49,17 @@ type ServingInfo struct {
 	CertFile     string `yaml:"certFile,omitempty"`
 	KeyFile      string `yaml:"keyFile,omitempty"`
 	RedirectPort int    `yaml:"redirectPort,omitempty"`
+	// MinTLSVersion and CipherSuites follow the TLS security profile of the cluster,
+	// they are only set for the console images which support them, see
+	// api.ServingTLSProfileAnnotation.
+	MinTLSVersion string   `yaml:"minTLSVersion,omitempty"`
+	CipherSuites  []string `yaml:"cipherSuites,omitempty"`
 
-	// These fields are defined in `HTTPServingInfo`, but are not supported for console. Fail if any are specified.
+	// The other fields defined in `HTTPServingInfo` are not supported for console. Fail if any are specified.
 	// https://github.com/openshift/api/blob/0cb4131a7636e1ada6b2769edc9118f0fe6844c8/config/v1/types.go#L7-L38
 	BindNetwork           string        `yaml:"bindNetwork,omitempty"`
 	ClientCA              string        `yaml:"clientCA,omitempty"`
 	NamedCertificates     []interface{} `yaml:"namedCertificates,omitempty"`
-	MinTLSVersion         string        `yaml:"minTLSVersion,omitempty"`
-	CipherSuites          []string      `yaml:"cipherSuites,omitempty"`
 	MaxRequestsInFlight   int64         `yaml:"maxRequestsInFlight,omitempty"`
 	RequestTimeoutSeconds int64         `yaml:"requestTimeoutSeconds,omitempty"`
 }
@@ -95,6 +98,10 @@ type Auth struct {
 type Session struct {
 	CookieEncryptionKeyFile     string `yaml:"cookieEncryptionKeyFile,omitempty"`
 	CookieAuthenticationKeyFile string `yaml:"cookieAuthenticationKeyFile,omitempty"`
+	// the previous keys keep the sessions secured before the last rotation of
+	// the keys valid for a grace period
+	PreviousCookieEncryptionKeyFile     string `yaml:"previousCookieEncryptionKeyFile,omitempty"`
+	PreviousCookieAuthenticationKeyFile string `yaml:"previousCookieAuthenticationKeyFile,omitempty"`
 	// TODO: move InactivityTimeoutSeconds here
 }
 
@@ -104,6 +111,9 @@ type Customization struct {
 	DocumentationBaseURL string `yaml:"documentationBaseURL,omitempty"`
 	CustomProductName    string `yaml:"customProductName,omitempty"`
 	CustomLogoFile       string `yaml:"customLogoFile,omitempty"`
+	// customLogoFiles and customFaviconFiles are the paths of the images per theme, Light or Dark.
+	CustomLogoFiles    map[string]string `yaml:"customLogoFiles,omitempty"`
+	CustomFaviconFiles map[string]string `yaml:"customFaviconFiles,omitempty"`
 	// developerCatalog allows to configure the shown developer catalog categories.
 	DeveloperCatalog *DeveloperConsoleCatalogCustomization `yaml:"developerCatalog,omitempty"`
 	ProjectAccess    ProjectAccess                         `yaml:"projectAccess,omitempty"`
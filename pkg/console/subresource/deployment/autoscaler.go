@@ -0,0 +1,58 @@
+package deployment
+
+import (
+	// kube
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	// openshift
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	// operator
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/subresource/util"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
+)
+
+// DefaultHorizontalPodAutoscaler returns the autoscaler of the console deployment.
+func DefaultHorizontalPodAutoscaler(operatorConfig *operatorv1.Console, autoscaling *workload.Autoscaling) *autoscalingv2.HorizontalPodAutoscaler {
+	minReplicas := autoscaling.MinReplicas
+	autoscaler := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      api.OpenShiftConsoleDeploymentName,
+			Namespace: api.OpenShiftConsoleNamespace,
+			Labels:    util.LabelsForConsole(),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       api.OpenShiftConsoleDeploymentName,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+		},
+	}
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		autoscaler.Spec.Metrics = append(autoscaler.Spec.Metrics, resourceMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		autoscaler.Spec.Metrics = append(autoscaler.Spec.Metrics, resourceMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+	util.AddOwnerRef(autoscaler, util.OwnerRefFrom(operatorConfig))
+	return autoscaler
+}
+
+func resourceMetric(name corev1.ResourceName, averageUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &averageUtilization,
+			},
+		},
+	}
+}
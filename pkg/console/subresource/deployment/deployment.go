@@ -1,7 +1,8 @@
 package deployment
 
 import (
-	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 
 	// kube
@@ -9,7 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	appsclientv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	"k8s.io/klog/v2"
 
 	// openshift
@@ -17,7 +18,9 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/console-operator/bindata"
 	"github.com/openshift/console-operator/pkg/api"
+	configmapsub "github.com/openshift/console-operator/pkg/console/subresource/configmap"
 	"github.com/openshift/console-operator/pkg/console/subresource/util"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
 	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
 )
 
@@ -25,30 +28,38 @@ const (
 	ConsoleOauthConfigName    = "console-oauth-config"
 	DefaultConsoleReplicas    = 2
 	SingleNodeConsoleReplicas = 1
+	// share of the autoscaled console pods which may be rolled or disrupted at once
+	AutoscaledRollingUpdatePercentage = "25%"
 )
 
+// hashes of the content the console consumes from each of its inputs, a change
+// of any of them rolls the console pods out.
 const (
-	configMapResourceVersionAnnotation             = "console.openshift.io/console-config-version"
-	proxyConfigResourceVersionAnnotation           = "console.openshift.io/proxy-config-version"
-	infrastructureConfigResourceVersionAnnotation  = "console.openshift.io/infrastructure-config-version"
-	serviceCAConfigMapResourceVersionAnnotation    = "console.openshift.io/service-ca-config-version"
-	trustedCAConfigMapResourceVersionAnnotation    = "console.openshift.io/trusted-ca-config-version"
-	secretResourceVersionAnnotation                = "console.openshift.io/oauth-secret-version"
-	consoleImageAnnotation                         = "console.openshift.io/image"
-	authnConfigVersionAnnotation                   = "console.openshift.io/authentication-config-version"
-	authnCATrustConfigMapResourceVersionAnnotation = "console.openshift.io/authn-ca-trust-config-version"
-	sessionSecretRVAnnotation                      = "console.openshift.io/session-secret-version"
+	configMapHashAnnotation             = "console.openshift.io/console-config-hash"
+	proxyConfigHashAnnotation           = "console.openshift.io/proxy-config-hash"
+	infrastructureConfigHashAnnotation  = "console.openshift.io/infrastructure-config-hash"
+	serviceCAConfigMapHashAnnotation    = "console.openshift.io/service-ca-config-hash"
+	trustedCAConfigMapHashAnnotation    = "console.openshift.io/trusted-ca-config-hash"
+	secretHashAnnotation                = "console.openshift.io/oauth-secret-hash"
+	consoleImageAnnotation              = "console.openshift.io/image"
+	authnCATrustConfigMapHashAnnotation = "console.openshift.io/authn-ca-trust-config-hash"
+	sessionSecretHashAnnotation         = "console.openshift.io/session-secret-hash"
+	customLogoConfigMapHashAnnotation   = "console.openshift.io/custom-logo-config-hash"
+	customBrandingHashAnnotation        = "console.openshift.io/custom-branding-config-hash"
 )
 
 var (
 	resourceAnnotations = []string{
-		configMapResourceVersionAnnotation,
-		proxyConfigResourceVersionAnnotation,
-		infrastructureConfigResourceVersionAnnotation,
-		serviceCAConfigMapResourceVersionAnnotation,
-		authnCATrustConfigMapResourceVersionAnnotation,
-		trustedCAConfigMapResourceVersionAnnotation,
-		secretResourceVersionAnnotation,
+		configMapHashAnnotation,
+		proxyConfigHashAnnotation,
+		infrastructureConfigHashAnnotation,
+		serviceCAConfigMapHashAnnotation,
+		authnCATrustConfigMapHashAnnotation,
+		trustedCAConfigMapHashAnnotation,
+		secretHashAnnotation,
+		sessionSecretHashAnnotation,
+		customLogoConfigMapHashAnnotation,
+		customBrandingHashAnnotation,
 		consoleImageAnnotation,
 	}
 )
@@ -72,9 +83,13 @@ func DefaultDeployment(
 	trustedCAConfigMap *corev1.ConfigMap,
 	oAuthClientSecret *corev1.Secret,
 	sessionSecret *corev1.Secret,
+	customLogoConfigMap *corev1.ConfigMap,
+	customBrandingConfigMaps []*corev1.ConfigMap,
 	proxyConfig *configv1.Proxy,
 	infrastructureConfig *configv1.Infrastructure,
 	canMountCustomLogo bool,
+	podOverrides *workload.PodOverrides,
+	autoscaling *workload.Autoscaling,
 ) *appsv1.Deployment {
 	authnCATrustConfigMap := localOAuthServingCertConfigMap
 	if authnCATrustConfigMap == nil {
@@ -82,9 +97,9 @@ func DefaultDeployment(
 	}
 
 	deployment := resourceread.ReadDeploymentV1OrDie(bindata.MustAsset("assets/deployments/console-deployment.yaml"))
-	withReplicas(deployment, infrastructureConfig)
-	withAffinity(deployment, infrastructureConfig, "ui")
-	withStrategy(deployment, infrastructureConfig)
+	withReplicas(deployment, infrastructureConfig, autoscaling)
+	withTopologySpread(deployment, infrastructureConfig, "ui", autoscaling)
+	withStrategy(deployment, infrastructureConfig, autoscaling)
 	withConsoleAnnotations(
 		deployment,
 		consoleConfigMap,
@@ -93,6 +108,8 @@ func DefaultDeployment(
 		trustedCAConfigMap,
 		oAuthClientSecret,
 		sessionSecret,
+		customLogoConfigMap,
+		customBrandingConfigMaps,
 		proxyConfig,
 		infrastructureConfig,
 	)
@@ -103,9 +120,11 @@ func DefaultDeployment(
 		trustedCAConfigMap,
 		sessionSecret,
 		canMountCustomLogo,
+		customBrandingConfigMaps,
 	)
 	withConsoleContainerImage(deployment, operatorConfig, proxyConfig)
 	withConsoleNodeSelector(deployment, infrastructureConfig)
+	withPodOverrides(deployment, podOverrides)
 	util.AddOwnerRef(deployment, util.OwnerRefFrom(operatorConfig))
 	return deployment
 }
@@ -113,14 +132,16 @@ func DefaultDeployment(
 func DefaultDownloadsDeployment(
 	operatorConfig *operatorv1.Console,
 	infrastructureConfig *configv1.Infrastructure,
+	podOverrides *workload.PodOverrides,
 ) *appsv1.Deployment {
 	downloadsDeployment := resourceread.ReadDeploymentV1OrDie(
 		bindata.MustAsset("assets/deployments/downloads-deployment.yaml"),
 	)
-	withReplicas(downloadsDeployment, infrastructureConfig)
-	withAffinity(downloadsDeployment, infrastructureConfig, "downloads")
-	withStrategy(downloadsDeployment, infrastructureConfig)
+	withReplicas(downloadsDeployment, infrastructureConfig, nil)
+	withTopologySpread(downloadsDeployment, infrastructureConfig, "downloads", nil)
+	withStrategy(downloadsDeployment, infrastructureConfig, nil)
 	withDownloadsContainerImage(downloadsDeployment)
+	withPodOverrides(downloadsDeployment, podOverrides)
 	util.AddOwnerRef(downloadsDeployment, util.OwnerRefFrom(operatorConfig))
 	return downloadsDeployment
 }
@@ -134,44 +155,70 @@ func ShouldDeployHA(infrastructureConfig *configv1.Infrastructure) bool {
 			infrastructureConfig.Status.InfrastructureTopology == configv1.HighlyAvailableTopologyMode)
 }
 
-func withReplicas(deployment *appsv1.Deployment, infrastructureConfig *configv1.Infrastructure) {
+// withReplicas sets the replicas from the cluster topology, or the minimum of the
+// autoscaler which then owns them, see WithAutoscaledReplicas.
+func withReplicas(deployment *appsv1.Deployment, infrastructureConfig *configv1.Infrastructure, autoscaling *workload.Autoscaling) {
 	replicas := int32(SingleNodeConsoleReplicas)
-	if ShouldDeployHA(infrastructureConfig) {
+	switch {
+	case autoscaling != nil:
+		replicas = autoscaling.MinReplicas
+	case ShouldDeployHA(infrastructureConfig):
 		replicas = int32(DefaultConsoleReplicas)
 	}
 	deployment.Spec.Replicas = &replicas
 }
 
-func withAffinity(
+// WithAutoscaledReplicas keeps the replicas the autoscaler set on the existing
+// deployment, as long as they are within its bounds, so that applying the
+// required deployment does not scale it back to the minimum.
+func WithAutoscaledReplicas(required, existing *appsv1.Deployment, autoscaling *workload.Autoscaling) {
+	if autoscaling == nil || existing == nil || existing.Spec.Replicas == nil {
+		return
+	}
+	replicas := *existing.Spec.Replicas
+	if replicas < autoscaling.MinReplicas || replicas > autoscaling.MaxReplicas {
+		return
+	}
+	required.Spec.Replicas = &replicas
+}
+
+// withTopologySpread spreads the pods of an HA or autoscaled deployment across
+// zones and nodes. The constraints are best effort, so that pods still get
+// scheduled during a zone outage or while nodes are drained.
+func withTopologySpread(
 	deployment *appsv1.Deployment,
 	infrastructureConfig *configv1.Infrastructure,
 	component string,
+	autoscaling *workload.Autoscaling,
 ) {
-	affinity := &corev1.Affinity{}
-	if ShouldDeployHA(infrastructureConfig) {
-		affinity = &corev1.Affinity{
-			PodAntiAffinity: &corev1.PodAntiAffinity{
-				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
-					LabelSelector: &metav1.LabelSelector{
-						MatchExpressions: []metav1.LabelSelectorRequirement{
-							{
-								Key:      "component",
-								Operator: metav1.LabelSelectorOpIn,
-								Values:   []string{component},
-							},
-						},
-					},
-					TopologyKey: "kubernetes.io/hostname",
-				}},
-			},
+	var constraints []corev1.TopologySpreadConstraint
+	if autoscaling != nil || ShouldDeployHA(infrastructureConfig) {
+		for _, topologyKey := range []string{corev1.LabelTopologyZone, corev1.LabelHostname} {
+			constraints = append(constraints, corev1.TopologySpreadConstraint{
+				MaxSkew:           1,
+				TopologyKey:       topologyKey,
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"component": component},
+				},
+			})
 		}
 	}
-	deployment.Spec.Template.Spec.Affinity = affinity
+	deployment.Spec.Template.Spec.TopologySpreadConstraints = constraints
 }
 
-func withStrategy(deployment *appsv1.Deployment, infrastructureConfig *configv1.Infrastructure) {
+func withStrategy(deployment *appsv1.Deployment, infrastructureConfig *configv1.Infrastructure, autoscaling *workload.Autoscaling) {
 	rollingUpdateParams := &appsv1.RollingUpdateDeployment{}
-	if ShouldDeployHA(infrastructureConfig) {
+	switch {
+	// the replicas vary, so does the number of pods rolled at once
+	case autoscaling != nil:
+		maxSurge := intstr.FromString(AutoscaledRollingUpdatePercentage)
+		maxUnavailable := intstr.FromString(AutoscaledRollingUpdatePercentage)
+		rollingUpdateParams = &appsv1.RollingUpdateDeployment{
+			MaxSurge:       &maxSurge,
+			MaxUnavailable: &maxUnavailable,
+		}
+	case ShouldDeployHA(infrastructureConfig):
 		rollingUpdateParams = &appsv1.RollingUpdateDeployment{
 			MaxSurge: &intstr.IntOrString{
 				IntVal: int32(3),
@@ -185,8 +232,9 @@ func withStrategy(deployment *appsv1.Deployment, infrastructureConfig *configv1.
 }
 
 // withConsoleAnnotations adds annotations in the console deployment which are used to track
-// resources that when updated, trigger a new deployment rollout; this happens when the resource
-// version changes.
+// resources that when updated, trigger a new deployment rollout; this happens when the content
+// the console consumes from them changes, so that no-op updates, e.g. of their status or labels,
+// leave the console pods alone.
 func withConsoleAnnotations(
 	deployment *appsv1.Deployment,
 	consoleConfigMap *corev1.ConfigMap,
@@ -195,25 +243,39 @@ func withConsoleAnnotations(
 	trustedCAConfigMap *corev1.ConfigMap,
 	oAuthClientSecret *corev1.Secret,
 	sessionSecret *corev1.Secret,
+	customLogoConfigMap *corev1.ConfigMap,
+	customBrandingConfigMaps []*corev1.ConfigMap,
 	proxyConfig *configv1.Proxy,
 	infrastructureConfig *configv1.Infrastructure,
 ) {
 	deployment.ObjectMeta.Annotations = map[string]string{
-		configMapResourceVersionAnnotation:            consoleConfigMap.GetResourceVersion(),
-		serviceCAConfigMapResourceVersionAnnotation:   serviceCAConfigMap.GetResourceVersion(),
-		trustedCAConfigMapResourceVersionAnnotation:   trustedCAConfigMap.GetResourceVersion(),
-		proxyConfigResourceVersionAnnotation:          proxyConfig.GetResourceVersion(),
-		infrastructureConfigResourceVersionAnnotation: infrastructureConfig.GetResourceVersion(),
-		secretResourceVersionAnnotation:               oAuthClientSecret.GetResourceVersion(),
-		consoleImageAnnotation:                        util.GetImageEnv("CONSOLE_IMAGE"),
+		configMapHashAnnotation:            configMapHash(consoleConfigMap),
+		serviceCAConfigMapHashAnnotation:   configMapHash(serviceCAConfigMap),
+		trustedCAConfigMapHashAnnotation:   configMapHash(trustedCAConfigMap, api.TrustedCABundleKey),
+		proxyConfigHashAnnotation:          proxyConfigHash(proxyConfig),
+		infrastructureConfigHashAnnotation: infrastructureConfigHash(infrastructureConfig),
+		secretHashAnnotation:               secretHash(oAuthClientSecret),
+		consoleImageAnnotation:             util.GetImageEnv("CONSOLE_IMAGE"),
 	}
 
 	if authServerCAConfigMap != nil {
-		deployment.ObjectMeta.Annotations[authnCATrustConfigMapResourceVersionAnnotation] = authServerCAConfigMap.GetResourceVersion()
+		deployment.ObjectMeta.Annotations[authnCATrustConfigMapHashAnnotation] = configMapHash(authServerCAConfigMap)
 	}
 
 	if sessionSecret != nil {
-		deployment.ObjectMeta.Annotations[sessionSecretRVAnnotation] = sessionSecret.GetResourceVersion()
+		deployment.ObjectMeta.Annotations[sessionSecretHashAnnotation] = secretHash(sessionSecret)
+	}
+
+	if customLogoConfigMap != nil {
+		deployment.ObjectMeta.Annotations[customLogoConfigMapHashAnnotation] = configMapHash(customLogoConfigMap)
+	}
+
+	if len(customBrandingConfigMaps) > 0 {
+		customBrandingHashes := map[string]string{}
+		for _, configMap := range customBrandingConfigMaps {
+			customBrandingHashes[configMap.Name] = configMapHash(configMap)
+		}
+		deployment.ObjectMeta.Annotations[customBrandingHashAnnotation] = contentHash(customBrandingHashes)
 	}
 
 	podAnnotations := deployment.Spec.Template.ObjectMeta.Annotations
@@ -229,7 +291,8 @@ func withConsoleVolumes(
 	authServerCAConfigMap *corev1.ConfigMap,
 	trustedCAConfigMap *corev1.ConfigMap,
 	sessionSecret *corev1.Secret,
-	canMountCustomLogo bool) {
+	canMountCustomLogo bool,
+	customBrandingConfigMaps []*corev1.ConfigMap) {
 	volumeConfig := defaultVolumeConfig()
 
 	caBundle, caBundleExists := trustedCAConfigMap.Data["ca-bundle.crt"]
@@ -239,6 +302,7 @@ func withConsoleVolumes(
 	if canMountCustomLogo {
 		volumeConfig = append(volumeConfig, customLogoVolume())
 	}
+	volumeConfig = append(volumeConfig, customBrandingVolumes(customBrandingConfigMaps)...)
 
 	if oauthServingCert != nil {
 		volumeConfig = append(volumeConfig, oauthServingCertVolumeConfig())
@@ -338,6 +402,66 @@ func withConsoleNodeSelector(
 	deployment.Spec.Template.Spec.NodeSelector = nodeSelector
 }
 
+// withPodOverrides applies the placement and the resources set by the cluster admin
+// in the console-workload-config ConfigMap, see workload.Config.
+func withPodOverrides(deployment *appsv1.Deployment, podOverrides *workload.PodOverrides) {
+	if podOverrides == nil {
+		return
+	}
+	withNodeSelectorOverride(deployment, podOverrides.NodeSelector)
+	withTolerationsOverride(deployment, podOverrides.Tolerations)
+	withResourcesOverride(deployment, podOverrides.Resources)
+	withPodMetadataOverride(deployment, podOverrides.PodLabels, podOverrides.PodAnnotations)
+}
+
+// withNodeSelectorOverride replaces the node selector, e.g. the master one of the console.
+func withNodeSelectorOverride(deployment *appsv1.Deployment, nodeSelector map[string]string) {
+	if nodeSelector == nil {
+		return
+	}
+	deployment.Spec.Template.Spec.NodeSelector = nodeSelector
+}
+
+// withTolerationsOverride replaces the tolerations, they go together with the node selector.
+func withTolerationsOverride(deployment *appsv1.Deployment, tolerations []corev1.Toleration) {
+	if tolerations == nil {
+		return
+	}
+	deployment.Spec.Template.Spec.Tolerations = tolerations
+}
+
+// withResourcesOverride sets the requests and the limits of the overridden
+// resources only, the others keep their defaults.
+func withResourcesOverride(deployment *appsv1.Deployment, resources *corev1.ResourceRequirements) {
+	if resources == nil {
+		return
+	}
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.Resources = workload.MergeResources(container.Resources, resources)
+}
+
+// withPodMetadataOverride adds labels and annotations to the pods, without
+// replacing those set by the operator.
+func withPodMetadataOverride(deployment *appsv1.Deployment, labels, annotations map[string]string) {
+	podMeta := &deployment.Spec.Template.ObjectMeta
+	for key, value := range labels {
+		if podMeta.Labels == nil {
+			podMeta.Labels = map[string]string{}
+		}
+		if _, ok := podMeta.Labels[key]; !ok {
+			podMeta.Labels[key] = value
+		}
+	}
+	for key, value := range annotations {
+		if podMeta.Annotations == nil {
+			podMeta.Annotations = map[string]string{}
+		}
+		if _, ok := podMeta.Annotations[key]; !ok {
+			podMeta.Annotations[key] = value
+		}
+	}
+}
+
 func withDownloadsContainerImage(downloadsDeployment *appsv1.Deployment) {
 	downloadsDeployment.Spec.Template.Spec.Containers[0].Image = util.GetImageEnv("DOWNLOADS_IMAGE")
 }
@@ -351,11 +475,10 @@ func Stub() *appsv1.Deployment {
 }
 
 func LogDeploymentAnnotationChanges(
-	client appsclientv1.DeploymentsGetter,
+	deploymentLister appsv1listers.DeploymentLister,
 	updated *appsv1.Deployment,
-	ctx context.Context,
 ) {
-	existing, err := client.Deployments(updated.Namespace).Get(ctx, updated.Name, metav1.GetOptions{})
+	existing, err := deploymentLister.Deployments(updated.Namespace).Get(updated.Name)
 	if err != nil {
 		klog.V(4).Infof("%v", err)
 		return
@@ -369,7 +492,7 @@ func LogDeploymentAnnotationChanges(
 		}
 	}
 	if changed {
-		klog.V(4).Infoln("deployment resource versions have changed")
+		klog.V(4).Infoln("deployment input hashes have changed")
 	}
 }
 
@@ -450,6 +573,144 @@ func IsAvailableAndUpdated(deployment *appsv1.Deployment) bool {
 	return available && currentGen && updated
 }
 
+// IsRunningConfig returns whether the latest rollout of the deployment mounts the
+// given content of console-config.
+func IsRunningConfig(deployment *appsv1.Deployment, consoleConfigMap *corev1.ConfigMap) bool {
+	return deployment.Spec.Template.Annotations[configMapHashAnnotation] == configMapHash(consoleConfigMap)
+}
+
+// InputHashes returns the hashes of the inputs the latest rollout of the deployment
+// mounts, leaving out the console image which changes on upgrades only.
+func InputHashes(deployment *appsv1.Deployment) map[string]string {
+	hashes := map[string]string{}
+	for _, annotation := range resourceAnnotations {
+		if annotation == consoleImageAnnotation {
+			continue
+		}
+		if hash, ok := deployment.Spec.Template.Annotations[annotation]; ok {
+			hashes[annotation] = hash
+		}
+	}
+	return hashes
+}
+
+// RolloutHashes returns the hashes of all the inputs the latest rollout of the
+// deployment mounts, the console image included.
+func RolloutHashes(deployment *appsv1.Deployment) map[string]string {
+	hashes := map[string]string{}
+	for _, annotation := range resourceAnnotations {
+		if hash, ok := deployment.Spec.Template.Annotations[annotation]; ok {
+			hashes[annotation] = hash
+		}
+	}
+	return hashes
+}
+
+// IsConfigOnlyRollout returns whether the latest rollout of the deployment changed
+// console-config, and none of the other inputs, from the rollout with the given
+// hashes.
+func IsConfigOnlyRollout(deployment *appsv1.Deployment, previousHashes map[string]string) bool {
+	hashes := RolloutHashes(deployment)
+	if len(previousHashes) == 0 || hashes[configMapHashAnnotation] == previousHashes[configMapHashAnnotation] {
+		return false
+	}
+	for _, annotation := range resourceAnnotations {
+		if annotation != configMapHashAnnotation && hashes[annotation] != previousHashes[annotation] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsImageChanged returns whether the required deployment runs another console image
+// than the existing one.
+func IsImageChanged(existing, required *appsv1.Deployment) bool {
+	return existing.Spec.Template.Annotations[consoleImageAnnotation] != required.Spec.Template.Annotations[consoleImageAnnotation]
+}
+
+// IsRunningOAuthClientSecret returns whether the latest rollout of the deployment
+// mounts the given content of the OAuth client secret.
+func IsRunningOAuthClientSecret(deployment *appsv1.Deployment, clientSecret *corev1.Secret) bool {
+	return deployment.Spec.Template.Annotations[secretHashAnnotation] == secretHash(clientSecret)
+}
+
+// IsRunningAuthnCATrust returns whether the latest rollout of the deployment mounts
+// the given content of the CA trusted by the authentication server.
+func IsRunningAuthnCATrust(deployment *appsv1.Deployment, authnCATrustConfigMap *corev1.ConfigMap) bool {
+	return deployment.Spec.Template.Annotations[authnCATrustConfigMapHashAnnotation] == configMapHash(authnCATrustConfigMap)
+}
+
+// configMapHash hashes the given keys of the ConfigMap, or all of them if none is given.
+func configMapHash(configMap *corev1.ConfigMap, keys ...string) string {
+	if configMap == nil {
+		return ""
+	}
+	data, binaryData := configMap.Data, configMap.BinaryData
+	if len(keys) > 0 {
+		data, binaryData = map[string]string{}, map[string][]byte{}
+		for _, key := range keys {
+			if value, ok := configMap.Data[key]; ok {
+				data[key] = value
+			}
+			if value, ok := configMap.BinaryData[key]; ok {
+				binaryData[key] = value
+			}
+		}
+	}
+	return contentHash(struct {
+		Data       map[string]string
+		BinaryData map[string][]byte
+	}{data, binaryData})
+}
+
+func secretHash(secret *corev1.Secret) string {
+	if secret == nil {
+		return ""
+	}
+	return contentHash(secret.Data)
+}
+
+// proxyConfigHash hashes the proxy status, which the console environment is set from.
+func proxyConfigHash(proxyConfig *configv1.Proxy) string {
+	if proxyConfig == nil {
+		return ""
+	}
+	return contentHash(proxyConfig.Status)
+}
+
+// infrastructureConfigHash hashes the cluster topology, which the replicas and
+// the placement of the console are derived from.
+func infrastructureConfigHash(infrastructureConfig *configv1.Infrastructure) string {
+	if infrastructureConfig == nil {
+		return ""
+	}
+	return contentHash([]configv1.TopologyMode{
+		infrastructureConfig.Status.ControlPlaneTopology,
+		infrastructureConfig.Status.InfrastructureTopology,
+	})
+}
+
+func contentHash(content interface{}) string {
+	// maps are encoded with sorted keys, the hash is stable
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		klog.Errorf("failed to hash console deployment input: %v", err)
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(contentJSON))
+}
+
+// IsProgressDeadlineExceeded returns whether the latest rollout of the deployment
+// has not made progress within its progressDeadlineSeconds.
+func IsProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Status == corev1.ConditionFalse && condition.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
+
 func defaultVolumeConfig() []volumeConfig {
 	return []volumeConfig{
 		{
@@ -498,6 +759,26 @@ func customLogoVolume() volumeConfig {
 		isConfigMap: true}
 }
 
+// customBrandingVolumes mounts the further branding images, the logos per theme
+// and the favicon, which passed validation.
+func customBrandingVolumes(customBrandingConfigMaps []*corev1.ConfigMap) []volumeConfig {
+	volumes := []volumeConfig{}
+	for _, file := range configmapsub.CustomBrandingFiles {
+		for _, configMap := range customBrandingConfigMaps {
+			if configMap.Name != file.ConfigMapName {
+				continue
+			}
+			volumes = append(volumes, volumeConfig{
+				name:        file.ConfigMapName,
+				path:        file.MountDir(),
+				readOnly:    true,
+				isConfigMap: true,
+			})
+		}
+	}
+	return volumes
+}
+
 func oauthServingCertVolumeConfig() volumeConfig {
 	return volumeConfig{
 		name:        api.OAuthServingCertConfigMapName,
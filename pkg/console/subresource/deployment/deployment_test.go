@@ -19,6 +19,7 @@ import (
 	"github.com/openshift/console-operator/pkg/api"
 	"github.com/openshift/console-operator/pkg/console/subresource/configmap"
 	"github.com/openshift/console-operator/pkg/console/subresource/util"
+	"github.com/openshift/console-operator/pkg/console/subresource/workload"
 	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
 )
 
@@ -73,16 +74,6 @@ func TestDefaultDeployment(t *testing.T) {
 		DeletionTimestamp:          nil,
 		DeletionGracePeriodSeconds: nil,
 		Labels:                     labels,
-		Annotations: map[string]string{
-			configMapResourceVersionAnnotation:             "",
-			secretResourceVersionAnnotation:                "",
-			authnCATrustConfigMapResourceVersionAnnotation: "",
-			serviceCAConfigMapResourceVersionAnnotation:    "",
-			trustedCAConfigMapResourceVersionAnnotation:    "",
-			proxyConfigResourceVersionAnnotation:           "",
-			infrastructureConfigResourceVersionAnnotation:  "",
-			consoleImageAnnotation:                         "",
-		},
 		OwnerReferences: []metav1.OwnerReference{{
 			APIVersion: "operator.openshift.io/v1",
 			Kind:       "Console",
@@ -127,32 +118,22 @@ func TestDefaultDeployment(t *testing.T) {
 		},
 	}
 
-	consoleDeploymentTemplateAnnotations := map[string]string{
-		configMapResourceVersionAnnotation:             "",
-		secretResourceVersionAnnotation:                "",
-		authnCATrustConfigMapResourceVersionAnnotation: "",
-		serviceCAConfigMapResourceVersionAnnotation:    "",
-		trustedCAConfigMapResourceVersionAnnotation:    "",
-		proxyConfigResourceVersionAnnotation:           "",
-		infrastructureConfigResourceVersionAnnotation:  "",
-		consoleImageAnnotation:                         "",
-		workloadManagementAnnotation:                   workloadManagementAnnotationValue,
-	}
-
-	consoleDeploymentAffinity := &corev1.Affinity{
-		PodAntiAffinity: &corev1.PodAntiAffinity{
-			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
-				LabelSelector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Key:      "component",
-							Operator: metav1.LabelSelectorOpIn,
-							Values:   []string{"ui"},
-						},
-					},
-				},
-				TopologyKey: "kubernetes.io/hostname",
-			}},
+	consoleDeploymentTopologySpread := []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "ui"},
+			},
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "ui"},
+			},
 		},
 	}
 
@@ -181,15 +162,40 @@ func TestDefaultDeployment(t *testing.T) {
 	withConsoleContainerImage(consoleDeploymentTemplate, consoleOperatorConfig, proxyConfig)
 	withConsoleVolumes(consoleDeploymentTemplate, &corev1.ConfigMap{
 		Data: map[string]string{"ca-bundle.crt": "test"},
-	}, nil, trustedCAConfigMapEmpty, nil, false)
+	}, nil, trustedCAConfigMapEmpty, nil, false, nil)
 	consoleDeploymentContainer := consoleDeploymentTemplate.Spec.Template.Spec.Containers[0]
 	consoleDeploymentVolumes := consoleDeploymentTemplate.Spec.Template.Spec.Volumes
 	withConsoleVolumes(consoleDeploymentTemplate, &corev1.ConfigMap{
 		Data: map[string]string{"ca-bundle.crt": "test"},
-	}, nil, trustedCAConfigMapSet, nil, false)
+	}, nil, trustedCAConfigMapSet, nil, false, nil)
 	consoleDeploymentContainerTrusted := consoleDeploymentTemplate.Spec.Template.Spec.Containers[0]
 	consoleDeploymentVolumesTrusted := consoleDeploymentTemplate.Spec.Template.Spec.Volumes
 
+	// the annotations hash the content of the inputs, which differ between the cases
+	// only by the trusted CA bundle and the cluster topology
+	consoleDeploymentAnnotations := func(trustedCAConfigMap *corev1.ConfigMap, infrastructureConfig *configv1.Infrastructure) map[string]string {
+		return map[string]string{
+			configMapHashAnnotation:             configMapHash(consoleConfig),
+			secretHashAnnotation:                secretHash(&corev1.Secret{}),
+			authnCATrustConfigMapHashAnnotation: configMapHash(&corev1.ConfigMap{Data: map[string]string{"ca-bundle.crt": "test"}}),
+			serviceCAConfigMapHashAnnotation:    configMapHash(&corev1.ConfigMap{}),
+			trustedCAConfigMapHashAnnotation:    configMapHash(trustedCAConfigMap, api.TrustedCABundleKey),
+			proxyConfigHashAnnotation:           proxyConfigHash(proxyConfig),
+			infrastructureConfigHashAnnotation:  infrastructureConfigHash(infrastructureConfig),
+			consoleImageAnnotation:              "",
+		}
+	}
+	consoleDeploymentObjectMetaWith := func(trustedCAConfigMap *corev1.ConfigMap, infrastructureConfig *configv1.Infrastructure) metav1.ObjectMeta {
+		objectMeta := *consoleDeploymentObjectMeta.DeepCopy()
+		objectMeta.Annotations = consoleDeploymentAnnotations(trustedCAConfigMap, infrastructureConfig)
+		return objectMeta
+	}
+	consoleDeploymentTemplateAnnotations := func(trustedCAConfigMap *corev1.ConfigMap, infrastructureConfig *configv1.Infrastructure) map[string]string {
+		annotations := consoleDeploymentAnnotations(trustedCAConfigMap, infrastructureConfig)
+		annotations[workloadManagementAnnotation] = workloadManagementAnnotationValue
+		return annotations
+	}
+
 	tests := []struct {
 		name string
 		args args
@@ -220,7 +226,7 @@ func TestDefaultDeployment(t *testing.T) {
 					Kind:       "Deployment",
 					APIVersion: "apps/v1",
 				},
-				ObjectMeta: consoleDeploymentObjectMeta,
+				ObjectMeta: consoleDeploymentObjectMetaWith(trustedCAConfigMapEmpty, infrastructureConfigHighlyAvailable),
 				Spec: appsv1.DeploymentSpec{
 					Replicas: &defaultReplicaCount,
 
@@ -230,7 +236,7 @@ func TestDefaultDeployment(t *testing.T) {
 					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
 						Name:        api.OpenShiftConsoleName,
 						Labels:      labels,
-						Annotations: consoleDeploymentTemplateAnnotations,
+						Annotations: consoleDeploymentTemplateAnnotations(trustedCAConfigMapEmpty, infrastructureConfigHighlyAvailable),
 					},
 						Spec: corev1.PodSpec{
 							ServiceAccountName: "console",
@@ -239,7 +245,7 @@ func TestDefaultDeployment(t *testing.T) {
 								// empty string is correct
 								"node-role.kubernetes.io/master": "",
 							},
-							Affinity: consoleDeploymentAffinity,
+							TopologySpreadConstraints: consoleDeploymentTopologySpread,
 							// toleration is a taint override. we can and should be scheduled on a master node.
 							Tolerations:                   consoleDeploymentTolerations,
 							PriorityClassName:             "system-cluster-critical",
@@ -302,7 +308,7 @@ func TestDefaultDeployment(t *testing.T) {
 					Kind:       "Deployment",
 					APIVersion: "apps/v1",
 				},
-				ObjectMeta: consoleDeploymentObjectMeta,
+				ObjectMeta: consoleDeploymentObjectMetaWith(trustedCAConfigMapSet, infrastructureConfigHighlyAvailable),
 				Spec: appsv1.DeploymentSpec{
 					Replicas: &defaultReplicaCount,
 					Selector: &metav1.LabelSelector{
@@ -311,7 +317,7 @@ func TestDefaultDeployment(t *testing.T) {
 					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
 						Name:        api.OpenShiftConsoleName,
 						Labels:      labels,
-						Annotations: consoleDeploymentTemplateAnnotations,
+						Annotations: consoleDeploymentTemplateAnnotations(trustedCAConfigMapSet, infrastructureConfigHighlyAvailable),
 					},
 						Spec: corev1.PodSpec{
 							ServiceAccountName: "console",
@@ -320,7 +326,7 @@ func TestDefaultDeployment(t *testing.T) {
 								// empty string is correct
 								"node-role.kubernetes.io/master": "",
 							},
-							Affinity: consoleDeploymentAffinity,
+							TopologySpreadConstraints: consoleDeploymentTopologySpread,
 							// toleration is a taint override. we can and should be scheduled on a master node.
 							Tolerations:                   consoleDeploymentTolerations,
 							PriorityClassName:             "system-cluster-critical",
@@ -383,7 +389,7 @@ func TestDefaultDeployment(t *testing.T) {
 					Kind:       "Deployment",
 					APIVersion: "apps/v1",
 				},
-				ObjectMeta: consoleDeploymentObjectMeta,
+				ObjectMeta: consoleDeploymentObjectMetaWith(trustedCAConfigMapEmpty, infrastructureConfigSingleReplica),
 				Spec: appsv1.DeploymentSpec{
 					Replicas: &singleNodeReplicaCount,
 					Selector: &metav1.LabelSelector{
@@ -392,7 +398,7 @@ func TestDefaultDeployment(t *testing.T) {
 					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
 						Name:        api.OpenShiftConsoleName,
 						Labels:      labels,
-						Annotations: consoleDeploymentTemplateAnnotations,
+						Annotations: consoleDeploymentTemplateAnnotations(trustedCAConfigMapEmpty, infrastructureConfigSingleReplica),
 					},
 						Spec: corev1.PodSpec{
 							ServiceAccountName: "console",
@@ -401,7 +407,6 @@ func TestDefaultDeployment(t *testing.T) {
 								// empty string is correct
 								"node-role.kubernetes.io/master": "",
 							},
-							Affinity: &corev1.Affinity{},
 							// toleration is a taint override. we can and should be scheduled on a master node.
 							Tolerations:                   consoleDeploymentTolerations,
 							PriorityClassName:             "system-cluster-critical",
@@ -457,7 +462,7 @@ func TestDefaultDeployment(t *testing.T) {
 					Kind:       "Deployment",
 					APIVersion: "apps/v1",
 				},
-				ObjectMeta: consoleDeploymentObjectMeta,
+				ObjectMeta: consoleDeploymentObjectMetaWith(trustedCAConfigMapEmpty, infrastructureConfigExternalTopologyMode),
 				Spec: appsv1.DeploymentSpec{
 					Replicas: &defaultReplicaCount,
 					Selector: &metav1.LabelSelector{
@@ -466,13 +471,13 @@ func TestDefaultDeployment(t *testing.T) {
 					Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
 						Name:        api.OpenShiftConsoleName,
 						Labels:      labels,
-						Annotations: consoleDeploymentTemplateAnnotations,
+						Annotations: consoleDeploymentTemplateAnnotations(trustedCAConfigMapEmpty, infrastructureConfigExternalTopologyMode),
 					},
 						Spec: corev1.PodSpec{
 							ServiceAccountName: "console",
 							// we do not want to deploy on master nodes
-							NodeSelector: map[string]string{},
-							Affinity:     consoleDeploymentAffinity,
+							NodeSelector:              map[string]string{},
+							TopologySpreadConstraints: consoleDeploymentTopologySpread,
 							// toleration is a taint override. we can and should be scheduled on a master node.
 							Tolerations:                   consoleDeploymentTolerations,
 							PriorityClassName:             "system-cluster-critical",
@@ -522,9 +527,13 @@ func TestDefaultDeployment(t *testing.T) {
 				tt.args.trustedCAConfigMap,
 				tt.args.oAuthClientSecret,
 				tt.args.sessionSecret,
+				nil,
+				nil,
 				tt.args.proxyConfig,
 				tt.args.infrastructureConfig,
 				tt.args.canMountCustomLogo,
+				nil,
+				nil,
 			), tt.want); diff != nil {
 				t.Error(diff)
 			}
@@ -541,9 +550,10 @@ func TestWithConsoleAnnotations(t *testing.T) {
 		trustedCAConfigMap    *corev1.ConfigMap
 		oAuthClientSecret     *corev1.Secret
 		sessionSecret         *corev1.Secret
+		customLogoConfigMap   *corev1.ConfigMap
+		customBranding        []*corev1.ConfigMap
 		proxyConfig           *configv1.Proxy
 		infrastructureConfig  *configv1.Infrastructure
-		authnConfig           *configv1.Authentication
 	}
 
 	consoleConfigMap := &corev1.ConfigMap{
@@ -575,22 +585,101 @@ func TestWithConsoleAnnotations(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			ResourceVersion: "34343",
 		},
+		Data: map[string]string{"service-ca.crt": "test"},
 	}
 	oauthServingCertConfigMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			ResourceVersion: "77777",
 		},
+		Data: map[string]string{"ca-bundle.crt": "test"},
 	}
 	trustedCAConfigMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			ResourceVersion: "75577",
 		},
+		Data: map[string]string{api.TrustedCABundleKey: "test"},
 	}
 
 	oAuthClientSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			ResourceVersion: "101010",
 		},
+		Data: map[string][]byte{"clientSecret": []byte("secret")},
+	}
+
+	sessionSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			ResourceVersion: "202020",
+		},
+		Data: map[string][]byte{"sessionEncryptionKey": []byte("key")},
+	}
+
+	customLogoConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			ResourceVersion: "303030",
+		},
+		BinaryData: map[string][]byte{"logo.svg": []byte("<svg/>")},
+	}
+
+	customFaviconConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            api.CustomFaviconConfigMapName,
+			ResourceVersion: "404040",
+		},
+		BinaryData: map[string][]byte{"favicon.png": []byte("favicon")},
+	}
+
+	defaultAnnotations := map[string]string{
+		configMapHashAnnotation:             configMapHash(consoleConfigMap),
+		serviceCAConfigMapHashAnnotation:    configMapHash(serviceCAConfigMap),
+		authnCATrustConfigMapHashAnnotation: configMapHash(oauthServingCertConfigMap),
+		trustedCAConfigMapHashAnnotation:    configMapHash(trustedCAConfigMap, api.TrustedCABundleKey),
+		proxyConfigHashAnnotation:           proxyConfigHash(proxyConfig),
+		infrastructureConfigHashAnnotation:  infrastructureConfigHash(infrastructureConfig),
+		secretHashAnnotation:                secretHash(oAuthClientSecret),
+		consoleImageAnnotation:              util.GetImageEnv("CONSOLE_IMAGE"),
+	}
+	withAnnotations := func(annotations map[string]string, extra map[string]string) map[string]string {
+		merged := map[string]string{}
+		for k, v := range annotations {
+			merged[k] = v
+		}
+		for k, v := range extra {
+			merged[k] = v
+		}
+		return merged
+	}
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							workloadManagementAnnotation: workloadManagementAnnotationValue,
+						},
+					},
+				},
+			},
+		}
+	}
+	wantDeployment := func(annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: annotations,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: withAnnotations(annotations, map[string]string{
+							workloadManagementAnnotation: workloadManagementAnnotationValue,
+						}),
+					},
+				},
+			},
+		}
 	}
 
 	tests := []struct {
@@ -601,20 +690,7 @@ func TestWithConsoleAnnotations(t *testing.T) {
 		{
 			name: "Test Default Annotations",
 			args: args{
-				deployment: &appsv1.Deployment{
-					ObjectMeta: metav1.ObjectMeta{
-						Annotations: map[string]string{},
-					},
-					Spec: appsv1.DeploymentSpec{
-						Template: corev1.PodTemplateSpec{
-							ObjectMeta: metav1.ObjectMeta{
-								Annotations: map[string]string{
-									workloadManagementAnnotation: workloadManagementAnnotationValue,
-								},
-							},
-						},
-					},
-				},
+				deployment:            newDeployment(),
 				consoleConfigMap:      consoleConfigMap,
 				serviceCAConfigMap:    serviceCAConfigMap,
 				authServerCAConfigMap: oauthServingCertConfigMap,
@@ -623,42 +699,89 @@ func TestWithConsoleAnnotations(t *testing.T) {
 				proxyConfig:           proxyConfig,
 				infrastructureConfig:  infrastructureConfig,
 			},
-			want: &appsv1.Deployment{
-				ObjectMeta: metav1.ObjectMeta{
-					Annotations: map[string]string{
-						configMapResourceVersionAnnotation:             consoleConfigMap.GetResourceVersion(),
-						serviceCAConfigMapResourceVersionAnnotation:    serviceCAConfigMap.GetResourceVersion(),
-						authnCATrustConfigMapResourceVersionAnnotation: oauthServingCertConfigMap.GetResourceVersion(),
-						trustedCAConfigMapResourceVersionAnnotation:    trustedCAConfigMap.GetResourceVersion(),
-						proxyConfigResourceVersionAnnotation:           proxyConfig.GetResourceVersion(),
-						infrastructureConfigResourceVersionAnnotation:  infrastructureConfig.GetResourceVersion(),
-						secretResourceVersionAnnotation:                oAuthClientSecret.GetResourceVersion(),
-						consoleImageAnnotation:                         util.GetImageEnv("CONSOLE_IMAGE"),
-					},
-				},
-				Spec: appsv1.DeploymentSpec{
-					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{
-								workloadManagementAnnotation:                   workloadManagementAnnotationValue,
-								configMapResourceVersionAnnotation:             consoleConfigMap.GetResourceVersion(),
-								serviceCAConfigMapResourceVersionAnnotation:    serviceCAConfigMap.GetResourceVersion(),
-								authnCATrustConfigMapResourceVersionAnnotation: oauthServingCertConfigMap.GetResourceVersion(),
-								trustedCAConfigMapResourceVersionAnnotation:    trustedCAConfigMap.GetResourceVersion(),
-								proxyConfigResourceVersionAnnotation:           proxyConfig.GetResourceVersion(),
-								infrastructureConfigResourceVersionAnnotation:  infrastructureConfig.GetResourceVersion(),
-								secretResourceVersionAnnotation:                oAuthClientSecret.GetResourceVersion(),
-								consoleImageAnnotation:                         util.GetImageEnv("CONSOLE_IMAGE"),
-							},
-						},
-					},
+			want: wantDeployment(defaultAnnotations),
+		},
+		{
+			name: "Test Annotations ignore resource version only changes",
+			args: args{
+				deployment: newDeployment(),
+				consoleConfigMap: func() *corev1.ConfigMap {
+					updated := consoleConfigMap.DeepCopy()
+					updated.ResourceVersion = "10246"
+					updated.Labels = map[string]string{"updated": "true"}
+					return updated
+				}(),
+				serviceCAConfigMap:    serviceCAConfigMap,
+				authServerCAConfigMap: oauthServingCertConfigMap,
+				trustedCAConfigMap: func() *corev1.ConfigMap {
+					// keys the console does not mount do not roll it out
+					updated := trustedCAConfigMap.DeepCopy()
+					updated.ResourceVersion = "75578"
+					updated.Data["unrelated"] = "test"
+					return updated
+				}(),
+				oAuthClientSecret: oAuthClientSecret,
+				proxyConfig: func() *configv1.Proxy {
+					updated := proxyConfig.DeepCopy()
+					updated.ResourceVersion = "54322"
+					updated.Spec.HTTPProxy = "http://not-yet-observed.openshift.com"
+					return updated
+				}(),
+				infrastructureConfig: func() *configv1.Infrastructure {
+					updated := infrastructureConfig.DeepCopy()
+					updated.ResourceVersion = "12346"
+					updated.Status.APIServerURL = "https://api.openshift.com"
+					return updated
+				}(),
+			},
+			want: wantDeployment(defaultAnnotations),
+		},
+		{
+			name: "Test Annotations follow content changes",
+			args: args{
+				deployment: newDeployment(),
+				consoleConfigMap: &corev1.ConfigMap{
+					ObjectMeta: consoleConfigMap.ObjectMeta,
+					Data:       map[string]string{"console-config.yaml": "changed"},
 				},
+				serviceCAConfigMap:    serviceCAConfigMap,
+				authServerCAConfigMap: oauthServingCertConfigMap,
+				trustedCAConfigMap:    trustedCAConfigMap,
+				oAuthClientSecret:     oAuthClientSecret,
+				proxyConfig:           proxyConfig,
+				infrastructureConfig:  infrastructureConfig,
+			},
+			want: wantDeployment(withAnnotations(defaultAnnotations, map[string]string{
+				configMapHashAnnotation: configMapHash(&corev1.ConfigMap{Data: map[string]string{"console-config.yaml": "changed"}}),
+			})),
+		},
+		{
+			name: "Test Session Secret, Custom Logo and Branding Annotations",
+			args: args{
+				deployment:            newDeployment(),
+				consoleConfigMap:      consoleConfigMap,
+				serviceCAConfigMap:    serviceCAConfigMap,
+				authServerCAConfigMap: oauthServingCertConfigMap,
+				trustedCAConfigMap:    trustedCAConfigMap,
+				oAuthClientSecret:     oAuthClientSecret,
+				sessionSecret:         sessionSecret,
+				customLogoConfigMap:   customLogoConfigMap,
+				customBranding:        []*corev1.ConfigMap{customFaviconConfigMap},
+				proxyConfig:           proxyConfig,
+				infrastructureConfig:  infrastructureConfig,
 			},
+			want: wantDeployment(withAnnotations(defaultAnnotations, map[string]string{
+				sessionSecretHashAnnotation:       secretHash(sessionSecret),
+				customLogoConfigMapHashAnnotation: configMapHash(customLogoConfigMap),
+				customBrandingHashAnnotation: contentHash(map[string]string{
+					api.CustomFaviconConfigMapName: configMapHash(customFaviconConfigMap),
+				}),
+			})),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			withConsoleAnnotations(tt.args.deployment, tt.args.consoleConfigMap, tt.args.serviceCAConfigMap, tt.args.authServerCAConfigMap, tt.args.trustedCAConfigMap, tt.args.oAuthClientSecret, tt.args.sessionSecret, tt.args.proxyConfig, tt.args.infrastructureConfig)
+			withConsoleAnnotations(tt.args.deployment, tt.args.consoleConfigMap, tt.args.serviceCAConfigMap, tt.args.authServerCAConfigMap, tt.args.trustedCAConfigMap, tt.args.oAuthClientSecret, tt.args.sessionSecret, tt.args.customLogoConfigMap, tt.args.customBranding, tt.args.proxyConfig, tt.args.infrastructureConfig)
 			if diff := deep.Equal(tt.args.deployment, tt.want); diff != nil {
 				t.Error(diff)
 			}
@@ -670,11 +793,13 @@ func TestWithReplicas(t *testing.T) {
 	var (
 		singleNodeReplicaCount int32 = SingleNodeConsoleReplicas
 		defaultReplicaCount    int32 = DefaultConsoleReplicas
+		minReplicaCount        int32 = 3
 	)
 
 	type args struct {
 		deployment           *appsv1.Deployment
 		infrastructureConfig *configv1.Infrastructure
+		autoscaling          *workload.Autoscaling
 	}
 
 	infrastructureConfigHighlyAvailable := infrastructureConfigWithTopology(configv1.HighlyAvailableTopologyMode,
@@ -747,10 +872,25 @@ func TestWithReplicas(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test Autoscaled Replica",
+			args: args{
+				deployment: &appsv1.Deployment{
+					Spec: appsv1.DeploymentSpec{},
+				},
+				infrastructureConfig: infrastructureConfigSingleReplica,
+				autoscaling:          &workload.Autoscaling{MinReplicas: 3, MaxReplicas: 6},
+			},
+			want: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &minReplicaCount,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			withReplicas(tt.args.deployment, tt.args.infrastructureConfig)
+			withReplicas(tt.args.deployment, tt.args.infrastructureConfig, tt.args.autoscaling)
 			if diff := deep.Equal(tt.args.deployment, tt.want); diff != nil {
 				t.Error(diff)
 			}
@@ -758,11 +898,59 @@ func TestWithReplicas(t *testing.T) {
 	}
 }
 
-func TestWithAffinity(t *testing.T) {
+func TestWithAutoscaledReplicas(t *testing.T) {
+	autoscaling := &workload.Autoscaling{MinReplicas: 2, MaxReplicas: 6}
+	deploymentWithReplicas := func(replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	}
+
+	tests := []struct {
+		name        string
+		existing    *appsv1.Deployment
+		autoscaling *workload.Autoscaling
+		want        *appsv1.Deployment
+	}{
+		{
+			name:        "Test replicas of a new deployment",
+			existing:    nil,
+			autoscaling: autoscaling,
+			want:        deploymentWithReplicas(2),
+		},
+		{
+			name:        "Test replicas scaled by the autoscaler are kept",
+			existing:    deploymentWithReplicas(5),
+			autoscaling: autoscaling,
+			want:        deploymentWithReplicas(5),
+		},
+		{
+			name:        "Test replicas out of the autoscaler bounds are reset",
+			existing:    deploymentWithReplicas(8),
+			autoscaling: autoscaling,
+			want:        deploymentWithReplicas(2),
+		},
+		{
+			name:        "Test replicas without autoscaling",
+			existing:    deploymentWithReplicas(5),
+			autoscaling: nil,
+			want:        deploymentWithReplicas(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			required := deploymentWithReplicas(2)
+			WithAutoscaledReplicas(required, tt.existing, tt.autoscaling)
+			if diff := deep.Equal(required, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWithTopologySpread(t *testing.T) {
 	type args struct {
-		deployment           *appsv1.Deployment
 		infrastructureConfig *configv1.Infrastructure
 		component            string
+		autoscaling          *workload.Autoscaling
 	}
 
 	infrastructureConfigHighlyAvailable := infrastructureConfigWithTopology(configv1.HighlyAvailableTopologyMode, configv1.HighlyAvailableTopologyMode)
@@ -771,123 +959,95 @@ func TestWithAffinity(t *testing.T) {
 		configv1.SingleReplicaTopologyMode)
 	infrastructureConfigExternalCPHighlyAvailable := infrastructureConfigWithTopology(configv1.ExternalTopologyMode,
 		configv1.HighlyAvailableTopologyMode)
+	uiTopologySpread := []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "ui"},
+			},
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "ui"},
+			},
+		},
+	}
+	foobarTopologySpread := []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "foobar"},
+			},
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "foobar"},
+			},
+		},
+	}
 
 	tests := []struct {
 		name string
 		args args
-		want *appsv1.Deployment
+		want []corev1.TopologySpreadConstraint
 	}{
 		{
-			name: "Test Single Replica Affinity",
+			name: "Test Single Replica Topology Spread",
 			args: args{
-				deployment: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{},
-				},
 				infrastructureConfig: infrastructureConfigSingleReplica,
 				component:            "ui",
 			},
-			want: &appsv1.Deployment{
-				Spec: appsv1.DeploymentSpec{
-					Template: corev1.PodTemplateSpec{
-						Spec: corev1.PodSpec{
-							Affinity: &corev1.Affinity{},
-						},
-					},
-				},
-			},
+			want: nil,
 		},
 		{
-			name: "Test Highly Available Affinity",
+			name: "Test Highly Available Topology Spread",
 			args: args{
-				deployment: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{},
-				},
 				infrastructureConfig: infrastructureConfigHighlyAvailable,
 				component:            "foobar",
 			},
-			want: &appsv1.Deployment{
-				Spec: appsv1.DeploymentSpec{
-					Template: corev1.PodTemplateSpec{
-						Spec: corev1.PodSpec{
-							Affinity: &corev1.Affinity{
-								PodAntiAffinity: &corev1.PodAntiAffinity{
-									RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
-										LabelSelector: &metav1.LabelSelector{
-											MatchExpressions: []metav1.LabelSelectorRequirement{
-												{
-													Key:      "component",
-													Operator: metav1.LabelSelectorOpIn,
-													Values:   []string{"foobar"},
-												},
-											},
-										},
-										TopologyKey: "kubernetes.io/hostname",
-									}},
-								},
-							},
-						},
-					},
-				},
-			},
+			want: foobarTopologySpread,
 		},
 		{
-			name: "Test Single Replica Affinity in externalized control plane with Single Replica workers",
+			name: "Test Single Replica Topology Spread in externalized control plane with Single Replica workers",
 			args: args{
-				deployment: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{},
-				},
 				infrastructureConfig: infrastructureConfigExternalCPSingleReplica,
 				component:            "ui",
 			},
-			want: &appsv1.Deployment{
-				Spec: appsv1.DeploymentSpec{
-					Template: corev1.PodTemplateSpec{
-						Spec: corev1.PodSpec{
-							Affinity: &corev1.Affinity{},
-						},
-					},
-				},
-			},
+			want: nil,
 		},
 		{
-			name: "Test Highly Available Affinity in externalized control plane with Highly Available workers",
+			name: "Test Highly Available Topology Spread in externalized control plane with Highly Available workers",
 			args: args{
-				deployment: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{},
-				},
 				infrastructureConfig: infrastructureConfigExternalCPHighlyAvailable,
 				component:            "foobar",
 			},
-			want: &appsv1.Deployment{
-				Spec: appsv1.DeploymentSpec{
-					Template: corev1.PodTemplateSpec{
-						Spec: corev1.PodSpec{
-							Affinity: &corev1.Affinity{
-								PodAntiAffinity: &corev1.PodAntiAffinity{
-									RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
-										LabelSelector: &metav1.LabelSelector{
-											MatchExpressions: []metav1.LabelSelectorRequirement{
-												{
-													Key:      "component",
-													Operator: metav1.LabelSelectorOpIn,
-													Values:   []string{"foobar"},
-												},
-											},
-										},
-										TopologyKey: "kubernetes.io/hostname",
-									}},
-								},
-							},
-						},
-					},
-				},
+			want: foobarTopologySpread,
+		},
+		{
+			name: "Test Autoscaled Topology Spread on a Single Replica topology",
+			args: args{
+				infrastructureConfig: infrastructureConfigSingleReplica,
+				component:            "ui",
+				autoscaling:          &workload.Autoscaling{MinReplicas: 1, MaxReplicas: 4},
 			},
+			want: uiTopologySpread,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			withAffinity(tt.args.deployment, tt.args.infrastructureConfig, tt.args.component)
-			if diff := deep.Equal(tt.args.deployment, tt.want); diff != nil {
+			deployment := &appsv1.Deployment{}
+			withTopologySpread(deployment, tt.args.infrastructureConfig, tt.args.component, tt.args.autoscaling)
+			if diff := deep.Equal(deployment.Spec.Template.Spec.TopologySpreadConstraints, tt.want); diff != nil {
 				t.Error(diff)
 			}
 		})
@@ -900,6 +1060,7 @@ func TestWithConsoleVolumes(t *testing.T) {
 		trustedCAConfigMap *corev1.ConfigMap
 		sessionSecret      *corev1.Secret
 		canMountCustomLogo bool
+		customBranding     []*corev1.ConfigMap
 	}
 
 	trustedCAConfigMap := &corev1.ConfigMap{
@@ -1008,6 +1169,19 @@ func TestWithConsoleVolumes(t *testing.T) {
 		},
 	}
 
+	customBrandingVolume := func(name string) corev1.Volume {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: name,
+					},
+				},
+			},
+		}
+	}
+
 	defaultVolumes := []corev1.Volume{
 		consoleServingCertVolume,
 		consoleOauthConfigVolume,
@@ -1017,6 +1191,8 @@ func TestWithConsoleVolumes(t *testing.T) {
 	trustedVolumes := append(defaultVolumes, trustedCAVolume)
 	customLogoVolumes := append(defaultVolumes, customLogoVolume)
 	allVolumes := append(defaultVolumes, trustedCAVolume, customLogoVolume)
+	customBrandingVolumes := append(defaultVolumes, customLogoVolume,
+		customBrandingVolume(api.CustomLogoLightConfigMapName), customBrandingVolume(api.CustomFaviconConfigMapName))
 
 	consoleServingCertVolumeMount := corev1.VolumeMount{
 		Name:      api.ConsoleServingCertName,
@@ -1063,6 +1239,18 @@ func TestWithConsoleVolumes(t *testing.T) {
 	trustedVolumeMounts := append(defaultVolumeMounts, trustedCAVolumeMount)
 	customLogoVolumeMounts := append(defaultVolumeMounts, customLogoVolumeMount)
 	allVolumeMounts := append(defaultVolumeMounts, trustedCAVolumeMount, customLogoVolumeMount)
+	customBrandingVolumeMounts := append(defaultVolumeMounts, customLogoVolumeMount,
+		corev1.VolumeMount{
+			Name:      api.CustomLogoLightConfigMapName,
+			ReadOnly:  true,
+			MountPath: "/var/branding/custom-logo-light/",
+		},
+		corev1.VolumeMount{
+			Name:      api.CustomFaviconConfigMapName,
+			ReadOnly:  true,
+			MountPath: "/var/branding/custom-favicon/",
+		},
+	)
 
 	tests := []struct {
 		name string
@@ -1161,6 +1349,33 @@ func TestWithConsoleVolumes(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test Volumes With Custom Logo And Branding Images",
+			args: args{
+				deployment:         consoleDeployment,
+				trustedCAConfigMap: &corev1.ConfigMap{},
+				canMountCustomLogo: true,
+				customBranding: []*corev1.ConfigMap{
+					{ObjectMeta: metav1.ObjectMeta{Name: api.CustomFaviconConfigMapName}},
+					{ObjectMeta: metav1.ObjectMeta{Name: api.CustomLogoLightConfigMapName}},
+				},
+			},
+			want: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:         "consoleContainer",
+									VolumeMounts: customBrandingVolumeMounts,
+								},
+							},
+							Volumes: customBrandingVolumes,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1171,6 +1386,7 @@ func TestWithConsoleVolumes(t *testing.T) {
 				tt.args.trustedCAConfigMap,
 				tt.args.sessionSecret,
 				tt.args.canMountCustomLogo,
+				tt.args.customBranding,
 			)
 			if diff := deep.Equal(tt.args.deployment, tt.want); diff != nil {
 				t.Error(diff)
@@ -1278,6 +1494,7 @@ func TestWithStrategy(t *testing.T) {
 	type args struct {
 		deployment           *appsv1.Deployment
 		infrastructureConfig *configv1.Infrastructure
+		autoscaling          *workload.Autoscaling
 	}
 
 	infrastructureConfigHighlyAvailable := infrastructureConfigWithTopology(configv1.HighlyAvailableTopologyMode, configv1.HighlyAvailableTopologyMode)
@@ -1294,6 +1511,11 @@ func TestWithStrategy(t *testing.T) {
 			IntVal: int32(1),
 		},
 	}
+	autoscaledRollingUpdatePercentage := intstr.FromString(AutoscaledRollingUpdatePercentage)
+	autoscaledStrategy := appsv1.RollingUpdateDeployment{
+		MaxSurge:       &autoscaledRollingUpdatePercentage,
+		MaxUnavailable: &autoscaledRollingUpdatePercentage,
+	}
 
 	tests := []struct {
 		name string
@@ -1356,10 +1578,25 @@ func TestWithStrategy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test Autoscaled Strategy",
+			args: args{
+				deployment:           &appsv1.Deployment{},
+				infrastructureConfig: infrastructureConfigSingleReplica,
+				autoscaling:          &workload.Autoscaling{MinReplicas: 1, MaxReplicas: 6},
+			},
+			want: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Strategy: appsv1.DeploymentStrategy{
+						RollingUpdate: &autoscaledStrategy,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			withStrategy(tt.args.deployment, tt.args.infrastructureConfig)
+			withStrategy(tt.args.deployment, tt.args.infrastructureConfig, tt.args.autoscaling)
 			if diff := deep.Equal(tt.args.deployment, tt.want); diff != nil {
 				t.Error(diff)
 			}
@@ -1429,6 +1666,105 @@ func TestWithConsoleNodeSelector(t *testing.T) {
 	}
 }
 
+func TestWithPodOverrides(t *testing.T) {
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      map[string]string{"app": api.OpenShiftConsoleName, "component": "ui"},
+						Annotations: map[string]string{workloadManagementAnnotation: workloadManagementAnnotationValue},
+					},
+					Spec: corev1.PodSpec{
+						NodeSelector: map[string]string{"node-role.kubernetes.io/master": ""},
+						Tolerations: []corev1.Toleration{{
+							Key:      "node-role.kubernetes.io/master",
+							Operator: corev1.TolerationOpExists,
+							Effect:   corev1.TaintEffectNoSchedule,
+						}},
+						Containers: []corev1.Container{{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("10m"),
+									corev1.ResourceMemory: resource.MustParse("100Mi"),
+								},
+							},
+						}},
+					},
+				},
+			},
+		}
+	}
+	infraTolerations := []corev1.Toleration{{
+		Key:      "node-role.kubernetes.io/infra",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}}
+
+	tests := []struct {
+		name         string
+		podOverrides *workload.PodOverrides
+		want         func(*appsv1.Deployment)
+	}{
+		{
+			name:         "Test no overrides",
+			podOverrides: nil,
+			want:         func(*appsv1.Deployment) {},
+		},
+		{
+			name: "Test placement overrides replace the defaults",
+			podOverrides: &workload.PodOverrides{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations:  infraTolerations,
+			},
+			want: func(deployment *appsv1.Deployment) {
+				deployment.Spec.Template.Spec.NodeSelector = map[string]string{"node-role.kubernetes.io/infra": ""}
+				deployment.Spec.Template.Spec.Tolerations = infraTolerations
+			},
+		},
+		{
+			name: "Test resource overrides are merged into the defaults",
+			podOverrides: &workload.PodOverrides{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("200Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+			want: func(deployment *appsv1.Deployment) {
+				deployment.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("10m"),
+						corev1.ResourceMemory: resource.MustParse("200Mi"),
+					},
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				}
+			},
+		},
+		{
+			name: "Test pod metadata overrides do not replace the operator's",
+			podOverrides: &workload.PodOverrides{
+				PodLabels:      map[string]string{"app": "other", "team": "console"},
+				PodAnnotations: map[string]string{workloadManagementAnnotation: "{}", "example.com/owner": "console"},
+			},
+			want: func(deployment *appsv1.Deployment) {
+				deployment.Spec.Template.Labels["team"] = "console"
+				deployment.Spec.Template.Annotations["example.com/owner"] = "console"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := newDeployment()
+			withPodOverrides(deployment, tt.podOverrides)
+			want := newDeployment()
+			tt.want(want)
+			if diff := deep.Equal(deployment, want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestDefaultDownloadsDeployment(t *testing.T) {
 
 	var (
@@ -1486,7 +1822,6 @@ func TestDefaultDownloadsDeployment(t *testing.T) {
 		NodeSelector: map[string]string{
 			"kubernetes.io/os": "linux",
 		},
-		Affinity: &corev1.Affinity{},
 		Tolerations: []corev1.Toleration{
 			{
 				Key:      "node-role.kubernetes.io/master",
@@ -1566,20 +1901,22 @@ func TestDefaultDownloadsDeployment(t *testing.T) {
 		},
 	}
 	downloadsDeploymentPodSpecHighAvail := downloadsDeploymentPodSpecSingleReplica.DeepCopy()
-	downloadsDeploymentPodSpecHighAvail.Affinity = &corev1.Affinity{
-		PodAntiAffinity: &corev1.PodAntiAffinity{
-			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
-				LabelSelector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Key:      "component",
-							Operator: metav1.LabelSelectorOpIn,
-							Values:   []string{"downloads"},
-						},
-					},
-				},
-				TopologyKey: "kubernetes.io/hostname",
-			}},
+	downloadsDeploymentPodSpecHighAvail.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "downloads"},
+			},
+		},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"component": "downloads"},
+			},
 		},
 	}
 
@@ -1668,7 +2005,7 @@ func TestDefaultDownloadsDeployment(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if diff := deep.Equal(DefaultDownloadsDeployment(tt.args.config, tt.args.infrastructure), tt.want); diff != nil {
+			if diff := deep.Equal(DefaultDownloadsDeployment(tt.args.config, tt.args.infrastructure, nil), tt.want); diff != nil {
 				t.Error(diff)
 			}
 		})
@@ -1936,3 +2273,116 @@ func infrastructureConfigWithTopology(controlPlaneTopologyMode, infrastructureTo
 		},
 	}
 }
+
+func TestIsProgressDeadlineExceeded(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []appsv1.DeploymentCondition
+		want       bool
+	}{
+		{
+			name:       "Test deployment without conditions",
+			conditions: nil,
+			want:       false,
+		},
+		{
+			name: "Test deployment progressing",
+			conditions: []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionTrue,
+				Reason: "ReplicaSetUpdated",
+			}},
+			want: false,
+		},
+		{
+			name: "Test deployment past its progress deadline",
+			conditions: []appsv1.DeploymentCondition{
+				{
+					Type:   appsv1.DeploymentAvailable,
+					Status: corev1.ConditionTrue,
+				},
+				{
+					Type:   appsv1.DeploymentProgressing,
+					Status: corev1.ConditionFalse,
+					Reason: "ProgressDeadlineExceeded",
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{Status: appsv1.DeploymentStatus{Conditions: tt.conditions}}
+			if got := IsProgressDeadlineExceeded(deployment); got != tt.want {
+				t.Errorf("IsProgressDeadlineExceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsConfigOnlyRollout(t *testing.T) {
+	lastGoodHashes := map[string]string{
+		configMapHashAnnotation:     "config-a",
+		sessionSecretHashAnnotation: "session-a",
+		consoleImageAnnotation:      "image-a",
+	}
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		previousHashes map[string]string
+		want           bool
+	}{
+		{
+			name: "Test rollout of a new console-config only",
+			annotations: map[string]string{
+				configMapHashAnnotation:     "config-b",
+				sessionSecretHashAnnotation: "session-a",
+				consoleImageAnnotation:      "image-a",
+			},
+			previousHashes: lastGoodHashes,
+			want:           true,
+		},
+		{
+			name: "Test rollout of the last good console-config",
+			annotations: map[string]string{
+				configMapHashAnnotation:     "config-a",
+				sessionSecretHashAnnotation: "session-b",
+				consoleImageAnnotation:      "image-a",
+			},
+			previousHashes: lastGoodHashes,
+			want:           false,
+		},
+		{
+			name: "Test rollout of a new console-config along with a new image",
+			annotations: map[string]string{
+				configMapHashAnnotation:     "config-b",
+				sessionSecretHashAnnotation: "session-a",
+				consoleImageAnnotation:      "image-b",
+			},
+			previousHashes: lastGoodHashes,
+			want:           false,
+		},
+		{
+			name: "Test rollout without a last good rollout",
+			annotations: map[string]string{
+				configMapHashAnnotation: "config-b",
+			},
+			previousHashes: nil,
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+					},
+				},
+			}
+			if got := IsConfigOnlyRollout(deployment, tt.previousHashes); got != tt.want {
+				t.Errorf("IsConfigOnlyRollout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
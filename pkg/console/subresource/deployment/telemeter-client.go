@@ -1,8 +1,12 @@
 package deployment
 
 import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -10,8 +14,10 @@ const (
 	TelemeterClientDeploymentNamespace = "openshift-monitoring"
 )
 
-func IsTelemeterClientAvailable(deploymentLister appsv1listers.DeploymentLister) (bool, error) {
-	deployment, err := deploymentLister.Deployments(TelemeterClientDeploymentNamespace).Get(TelemeterClientDeploymentName)
+// IsTelemeterClientAvailable takes a lister of unstructured deployments, the
+// monitoring stack is optional and its deployments are watched through a dynamic informer.
+func IsTelemeterClientAvailable(deploymentLister cache.GenericLister) (bool, error) {
+	obj, err := deploymentLister.ByNamespace(TelemeterClientDeploymentNamespace).Get(TelemeterClientDeploymentName)
 
 	if errors.IsNotFound(err) {
 		return false, nil
@@ -21,5 +27,14 @@ func IsTelemeterClientAvailable(deploymentLister appsv1listers.DeploymentLister)
 		return false, err
 	}
 
+	deployment := &appsv1.Deployment{}
+	if unstructuredObj, ok := obj.(runtime.Unstructured); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.UnstructuredContent(), deployment); err != nil {
+			return false, err
+		}
+	} else if deployment, ok = obj.(*appsv1.Deployment); !ok {
+		return false, fmt.Errorf("unexpected telemeter-client deployment type %T", obj)
+	}
+
 	return IsAvailable(deployment), nil
 }
@@ -2,6 +2,7 @@ package oauthclient
 
 import (
 	"context"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,31 +35,44 @@ func CustomApplyOAuth(client oauthclient.OAuthClientsGetter, required *oauthv1.O
 	// tedious to manually copy things over
 	modified := resourcemerge.BoolPtr(false)
 	resourcemerge.EnsureObjectMeta(modified, &existing.ObjectMeta, required.ObjectMeta)
-	// at present, we only care about these two fields. this is NOT generic to all oauth clients
+	// the annotation recording the token policy set by the operator goes away with it,
+	// which merging the metadata does not do
+	if _, ok := required.Annotations[api.OAuthClientTokenPolicyFieldsAnnotation]; !ok {
+		if _, ok := existing.Annotations[api.OAuthClientTokenPolicyFieldsAnnotation]; ok {
+			delete(existing.Annotations, api.OAuthClientTokenPolicyFieldsAnnotation)
+			*modified = true
+		}
+	}
+	// at present, we only care about these fields. this is NOT generic to all oauth clients
 	secretSame := equality.Semantic.DeepEqual(existing.Secret, required.Secret)
+	additionalSecretsSame := equality.Semantic.DeepEqual(existing.AdditionalSecrets, required.AdditionalSecrets)
 	redirectsSame := equality.Semantic.DeepEqual(existing.RedirectURIs, required.RedirectURIs)
+	tokenPolicySame := equality.Semantic.DeepEqual(existing.ScopeRestrictions, required.ScopeRestrictions) &&
+		equality.Semantic.DeepEqual(existing.AccessTokenMaxAgeSeconds, required.AccessTokenMaxAgeSeconds) &&
+		equality.Semantic.DeepEqual(existing.AccessTokenInactivityTimeoutSeconds, required.AccessTokenInactivityTimeoutSeconds)
 	// nothing changed, so don't update
-	if secretSame && redirectsSame && !*modified {
+	if secretSame && additionalSecretsSame && redirectsSame && tokenPolicySame && !*modified {
 		// per ApplyService, etc, if nothing changed, return nil.
 		return nil, false, nil
 	}
 	existing.Secret = required.Secret
-	// existing.AdditionalSecrets = required.AdditionalSecrets
+	existing.AdditionalSecrets = required.AdditionalSecrets
 	// existing.RespondWithChallenges = required.RespondWithChallenges
 	existing.RedirectURIs = required.RedirectURIs
 	// existing.GrantMethod = required.GrantMethod
-	// existing.ScopeRestrictions = required.ScopeRestrictions
-	// existing.AccessTokenMaxAgeSeconds = required.AccessTokenMaxAgeSeconds
-	// existing.AccessTokenInactivityTimeoutSeconds = required.AccessTokenInactivityTimeoutSeconds
+	existing.ScopeRestrictions = required.ScopeRestrictions
+	existing.AccessTokenMaxAgeSeconds = required.AccessTokenMaxAgeSeconds
+	existing.AccessTokenInactivityTimeoutSeconds = required.AccessTokenInactivityTimeoutSeconds
 	actual, err := client.OAuthClients().Update(ctx, existing, metav1.UpdateOptions{})
 	return actual, true, err
 }
 
 // registers the console on the oauth client as a valid application
-func RegisterConsoleToOAuthClient(client *oauthv1.OAuthClient, host string, randomBits string) *oauthv1.OAuthClient {
-	SetRedirectURI(client, host)
-	// client.Secret = randomBits
-	SetSecretString(client, randomBits)
+// hosts are the hostnames the console is reached at
+// rolledOut tells whether every console pod runs with the given secret
+func RegisterConsoleToOAuthClient(client *oauthv1.OAuthClient, hosts []string, randomBits string, rolledOut bool) *oauthv1.OAuthClient {
+	SetRedirectURIs(client, hosts)
+	RotateSecretString(client, randomBits, rolledOut)
 	return client
 }
 
@@ -68,6 +82,7 @@ func DeRegisterConsoleFromOAuthClient(client *oauthv1.OAuthClient) *oauthv1.OAut
 	client.RedirectURIs = []string{}
 	// changing the string to anything else will invalidate the client
 	client.Secret = crypto.Random256BitsString()
+	client.AdditionalSecrets = nil
 	return client
 }
 
@@ -93,12 +108,37 @@ func SetSecretString(client *oauthv1.OAuthClient, randomBits string) *oauthv1.OA
 	return client
 }
 
+// RotateSecretString sets the secret of the client, keeping the secrets it
+// replaces valid as additional secrets until the console runs with the new
+// one, so that no console pod is left with a secret the server rejects.
+func RotateSecretString(client *oauthv1.OAuthClient, randomBits string, rolledOut bool) *oauthv1.OAuthClient {
+	switch {
+	case rolledOut:
+		client.AdditionalSecrets = nil
+	case len(client.Secret) > 0 && client.Secret != randomBits && !slices.Contains(client.AdditionalSecrets, client.Secret):
+		client.AdditionalSecrets = append(client.AdditionalSecrets, client.Secret)
+	}
+	client.Secret = randomBits
+	return client
+}
+
 // we are the only application for this client
-// in the future we may accept multiple routes
-// for now, we can clobber the slice & reset the entire thing
+// we can clobber the slice & reset the entire thing
 func SetRedirectURI(client *oauthv1.OAuthClient, host string) *oauthv1.OAuthClient {
+	return SetRedirectURIs(client, []string{host})
+}
+
+// SetRedirectURIs registers the callback of every hostname the console is
+// reached at, so that logins started from any of them succeed.
+func SetRedirectURIs(client *oauthv1.OAuthClient, hosts []string) *oauthv1.OAuthClient {
 	client.RedirectURIs = []string{}
-	client.RedirectURIs = append(client.RedirectURIs, util.HTTPS(host)+"/auth/callback")
+	for _, host := range hosts {
+		redirectURI := util.HTTPS(host) + "/auth/callback"
+		if len(host) == 0 || slices.Contains(client.RedirectURIs, redirectURI) {
+			continue
+		}
+		client.RedirectURIs = append(client.RedirectURIs, redirectURI)
+	}
 	return client
 }
 
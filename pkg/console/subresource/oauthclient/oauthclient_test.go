@@ -133,3 +133,108 @@ func TestSetRedirectURI(t *testing.T) {
 		})
 	}
 }
+
+func TestRotateSecretString(t *testing.T) {
+	type args struct {
+		client     *oauthv1.OAuthClient
+		randomBits string
+		rolledOut  bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want *oauthv1.OAuthClient
+	}{
+		{
+			name: "Test first secret",
+			args: args{
+				client:     &oauthv1.OAuthClient{},
+				randomBits: "secret1",
+			},
+			want: &oauthv1.OAuthClient{
+				Secret: "secret1",
+			},
+		},
+		{
+			name: "Test rotated secret not rolled out",
+			args: args{
+				client:     &oauthv1.OAuthClient{Secret: "secret1"},
+				randomBits: "secret2",
+			},
+			want: &oauthv1.OAuthClient{
+				Secret:            "secret2",
+				AdditionalSecrets: []string{"secret1"},
+			},
+		},
+		{
+			name: "Test secret rotated again before rolling out",
+			args: args{
+				client:     &oauthv1.OAuthClient{Secret: "secret2", AdditionalSecrets: []string{"secret1"}},
+				randomBits: "secret3",
+			},
+			want: &oauthv1.OAuthClient{
+				Secret:            "secret3",
+				AdditionalSecrets: []string{"secret1", "secret2"},
+			},
+		},
+		{
+			name: "Test rotated secret still rolling out",
+			args: args{
+				client:     &oauthv1.OAuthClient{Secret: "secret2", AdditionalSecrets: []string{"secret1"}},
+				randomBits: "secret2",
+			},
+			want: &oauthv1.OAuthClient{
+				Secret:            "secret2",
+				AdditionalSecrets: []string{"secret1"},
+			},
+		},
+		{
+			name: "Test rotated secret rolled out",
+			args: args{
+				client:     &oauthv1.OAuthClient{Secret: "secret2", AdditionalSecrets: []string{"secret1"}},
+				randomBits: "secret2",
+				rolledOut:  true,
+			},
+			want: &oauthv1.OAuthClient{
+				Secret: "secret2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(RotateSecretString(tt.args.client, tt.args.randomBits, tt.args.rolledOut), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestSetRedirectURIs(t *testing.T) {
+	tests := []struct {
+		name  string
+		hosts []string
+		want  []string
+	}{
+		{
+			name:  "Test default and custom hostnames",
+			hosts: []string{"console-openshift-console.apps.example.com", "console.example.com"},
+			want: []string{
+				"https://console-openshift-console.apps.example.com/auth/callback",
+				"https://console.example.com/auth/callback",
+			},
+		},
+		{
+			name:  "Test duplicate and empty hostnames",
+			hosts: []string{"https://console.example.com", "console.example.com", ""},
+			want:  []string{"https://console.example.com/auth/callback"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &oauthv1.OAuthClient{RedirectURIs: []string{"https://stale.example.com/auth/callback"}}
+			if diff := deep.Equal(SetRedirectURIs(client, tt.hosts).RedirectURIs, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
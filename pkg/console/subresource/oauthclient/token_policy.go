@@ -0,0 +1,136 @@
+package oauthclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+// the OAuth server rejects shorter inactivity timeouts, 0 disables the timeout
+const minAccessTokenInactivityTimeout = 5 * time.Minute
+
+// the fields of the token policy, as listed by api.OAuthClientTokenPolicyFieldsAnnotation
+const (
+	accessTokenMaxAgeSecondsField            = "accessTokenMaxAgeSeconds"
+	accessTokenInactivityTimeoutSecondsField = "accessTokenInactivityTimeoutSeconds"
+	scopeRestrictionsField                   = "scopeRestrictions"
+)
+
+// TokenPolicy holds the token lifetimes and the scope restrictions of the
+// console OAuthClient declared on the operator config. Nil fields are not
+// declared: the OAuthClient is created only once, admins may have set them on
+// it by hand and those values are left as they are, unless the operator set
+// them while they were declared.
+type TokenPolicy struct {
+	AccessTokenMaxAgeSeconds            *int32
+	AccessTokenInactivityTimeoutSeconds *int32
+	ScopeRestrictions                   *[]oauthv1.ScopeRestriction
+}
+
+// GetTokenPolicy returns the token policy of the console OAuthClient declared
+// on the operator config.
+func GetTokenPolicy(operatorConfig *operatorv1.Console) (*TokenPolicy, error) {
+	annotations := operatorConfig.GetAnnotations()
+	policy := &TokenPolicy{}
+
+	maxAge, err := getSeconds(annotations, api.OAuthAccessTokenMaxAgeAnnotation)
+	if err != nil {
+		return nil, err
+	}
+	policy.AccessTokenMaxAgeSeconds = maxAge
+
+	inactivityTimeout, err := getSeconds(annotations, api.OAuthAccessTokenInactivityTimeoutAnnotation)
+	if err != nil {
+		return nil, err
+	}
+	if inactivityTimeout != nil && *inactivityTimeout != 0 && time.Duration(*inactivityTimeout)*time.Second < minAccessTokenInactivityTimeout {
+		return nil, fmt.Errorf("invalid %s annotation %q: must be 0 or at least %s", api.OAuthAccessTokenInactivityTimeoutAnnotation, annotations[api.OAuthAccessTokenInactivityTimeoutAnnotation], minAccessTokenInactivityTimeout)
+	}
+	policy.AccessTokenInactivityTimeoutSeconds = inactivityTimeout
+
+	if value := annotations[api.OAuthScopeRestrictionsAnnotation]; len(value) > 0 {
+		scopeRestrictions := []oauthv1.ScopeRestriction{}
+		decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&scopeRestrictions); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", api.OAuthScopeRestrictionsAnnotation, err)
+		}
+		policy.ScopeRestrictions = &scopeRestrictions
+		for i, restriction := range scopeRestrictions {
+			if (len(restriction.ExactValues) > 0) == (restriction.ClusterRole != nil) {
+				return nil, fmt.Errorf("invalid %s annotation: restriction %d must set exactly one of literals and clusterRole", api.OAuthScopeRestrictionsAnnotation, i)
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// SetTokenPolicy sets the declared fields of the token policy on the client and
+// records them on its api.OAuthClientTokenPolicyFieldsAnnotation. The fields it
+// recorded before which are no longer declared are unset, the others are left
+// as they are.
+func SetTokenPolicy(client *oauthv1.OAuthClient, policy *TokenPolicy) *oauthv1.OAuthClient {
+	previous := sets.NewString()
+	if value := client.Annotations[api.OAuthClientTokenPolicyFieldsAnnotation]; len(value) > 0 {
+		previous.Insert(strings.Split(value, ",")...)
+	}
+	declared := sets.NewString()
+
+	if policy.AccessTokenMaxAgeSeconds != nil {
+		client.AccessTokenMaxAgeSeconds = policy.AccessTokenMaxAgeSeconds
+		declared.Insert(accessTokenMaxAgeSecondsField)
+	} else if previous.Has(accessTokenMaxAgeSecondsField) {
+		client.AccessTokenMaxAgeSeconds = nil
+	}
+	if policy.AccessTokenInactivityTimeoutSeconds != nil {
+		client.AccessTokenInactivityTimeoutSeconds = policy.AccessTokenInactivityTimeoutSeconds
+		declared.Insert(accessTokenInactivityTimeoutSecondsField)
+	} else if previous.Has(accessTokenInactivityTimeoutSecondsField) {
+		client.AccessTokenInactivityTimeoutSeconds = nil
+	}
+	if policy.ScopeRestrictions != nil {
+		client.ScopeRestrictions = *policy.ScopeRestrictions
+		declared.Insert(scopeRestrictionsField)
+	} else if previous.Has(scopeRestrictionsField) {
+		client.ScopeRestrictions = nil
+	}
+
+	if declared.Len() == 0 {
+		delete(client.Annotations, api.OAuthClientTokenPolicyFieldsAnnotation)
+		return client
+	}
+	if client.Annotations == nil {
+		client.Annotations = map[string]string{}
+	}
+	client.Annotations[api.OAuthClientTokenPolicyFieldsAnnotation] = strings.Join(declared.List(), ",")
+	return client
+}
+
+// getSeconds returns the whole seconds of the duration set by the annotation,
+// or nothing if it is not set.
+func getSeconds(annotations map[string]string, annotation string) (*int32, error) {
+	value, ok := annotations[annotation]
+	if !ok || len(value) == 0 {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation %q: %w", annotation, value, err)
+	}
+	if duration < 0 || duration.Seconds() > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid %s annotation %q: must be between 0 and %d seconds", annotation, value, math.MaxInt32)
+	}
+	seconds := int32(duration / time.Second)
+	return &seconds, nil
+}
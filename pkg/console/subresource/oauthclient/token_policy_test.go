@@ -0,0 +1,223 @@
+package oauthclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/client-go/oauth/clientset/versioned/fake"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+func TestGetTokenPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *TokenPolicy
+		wantErr     bool
+	}{
+		{
+			name: "Test no token policy",
+			want: &TokenPolicy{},
+		},
+		{
+			name: "Test token policy",
+			annotations: map[string]string{
+				api.OAuthAccessTokenMaxAgeAnnotation:            "24h",
+				api.OAuthAccessTokenInactivityTimeoutAnnotation: "15m",
+				api.OAuthScopeRestrictionsAnnotation:            `[{"literals": ["user:full"]}, {"clusterRole": {"roleNames": ["view"], "namespaces": ["*"], "allowEscalation": false}}]`,
+			},
+			want: &TokenPolicy{
+				AccessTokenMaxAgeSeconds:            ptr.To[int32](86400),
+				AccessTokenInactivityTimeoutSeconds: ptr.To[int32](900),
+				ScopeRestrictions: &[]oauthv1.ScopeRestriction{
+					{ExactValues: []string{"user:full"}},
+					{ClusterRole: &oauthv1.ClusterRoleScopeRestriction{RoleNames: []string{"view"}, Namespaces: []string{"*"}}},
+				},
+			},
+		},
+		{
+			name:        "Test disabled inactivity timeout",
+			annotations: map[string]string{api.OAuthAccessTokenInactivityTimeoutAnnotation: "0s"},
+			want:        &TokenPolicy{AccessTokenInactivityTimeoutSeconds: ptr.To[int32](0)},
+		},
+		{
+			name:        "Test inactivity timeout shorter than allowed",
+			annotations: map[string]string{api.OAuthAccessTokenInactivityTimeoutAnnotation: "1m"},
+			wantErr:     true,
+		},
+		{
+			name:        "Test invalid max age",
+			annotations: map[string]string{api.OAuthAccessTokenMaxAgeAnnotation: "one day"},
+			wantErr:     true,
+		},
+		{
+			name:        "Test negative max age",
+			annotations: map[string]string{api.OAuthAccessTokenMaxAgeAnnotation: "-1h"},
+			wantErr:     true,
+		},
+		{
+			name:        "Test scope restriction with unknown fields",
+			annotations: map[string]string{api.OAuthScopeRestrictionsAnnotation: `[{"exactValues": ["user:full"]}]`},
+			wantErr:     true,
+		},
+		{
+			name:        "Test empty scope restriction",
+			annotations: map[string]string{api.OAuthScopeRestrictionsAnnotation: `[{}]`},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got, err := GetTokenPolicy(operatorConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetTokenPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestApplyTokenPolicyOnUpgrade(t *testing.T) {
+	handSet := func() *oauthv1.OAuthClient {
+		return &oauthv1.OAuthClient{
+			ObjectMeta:                          metav1.ObjectMeta{Name: api.OAuthClientName},
+			Secret:                              "secret",
+			RedirectURIs:                        []string{"https://console.example.com/auth/callback"},
+			AccessTokenMaxAgeSeconds:            ptr.To[int32](3600),
+			AccessTokenInactivityTimeoutSeconds: ptr.To[int32](600),
+			ScopeRestrictions:                   []oauthv1.ScopeRestriction{{ExactValues: []string{"user:full"}}},
+		}
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *oauthv1.OAuthClient
+	}{
+		{
+			name: "Test hand-set token policy kept without a declared one",
+			want: handSet(),
+		},
+		{
+			name:        "Test declared max age replaces the hand-set one only",
+			annotations: map[string]string{api.OAuthAccessTokenMaxAgeAnnotation: "24h"},
+			want: func() *oauthv1.OAuthClient {
+				client := handSet()
+				client.AccessTokenMaxAgeSeconds = ptr.To[int32](86400)
+				return client
+			}(),
+		},
+		{
+			name:        "Test declared empty scope restrictions replace the hand-set ones",
+			annotations: map[string]string{api.OAuthScopeRestrictionsAnnotation: "[]"},
+			want: func() *oauthv1.OAuthClient {
+				client := handSet()
+				client.ScopeRestrictions = []oauthv1.ScopeRestriction{}
+				return client
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(handSet())
+			policy, err := GetTokenPolicy(&operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}})
+			if err != nil {
+				t.Fatalf("GetTokenPolicy() error = %v", err)
+			}
+
+			required := RegisterConsoleToOAuthClient(handSet(), []string{"console.example.com"}, "secret", true)
+			SetTokenPolicy(required, policy)
+			if _, _, err := CustomApplyOAuth(client.OauthV1(), required, context.TODO()); err != nil {
+				t.Fatalf("CustomApplyOAuth() error = %v", err)
+			}
+
+			got, err := client.OauthV1().OAuthClients().Get(context.TODO(), api.OAuthClientName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the token policy and the fields the console registers, the metadata is merged
+			if diff := deep.Equal(got.RedirectURIs, tt.want.RedirectURIs); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(got.AccessTokenMaxAgeSeconds, tt.want.AccessTokenMaxAgeSeconds); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(got.AccessTokenInactivityTimeoutSeconds, tt.want.AccessTokenInactivityTimeoutSeconds); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(got.ScopeRestrictions, tt.want.ScopeRestrictions); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestRemoveTokenPolicy(t *testing.T) {
+	// the inactivity timeout is set by hand, the operator never declared it
+	client := fake.NewSimpleClientset(&oauthv1.OAuthClient{
+		ObjectMeta:                          metav1.ObjectMeta{Name: api.OAuthClientName},
+		Secret:                              "secret",
+		AccessTokenInactivityTimeoutSeconds: ptr.To[int32](600),
+	})
+	apply := func(annotations map[string]string) *oauthv1.OAuthClient {
+		policy, err := GetTokenPolicy(&operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}})
+		if err != nil {
+			t.Fatalf("GetTokenPolicy() error = %v", err)
+		}
+		existing, err := client.OauthV1().OAuthClients().Get(context.TODO(), api.OAuthClientName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		required := RegisterConsoleToOAuthClient(existing.DeepCopy(), []string{"console.example.com"}, "secret", true)
+		SetTokenPolicy(required, policy)
+		if _, _, err := CustomApplyOAuth(client.OauthV1(), required, context.TODO()); err != nil {
+			t.Fatalf("CustomApplyOAuth() error = %v", err)
+		}
+		got, err := client.OauthV1().OAuthClients().Get(context.TODO(), api.OAuthClientName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	got := apply(map[string]string{
+		api.OAuthAccessTokenMaxAgeAnnotation: "24h",
+		api.OAuthScopeRestrictionsAnnotation: `[{"literals": ["user:full"]}]`,
+	})
+	if diff := deep.Equal(got.Annotations[api.OAuthClientTokenPolicyFieldsAnnotation], "accessTokenMaxAgeSeconds,scopeRestrictions"); diff != nil {
+		t.Error(diff)
+	}
+
+	// the scope restrictions are no longer declared
+	got = apply(map[string]string{api.OAuthAccessTokenMaxAgeAnnotation: "24h"})
+	if diff := deep.Equal(got.AccessTokenMaxAgeSeconds, ptr.To[int32](86400)); diff != nil {
+		t.Error(diff)
+	}
+	if got.ScopeRestrictions != nil {
+		t.Errorf("expected the scope restrictions to be unset, got: %v", got.ScopeRestrictions)
+	}
+	if diff := deep.Equal(got.Annotations[api.OAuthClientTokenPolicyFieldsAnnotation], "accessTokenMaxAgeSeconds"); diff != nil {
+		t.Error(diff)
+	}
+
+	// nothing is declared any more
+	got = apply(nil)
+	if got.AccessTokenMaxAgeSeconds != nil {
+		t.Errorf("expected the max age to be unset, got: %d", *got.AccessTokenMaxAgeSeconds)
+	}
+	if _, ok := got.Annotations[api.OAuthClientTokenPolicyFieldsAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed", api.OAuthClientTokenPolicyFieldsAnnotation)
+	}
+	if diff := deep.Equal(got.AccessTokenInactivityTimeoutSeconds, ptr.To[int32](600)); diff != nil {
+		t.Errorf("expected the hand-set inactivity timeout to be kept: %v", diff)
+	}
+}
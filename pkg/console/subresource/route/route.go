@@ -6,6 +6,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	// kube
@@ -13,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -178,6 +180,34 @@ func GetDefaultRouteHost(routeName string, ingressConfig *configv1.Ingress) stri
 	return fmt.Sprintf("%s-%s.%s", routeName, api.OpenShiftConsoleNamespace, ingressConfig.Spec.Domain)
 }
 
+// GetHostnameAliases returns the further hostnames the console is reached at,
+// set on the operator config.
+func GetHostnameAliases(operatorConfig *operatorv1.Console) ([]string, error) {
+	value := operatorConfig.GetAnnotations()[api.HostnameAliasesAnnotation]
+	aliases := []string{}
+	for _, alias := range strings.Split(value, ",") {
+		alias = strings.TrimSpace(alias)
+		if len(alias) == 0 {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(alias); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid %s annotation, %q is not a valid hostname: %s", api.HostnameAliasesAnnotation, alias, strings.Join(errs, ", "))
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, nil
+}
+
+// GetConsoleHostnames returns every hostname the console is reached at: the
+// default route, the custom route when one is set and the aliases.
+func (rc *RouteConfig) GetConsoleHostnames(aliases []string) []string {
+	hostnames := []string{rc.defaultRoute.hostname}
+	if rc.IsCustomHostnameSet() {
+		hostnames = append(hostnames, rc.customRoute.hostname)
+	}
+	return append(hostnames, aliases...)
+}
+
 func ApplyRoute(client routeclient.RoutesGetter, required *routev1.Route) (*routev1.Route, bool, error) {
 	existing, err := client.Routes(required.Namespace).Get(context.TODO(), required.Name, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
@@ -5,7 +5,12 @@ import (
 
 	"github.com/go-test/deep"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
 )
 
 func TestGetDefaultRouteHost(t *testing.T) {
@@ -39,3 +44,93 @@ func TestGetDefaultRouteHost(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConsoleHostnames(t *testing.T) {
+	ingressConfig := &configv1.Ingress{
+		Spec: configv1.IngressSpec{
+			Domain: "apps.devcluster.openshift.com",
+		},
+	}
+	tests := []struct {
+		name           string
+		operatorConfig *operatorv1.Console
+		ingressConfig  *configv1.Ingress
+		aliases        []string
+		want           []string
+	}{
+		{
+			name:           "Test default route",
+			operatorConfig: &operatorv1.Console{},
+			ingressConfig:  ingressConfig,
+			want:           []string{"console-openshift-console.apps.devcluster.openshift.com"},
+		},
+		{
+			name:           "Test custom route from the operator config",
+			operatorConfig: &operatorv1.Console{Spec: operatorv1.ConsoleSpec{Route: operatorv1.ConsoleConfigRoute{Hostname: "console.example.com"}}},
+			ingressConfig:  ingressConfig,
+			want:           []string{"console-openshift-console.apps.devcluster.openshift.com", "console.example.com"},
+		},
+		{
+			name:           "Test custom route from the componentRoutes with aliases",
+			operatorConfig: &operatorv1.Console{},
+			ingressConfig: &configv1.Ingress{
+				Spec: configv1.IngressSpec{
+					Domain: "apps.devcluster.openshift.com",
+					ComponentRoutes: []configv1.ComponentRouteSpec{
+						{
+							Name:      api.OpenShiftConsoleRouteName,
+							Namespace: api.OpenShiftConsoleNamespace,
+							Hostname:  "console.example.com",
+						},
+					},
+				},
+			},
+			aliases: []string{"console.lb.example.com"},
+			want:    []string{"console-openshift-console.apps.devcluster.openshift.com", "console.example.com", "console.lb.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routeConfig := NewRouteConfig(tt.operatorConfig, tt.ingressConfig, api.OpenShiftConsoleRouteName)
+			if diff := deep.Equal(routeConfig.GetConsoleHostnames(tt.aliases), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGetHostnameAliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name: "Test no aliases",
+			want: []string{},
+		},
+		{
+			name:        "Test aliases",
+			annotations: map[string]string{api.HostnameAliasesAnnotation: "console.lb.example.com, console.example.org,"},
+			want:        []string{"console.lb.example.com", "console.example.org"},
+		},
+		{
+			name:        "Test invalid alias",
+			annotations: map[string]string{api.HostnameAliasesAnnotation: "https://console.example.com"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got, err := GetHostnameAliases(operatorConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetHostnameAliases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package secret
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+// GetRotationInterval returns the interval of the scheduled rotations of a
+// secret set by the given annotation of the operator config, or nothing if the
+// secret is only rotated on demand.
+func GetRotationInterval(operatorConfig *operatorv1.Console, annotation string, minInterval time.Duration) (time.Duration, error) {
+	value, ok := operatorConfig.GetAnnotations()[annotation]
+	if !ok || len(value) == 0 {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation %q: %w", annotation, value, err)
+	}
+	if interval < minInterval {
+		return 0, fmt.Errorf("invalid %s annotation %q: must be at least %s", annotation, value, minInterval)
+	}
+	return interval, nil
+}
+
+// RotationDue returns why the secret has to be rotated, if it has to: on
+// demand when the request token differs from the last one it handled, or on
+// schedule. Otherwise it returns when its next scheduled rotation is due, if any.
+func RotationDue(secret *corev1.Secret, request string, interval time.Duration, now time.Time) (string, time.Time) {
+	if len(request) > 0 && request != secret.GetAnnotations()[api.SecretRotationRequestAnnotation] {
+		return "requested", time.Time{}
+	}
+	if interval == 0 {
+		return "", time.Time{}
+	}
+	if next := GetRotatedAt(secret).Add(interval); now.Before(next) {
+		return "", next
+	}
+	return "scheduled", time.Time{}
+}
+
+// SetRotated records on the secret that it was rotated at the given time,
+// handling the given request token.
+func SetRotated(secret *corev1.Secret, request string, now time.Time) *corev1.Secret {
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, api.SecretRotatedAtAnnotation, now.UTC().Format(time.RFC3339))
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, api.SecretRotationRequestAnnotation, request)
+	return secret
+}
+
+// GetRotatedAt returns when the secret was last rotated. Secrets written before
+// rotations were recorded count from their creation.
+func GetRotatedAt(secret *corev1.Secret) time.Time {
+	rotatedAt, err := time.Parse(time.RFC3339, secret.GetAnnotations()[api.SecretRotatedAtAnnotation])
+	if err != nil {
+		return secret.CreationTimestamp.Time
+	}
+	return rotatedAt
+}
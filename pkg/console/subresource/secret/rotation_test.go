@@ -0,0 +1,117 @@
+package secret
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+func TestGetRotationInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        time.Duration
+		wantErr     bool
+	}{
+		{
+			name: "Test no rotation interval",
+			want: 0,
+		},
+		{
+			name:        "Test rotation interval",
+			annotations: map[string]string{api.OAuthClientSecretRotationIntervalAnnotation: "720h"},
+			want:        720 * time.Hour,
+		},
+		{
+			name:        "Test invalid rotation interval",
+			annotations: map[string]string{api.OAuthClientSecretRotationIntervalAnnotation: "monthly"},
+			wantErr:     true,
+		},
+		{
+			name:        "Test rotation interval shorter than allowed",
+			annotations: map[string]string{api.OAuthClientSecretRotationIntervalAnnotation: "5m"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.Console{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got, err := GetRotationInterval(operatorConfig, api.OAuthClientSecretRotationIntervalAnnotation, time.Hour)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRotationInterval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetRotationInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotationDue(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	secret := func(annotations map[string]string) *corev1.Secret {
+		s := Stub()
+		s.Annotations = annotations
+		s.CreationTimestamp = metav1.NewTime(now.Add(-48 * time.Hour))
+		return s
+	}
+
+	tests := []struct {
+		name               string
+		secret             *corev1.Secret
+		request            string
+		interval           time.Duration
+		wantReason         string
+		wantNextRotationAt time.Time
+	}{
+		{
+			name:   "Test no rotation",
+			secret: secret(nil),
+		},
+		{
+			name:       "Test requested rotation",
+			secret:     secret(nil),
+			request:    "1",
+			wantReason: "requested",
+		},
+		{
+			name:    "Test handled rotation request",
+			secret:  secret(map[string]string{api.SecretRotationRequestAnnotation: "1"}),
+			request: "1",
+		},
+		{
+			name:               "Test scheduled rotation not due",
+			secret:             SetRotated(secret(nil), "", now.Add(-time.Hour)),
+			interval:           24 * time.Hour,
+			wantNextRotationAt: now.Add(23 * time.Hour),
+		},
+		{
+			name:       "Test scheduled rotation due",
+			secret:     SetRotated(secret(nil), "", now.Add(-25*time.Hour)),
+			interval:   24 * time.Hour,
+			wantReason: "scheduled",
+		},
+		{
+			name:       "Test scheduled rotation due since the creation of the secret",
+			secret:     secret(nil),
+			interval:   24 * time.Hour,
+			wantReason: "scheduled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, nextRotationAt := RotationDue(tt.secret, tt.request, tt.interval, now)
+			if reason != tt.wantReason {
+				t.Errorf("RotationDue() reason = %q, want %q", reason, tt.wantReason)
+			}
+			if !nextRotationAt.Equal(tt.wantNextRotationAt) {
+				t.Errorf("RotationDue() next rotation = %v, want %v", nextRotationAt, tt.wantNextRotationAt)
+			}
+		})
+	}
+}
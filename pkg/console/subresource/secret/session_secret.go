@@ -1,9 +1,11 @@
 package secret
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -25,6 +27,16 @@ func DefaultSessionSecret(cr *operatorv1.Console) *corev1.Secret {
 	return secret
 }
 
+const (
+	sessionEncryptionKey             = "sessionEncryptionKey"
+	sessionAuthenticationKey         = "sessionAuthenticationKey"
+	previousSessionEncryptionKey     = "previousSessionEncryptionKey"
+	previousSessionAuthenticationKey = "previousSessionAuthenticationKey"
+)
+
+// ResetSessionSecretKeysIfNeeded regenerates the session keys of the wrong
+// length. The previous pair of keys, which the console still accepts the
+// sessions of, is the current pair unless it was just rotated.
 func ResetSessionSecretKeysIfNeeded(secret *corev1.Secret) bool {
 	const (
 		sha256KeyLenBytes = sha256.BlockSize // max key size with HMAC SHA256
@@ -37,19 +49,68 @@ func ResetSessionSecretKeysIfNeeded(secret *corev1.Secret) bool {
 		secret.Data = map[string][]byte{}
 	}
 
-	if len(secret.Data["sessionEncryptionKey"]) != aes256KeyLenBytes {
-		secret.Data["sessionEncryptionKey"] = []byte(randomString(aes256KeyLenBytes))
+	if len(secret.Data[sessionEncryptionKey]) != aes256KeyLenBytes {
+		secret.Data[sessionEncryptionKey] = []byte(randomString(aes256KeyLenBytes))
+		changed = true
+	}
+
+	if len(secret.Data[sessionAuthenticationKey]) != sha256KeyLenBytes {
+		secret.Data[sessionAuthenticationKey] = []byte(randomString(sha256KeyLenBytes))
 		changed = true
 	}
 
-	if len(secret.Data["sessionAuthenticationKey"]) != sha256KeyLenBytes {
-		secret.Data["sessionAuthenticationKey"] = []byte(randomString(sha256KeyLenBytes))
+	if len(secret.Data[previousSessionEncryptionKey]) != aes256KeyLenBytes || len(secret.Data[previousSessionAuthenticationKey]) != sha256KeyLenBytes {
+		resetPreviousSessionSecretKeys(secret)
 		changed = true
 	}
 
 	return changed
 }
 
+// RotateSessionSecretKeys generates a new pair of session keys, keeping the
+// current pair as the previous one so that the sessions it secures stay valid.
+func RotateSessionSecretKeys(secret *corev1.Secret) *corev1.Secret {
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[previousSessionEncryptionKey] = secret.Data[sessionEncryptionKey]
+	secret.Data[previousSessionAuthenticationKey] = secret.Data[sessionAuthenticationKey]
+	delete(secret.Data, sessionEncryptionKey)
+	delete(secret.Data, sessionAuthenticationKey)
+	// the previous pair is reset as well if the current one was invalid
+	ResetSessionSecretKeysIfNeeded(secret)
+	return secret
+}
+
+// PreviousSessionSecretKeysExpireAt returns when the previous pair of session
+// keys expires, if it differs from the current one.
+func PreviousSessionSecretKeysExpireAt(secret *corev1.Secret, gracePeriod time.Duration) time.Time {
+	if bytes.Equal(secret.Data[previousSessionEncryptionKey], secret.Data[sessionEncryptionKey]) &&
+		bytes.Equal(secret.Data[previousSessionAuthenticationKey], secret.Data[sessionAuthenticationKey]) {
+		return time.Time{}
+	}
+	return GetRotatedAt(secret).Add(gracePeriod)
+}
+
+// ExpirePreviousSessionSecretKeys drops the previous pair of session keys once
+// the grace period since the last rotation has passed, and returns whether it
+// did.
+func ExpirePreviousSessionSecretKeys(secret *corev1.Secret, gracePeriod time.Duration, now time.Time) bool {
+	expiresAt := PreviousSessionSecretKeysExpireAt(secret, gracePeriod)
+	if expiresAt.IsZero() || now.Before(expiresAt) {
+		return false
+	}
+	resetPreviousSessionSecretKeys(secret)
+	return true
+}
+
+// the console reads both pairs of keys, the previous pair is the current one
+// when there is nothing to keep accepting
+func resetPreviousSessionSecretKeys(secret *corev1.Secret) {
+	secret.Data[previousSessionEncryptionKey] = secret.Data[sessionEncryptionKey]
+	secret.Data[previousSessionAuthenticationKey] = secret.Data[sessionAuthenticationKey]
+}
+
 // needs to be in lib-go
 func randomBytes(size int) []byte {
 	b := make([]byte, size)
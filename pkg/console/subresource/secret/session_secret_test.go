@@ -0,0 +1,76 @@
+package secret
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+)
+
+func TestRotateSessionSecretKeys(t *testing.T) {
+	secret := DefaultSessionSecret(&operatorv1.Console{})
+	encryptionKey := secret.Data[sessionEncryptionKey]
+	authenticationKey := secret.Data[sessionAuthenticationKey]
+	if !bytes.Equal(secret.Data[previousSessionEncryptionKey], encryptionKey) || !bytes.Equal(secret.Data[previousSessionAuthenticationKey], authenticationKey) {
+		t.Fatalf("DefaultSessionSecret() previous keys differ from the current keys")
+	}
+
+	RotateSessionSecretKeys(secret)
+	if bytes.Equal(secret.Data[sessionEncryptionKey], encryptionKey) || bytes.Equal(secret.Data[sessionAuthenticationKey], authenticationKey) {
+		t.Errorf("RotateSessionSecretKeys() kept the current keys")
+	}
+	if !bytes.Equal(secret.Data[previousSessionEncryptionKey], encryptionKey) || !bytes.Equal(secret.Data[previousSessionAuthenticationKey], authenticationKey) {
+		t.Errorf("RotateSessionSecretKeys() did not keep the replaced keys as the previous keys")
+	}
+	if ResetSessionSecretKeysIfNeeded(secret) {
+		t.Errorf("RotateSessionSecretKeys() generated keys of the wrong length")
+	}
+}
+
+func TestExpirePreviousSessionSecretKeys(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	gracePeriod := 24 * time.Hour
+	tests := []struct {
+		name        string
+		rotatedAt   time.Time
+		rotate      bool
+		wantExpired bool
+	}{
+		{
+			name:      "Test no previous keys",
+			rotatedAt: now.Add(-48 * time.Hour),
+		},
+		{
+			name:      "Test previous keys within the grace period",
+			rotatedAt: now.Add(-time.Hour),
+			rotate:    true,
+		},
+		{
+			name:        "Test previous keys past the grace period",
+			rotatedAt:   now.Add(-25 * time.Hour),
+			rotate:      true,
+			wantExpired: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := DefaultSessionSecret(&operatorv1.Console{})
+			if tt.rotate {
+				RotateSessionSecretKeys(secret)
+			}
+			SetRotated(secret, "", tt.rotatedAt)
+
+			if expired := ExpirePreviousSessionSecretKeys(secret, gracePeriod, now); expired != tt.wantExpired {
+				t.Errorf("ExpirePreviousSessionSecretKeys() = %v, want %v", expired, tt.wantExpired)
+			}
+			wantExpireAt := time.Time{}
+			if tt.rotate && !tt.wantExpired {
+				wantExpireAt = tt.rotatedAt.Add(gracePeriod)
+			}
+			if expireAt := PreviousSessionSecretKeysExpireAt(secret, gracePeriod); !expireAt.Equal(wantExpireAt) {
+				t.Errorf("PreviousSessionSecretKeysExpireAt() = %v, want %v", expireAt, wantExpireAt)
+			}
+		})
+	}
+}
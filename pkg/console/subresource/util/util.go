@@ -147,23 +147,48 @@ func RemoveDuplicateStr(strSlice []string) []string {
 	return list
 }
 
-func GetOIDCClientConfig(authnConfig *configv1.Authentication) (*configv1.OIDCProvider, *configv1.OIDCClientConfig) {
-	if len(authnConfig.Spec.OIDCProviders) == 0 {
-		return nil, nil
-	}
-
-	var clientIdx int
-	for i := 0; i < len(authnConfig.Spec.OIDCProviders); i++ {
-		clientIdx = slices.IndexFunc(authnConfig.Spec.OIDCProviders[i].OIDCClients, func(oc configv1.OIDCClientConfig) bool {
-			if oc.ComponentNamespace == api.TargetNamespace && oc.ComponentName == api.OpenShiftConsoleName {
-				return true
-			}
-			return false
+// GetOIDCClientConfig returns the OIDC provider that configures the console's
+// client, together with the client config. All the OIDC code paths must use this
+// provider, e.g. for its CA. It returns an error if more than one provider
+// configures the console's client, as there is no telling which one to use.
+func GetOIDCClientConfig(authnConfig *configv1.Authentication) (*configv1.OIDCProvider, *configv1.OIDCClientConfig, error) {
+	var (
+		oidcProvider  *configv1.OIDCProvider
+		clientConfig  *configv1.OIDCClientConfig
+		providerNames []string
+	)
+	for i := range authnConfig.Spec.OIDCProviders {
+		provider := &authnConfig.Spec.OIDCProviders[i]
+		clientIdx := slices.IndexFunc(provider.OIDCClients, func(oc configv1.OIDCClientConfig) bool {
+			return oc.ComponentNamespace == api.TargetNamespace && oc.ComponentName == api.OpenShiftConsoleName
 		})
-		if clientIdx != -1 {
-			return &authnConfig.Spec.OIDCProviders[i], &authnConfig.Spec.OIDCProviders[i].OIDCClients[clientIdx]
+		if clientIdx == -1 {
+			continue
 		}
+		if oidcProvider == nil {
+			oidcProvider, clientConfig = provider, &provider.OIDCClients[clientIdx]
+		}
+		providerNames = append(providerNames, provider.Name)
+	}
+
+	if len(providerNames) > 1 {
+		return nil, nil, fmt.Errorf("the %s/%s OIDC client is configured by %d OIDC providers (%s), exactly one provider must configure it",
+			api.TargetNamespace, api.OpenShiftConsoleName, len(providerNames), strings.Join(providerNames, ", "))
 	}
+	return oidcProvider, clientConfig, nil
+}
+
+// PreviousSessionKeysEnabled returns whether the admin declared, on the operator
+// config, that the console reads the previous session keys. Until it does, a
+// rotation of the session keys drops every console session, so the operator
+// neither rotates them nor points the console at the previous keys.
+func PreviousSessionKeysEnabled(operatorConfig *operatorv1.Console) bool {
+	return operatorConfig.GetAnnotations()[api.SessionSecretPreviousKeysAnnotation] == "true"
+}
 
-	return nil, nil
+// ServingTLSProfileEnabled returns whether the admin declared, on the operator
+// config, that the console reads the TLS settings of its serving config. Until
+// it does, the console keeps its own defaults whatever the cluster profile.
+func ServingTLSProfileEnabled(operatorConfig *operatorv1.Console) bool {
+	return operatorConfig.GetAnnotations()[api.ServingTLSProfileAnnotation] == "true"
 }
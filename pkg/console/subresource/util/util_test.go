@@ -7,6 +7,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/console-operator/pkg/api"
 )
@@ -196,3 +197,83 @@ func TestRemoveDuplicateStr(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOIDCClientConfig(t *testing.T) {
+	consoleClient := configv1.OIDCClientConfig{
+		ComponentName:      api.OpenShiftConsoleName,
+		ComponentNamespace: api.TargetNamespace,
+		ClientID:           "console-client",
+	}
+	otherClient := configv1.OIDCClientConfig{
+		ComponentName:      "cli",
+		ComponentNamespace: "openshift-cli",
+		ClientID:           "cli-client",
+	}
+	tests := []struct {
+		name         string
+		providers    []configv1.OIDCProvider
+		wantProvider string
+		wantClientID string
+		wantErr      bool
+	}{
+		{
+			name: "Test no OIDC providers",
+		},
+		{
+			name: "Test no provider configures the console client",
+			providers: []configv1.OIDCProvider{
+				{Name: "first", OIDCClients: []configv1.OIDCClientConfig{otherClient}},
+			},
+		},
+		{
+			name: "Test console client in the first provider",
+			providers: []configv1.OIDCProvider{
+				{Name: "first", OIDCClients: []configv1.OIDCClientConfig{otherClient, consoleClient}},
+				{Name: "second", OIDCClients: []configv1.OIDCClientConfig{otherClient}},
+			},
+			wantProvider: "first",
+			wantClientID: "console-client",
+		},
+		{
+			name: "Test console client in the second provider",
+			providers: []configv1.OIDCProvider{
+				{Name: "first", OIDCClients: []configv1.OIDCClientConfig{otherClient}},
+				{Name: "second", OIDCClients: []configv1.OIDCClientConfig{consoleClient}},
+			},
+			wantProvider: "second",
+			wantClientID: "console-client",
+		},
+		{
+			name: "Test console client in several providers",
+			providers: []configv1.OIDCProvider{
+				{Name: "first", OIDCClients: []configv1.OIDCClientConfig{consoleClient}},
+				{Name: "second", OIDCClients: []configv1.OIDCClientConfig{consoleClient}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authnConfig := &configv1.Authentication{
+				Spec: configv1.AuthenticationSpec{
+					Type:          configv1.AuthenticationTypeOIDC,
+					OIDCProviders: tt.providers,
+				},
+			}
+			provider, clientConfig, err := GetOIDCClientConfig(authnConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetOIDCClientConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotProvider, gotClientID string
+			if provider != nil {
+				gotProvider = provider.Name
+			}
+			if clientConfig != nil {
+				gotClientID = clientConfig.ClientID
+			}
+			if gotProvider != tt.wantProvider || gotClientID != tt.wantClientID {
+				t.Errorf("GetOIDCClientConfig() = %q, %q, want %q, %q", gotProvider, gotClientID, tt.wantProvider, tt.wantClientID)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package workload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	// kube
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	// openshift
+	"github.com/ghodss/yaml"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceread"
+
+	// operator
+	"github.com/openshift/console-operator/bindata"
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+var (
+	// labels selecting the pods of the deployments, they can't be overridden
+	reservedPodLabels = sets.NewString("app", "component")
+	// annotations tracking the inputs of the console pods, they can't be overridden
+	reservedPodAnnotationPrefix = "console.openshift.io/"
+
+	validTolerationOperators = sets.NewString(string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists))
+	validTolerationEffects   = sets.NewString(
+		"",
+		string(corev1.TaintEffectNoSchedule),
+		string(corev1.TaintEffectPreferNoSchedule),
+		string(corev1.TaintEffectNoExecute),
+	)
+
+	// resources of the containers the overrides are merged into
+	consoleDefaultResources   = defaultResources("assets/deployments/console-deployment.yaml")
+	downloadsDefaultResources = defaultResources("assets/deployments/downloads-deployment.yaml")
+)
+
+func defaultResources(deploymentAsset string) corev1.ResourceRequirements {
+	deployment := resourceread.ReadDeploymentV1OrDie(bindata.MustAsset(deploymentAsset))
+	return deployment.Spec.Template.Spec.Containers[0].Resources
+}
+
+// Config is the content of the console-workload-config ConfigMap in the
+// openshift-config namespace, where cluster admins set how the console and
+// downloads pods are scheduled and sized.
+//
+//	console:
+//	  nodeSelector:
+//	    node-role.kubernetes.io/infra: ""
+//	  tolerations:
+//	  - key: node-role.kubernetes.io/infra
+//	    operator: Exists
+//	    effect: NoSchedule
+//	  resources:
+//	    limits:
+//	      memory: 1Gi
+//	  podLabels:
+//	    team: console
+//	autoscaling:
+//	  minReplicas: 2
+//	  maxReplicas: 6
+//	  targetCPUUtilizationPercentage: 75
+type Config struct {
+	Console     *PodOverrides `json:"console,omitempty"`
+	Downloads   *PodOverrides `json:"downloads,omitempty"`
+	Autoscaling *Autoscaling  `json:"autoscaling,omitempty"`
+}
+
+// PodOverrides replace the node selector and the tolerations of the pods, are
+// merged into the resources of their container and add labels and annotations.
+type PodOverrides struct {
+	NodeSelector   map[string]string            `json:"nodeSelector,omitempty"`
+	Tolerations    []corev1.Toleration          `json:"tolerations,omitempty"`
+	Resources      *corev1.ResourceRequirements `json:"resources,omitempty"`
+	PodLabels      map[string]string            `json:"podLabels,omitempty"`
+	PodAnnotations map[string]string            `json:"podAnnotations,omitempty"`
+}
+
+// the sections of the workload config, each decoded and validated on its own so
+// that an invalid section only holds back the controllers which consume it
+const (
+	ConsoleSection     = "console"
+	DownloadsSection   = "downloads"
+	AutoscalingSection = "autoscaling"
+)
+
+var configSections = map[string]func(config *Config, sectionJSON []byte) error{
+	ConsoleSection: func(config *Config, sectionJSON []byte) error {
+		if err := decodeSection(ConsoleSection, sectionJSON, &config.Console); err != nil {
+			return err
+		}
+		return config.Console.validate(field.NewPath(ConsoleSection), consoleDefaultResources).ToAggregate()
+	},
+	DownloadsSection: func(config *Config, sectionJSON []byte) error {
+		if err := decodeSection(DownloadsSection, sectionJSON, &config.Downloads); err != nil {
+			return err
+		}
+		return config.Downloads.validate(field.NewPath(DownloadsSection), downloadsDefaultResources).ToAggregate()
+	},
+	AutoscalingSection: func(config *Config, sectionJSON []byte) error {
+		if err := decodeSection(AutoscalingSection, sectionJSON, &config.Autoscaling); err != nil {
+			return err
+		}
+		return config.Autoscaling.validate(field.NewPath(AutoscalingSection)).ToAggregate()
+	},
+}
+
+// GetConfig returns the given sections of the workload config, or all of them if
+// none is given, from the openshift-config ConfigMap lister. A missing ConfigMap
+// means there is nothing to override.
+func GetConfig(configNSConfigMapLister corev1listers.ConfigMapLister, sections ...string) (*Config, error) {
+	configMap, err := configNSConfigMapLister.ConfigMaps(api.OpenShiftConfigNamespace).Get(api.WorkloadConfigMapName)
+	if apierrors.IsNotFound(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	config, err := ParseConfig(configMap, sections...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s/%s configmap: %w", api.OpenShiftConfigNamespace, api.WorkloadConfigMapName, err)
+	}
+	return config, nil
+}
+
+// ParseConfig decodes and validates the given sections of the workload config, or
+// all of them if none is given. The other sections are left unset. A document
+// which does not parse or has unknown sections is invalid whatever the sections.
+func ParseConfig(configMap *corev1.ConfigMap, sections ...string) (*Config, error) {
+	config := &Config{}
+	if configMap == nil || len(configMap.Data[api.WorkloadConfigKey]) == 0 {
+		return config, nil
+	}
+
+	configJSON, err := yaml.YAMLToJSON([]byte(configMap.Data[api.WorkloadConfigKey]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", api.WorkloadConfigKey, err)
+	}
+	sectionsJSON := map[string]json.RawMessage{}
+	if err := json.Unmarshal(configJSON, &sectionsJSON); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", api.WorkloadConfigKey, err)
+	}
+	for name := range sectionsJSON {
+		if _, ok := configSections[name]; !ok {
+			return nil, fmt.Errorf("failed to decode %s: unknown section %q", api.WorkloadConfigKey, name)
+		}
+	}
+
+	if len(sections) == 0 {
+		sections = []string{ConsoleSection, DownloadsSection, AutoscalingSection}
+	}
+	errs := []error{}
+	for _, name := range sections {
+		sectionJSON, ok := sectionsJSON[name]
+		if !ok {
+			continue
+		}
+		if err := configSections[name](config, sectionJSON); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return config, nil
+}
+
+// decodeSection decodes a section of the workload config, rejecting unknown fields.
+func decodeSection(name string, sectionJSON []byte, into interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(sectionJSON))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(into); err != nil {
+		return fmt.Errorf("failed to decode the %s section of %s: %w", name, api.WorkloadConfigKey, err)
+	}
+	return nil
+}
+
+// Autoscaling lets a HorizontalPodAutoscaler manage the replicas of the console
+// deployment, instead of the fixed ones derived from the cluster topology.
+type Autoscaling struct {
+	MinReplicas                       int32  `json:"minReplicas"`
+	MaxReplicas                       int32  `json:"maxReplicas"`
+	TargetCPUUtilizationPercentage    *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+func (a *Autoscaling) validate(fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if a == nil {
+		return errs
+	}
+
+	if a.MinReplicas < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("minReplicas"), a.MinReplicas, "must be greater than or equal to 1"))
+	}
+	if a.MaxReplicas < a.MinReplicas {
+		errs = append(errs, field.Invalid(fldPath.Child("maxReplicas"), a.MaxReplicas, "must be greater than or equal to minReplicas"))
+	}
+	if a.TargetCPUUtilizationPercentage == nil && a.TargetMemoryUtilizationPercentage == nil {
+		errs = append(errs, field.Required(fldPath, "either targetCPUUtilizationPercentage or targetMemoryUtilizationPercentage must be set"))
+	}
+	if a.TargetCPUUtilizationPercentage != nil && *a.TargetCPUUtilizationPercentage < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("targetCPUUtilizationPercentage"), *a.TargetCPUUtilizationPercentage, "must be greater than or equal to 1"))
+	}
+	if a.TargetMemoryUtilizationPercentage != nil && *a.TargetMemoryUtilizationPercentage < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("targetMemoryUtilizationPercentage"), *a.TargetMemoryUtilizationPercentage, "must be greater than or equal to 1"))
+	}
+	return errs
+}
+
+func (o *PodOverrides) validate(fldPath *field.Path, defaultResources corev1.ResourceRequirements) field.ErrorList {
+	errs := field.ErrorList{}
+	if o == nil {
+		return errs
+	}
+
+	errs = append(errs, metav1validation.ValidateLabels(o.NodeSelector, fldPath.Child("nodeSelector"))...)
+	for i, toleration := range o.Tolerations {
+		errs = append(errs, validateToleration(toleration, fldPath.Child("tolerations").Index(i))...)
+	}
+	if o.Resources != nil {
+		errs = append(errs, validateResources(o.Resources, defaultResources, fldPath.Child("resources"))...)
+	}
+
+	errs = append(errs, metav1validation.ValidateLabels(o.PodLabels, fldPath.Child("podLabels"))...)
+	for key := range o.PodLabels {
+		if reservedPodLabels.Has(key) {
+			errs = append(errs, field.Forbidden(fldPath.Child("podLabels").Key(key), "the label selects the pods of the deployment"))
+		}
+	}
+	errs = append(errs, apivalidation.ValidateAnnotations(o.PodAnnotations, fldPath.Child("podAnnotations"))...)
+	for key := range o.PodAnnotations {
+		if strings.HasPrefix(key, reservedPodAnnotationPrefix) {
+			errs = append(errs, field.Forbidden(fldPath.Child("podAnnotations").Key(key), fmt.Sprintf("annotations prefixed with %q are managed by the operator", reservedPodAnnotationPrefix)))
+		}
+	}
+	return errs
+}
+
+func validateToleration(toleration corev1.Toleration, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(toleration.Key) > 0 {
+		errs = append(errs, metav1validation.ValidateLabelName(toleration.Key, fldPath.Child("key"))...)
+	}
+	operator := string(toleration.Operator)
+	switch {
+	case !validTolerationOperators.Has(operator) && len(operator) > 0:
+		errs = append(errs, field.NotSupported(fldPath.Child("operator"), operator, validTolerationOperators.List()))
+	case toleration.Operator == corev1.TolerationOpExists && len(toleration.Value) > 0:
+		errs = append(errs, field.Invalid(fldPath.Child("value"), toleration.Value, "must be empty when operator is Exists"))
+	case toleration.Operator != corev1.TolerationOpExists && len(toleration.Key) == 0:
+		errs = append(errs, field.Required(fldPath.Child("key"), "only an Exists operator can tolerate all taints"))
+	}
+	if !validTolerationEffects.Has(string(toleration.Effect)) {
+		errs = append(errs, field.NotSupported(fldPath.Child("effect"), toleration.Effect, validTolerationEffects.List()[1:]))
+	}
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		errs = append(errs, field.Invalid(fldPath.Child("tolerationSeconds"), *toleration.TolerationSeconds, "only applies to the NoExecute effect"))
+	}
+	return errs
+}
+
+// validateResources validates the overrides and the requirements they are merged
+// into, a limit may be set below the request it keeps the default of.
+func validateResources(resources *corev1.ResourceRequirements, defaults corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for name, quantity := range resources.Requests {
+		if quantity.Sign() < 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("requests").Key(string(name)), quantity.String(), "must not be negative"))
+		}
+	}
+	for name, quantity := range resources.Limits {
+		if quantity.Sign() < 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("limits").Key(string(name)), quantity.String(), "must not be negative"))
+		}
+	}
+
+	merged := MergeResources(defaults, resources)
+	for name, request := range merged.Requests {
+		limit, ok := merged.Limits[name]
+		if !ok || request.Cmp(limit) <= 0 {
+			continue
+		}
+		if _, overridden := resources.Requests[name]; overridden {
+			errs = append(errs, field.Invalid(fldPath.Child("requests").Key(string(name)), request.String(), fmt.Sprintf("must be less than or equal to the %s limit", name)))
+		} else {
+			errs = append(errs, field.Invalid(fldPath.Child("limits").Key(string(name)), limit.String(), fmt.Sprintf("must be greater than or equal to the default %s request of %s", name, request.String())))
+		}
+	}
+	return errs
+}
+
+// MergeResources returns the default requirements with the requests and the limits
+// of the overridden resources replaced, the others keep their defaults.
+func MergeResources(defaults corev1.ResourceRequirements, overrides *corev1.ResourceRequirements) corev1.ResourceRequirements {
+	merged := *defaults.DeepCopy()
+	if overrides == nil {
+		return merged
+	}
+	for name, quantity := range overrides.Requests {
+		if merged.Requests == nil {
+			merged.Requests = corev1.ResourceList{}
+		}
+		merged.Requests[name] = quantity
+	}
+	for name, quantity := range overrides.Limits {
+		if merged.Limits == nil {
+			merged.Limits = corev1.ResourceList{}
+		}
+		merged.Limits[name] = quantity
+	}
+	return merged
+}
@@ -0,0 +1,221 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/console-operator/pkg/api"
+)
+
+func TestParseConfig(t *testing.T) {
+	configMap := func(config string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{Data: map[string]string{api.WorkloadConfigKey: config}}
+	}
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		want      *Config
+		wantErr   bool
+	}{
+		{
+			name:      "Test missing config",
+			configMap: nil,
+			want:      &Config{},
+		},
+		{
+			name: "Test valid config",
+			configMap: configMap(`
+console:
+  nodeSelector:
+    node-role.kubernetes.io/infra: ""
+  tolerations:
+  - key: node-role.kubernetes.io/infra
+    operator: Exists
+    effect: NoSchedule
+  resources:
+    requests:
+      memory: 200Mi
+    limits:
+      memory: 1Gi
+downloads:
+  podLabels:
+    team: console
+autoscaling:
+  minReplicas: 2
+  maxReplicas: 6
+  targetCPUUtilizationPercentage: 75
+`),
+			want: &Config{
+				Console: &PodOverrides{
+					NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+					Tolerations: []corev1.Toleration{{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("200Mi")},
+						Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				},
+				Downloads: &PodOverrides{
+					PodLabels: map[string]string{"team": "console"},
+				},
+				Autoscaling: &Autoscaling{
+					MinReplicas:                    2,
+					MaxReplicas:                    6,
+					TargetCPUUtilizationPercentage: ptr.To[int32](75),
+				},
+			},
+		},
+		{
+			name:      "Test unknown field",
+			configMap: configMap("console:\n  replicas: 3\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test reserved pod label",
+			configMap: configMap("downloads:\n  podLabels:\n    app: other\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test reserved pod annotation",
+			configMap: configMap("console:\n  podAnnotations:\n    console.openshift.io/image: other\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test toleration with a value and the Exists operator",
+			configMap: configMap("console:\n  tolerations:\n  - key: infra\n    operator: Exists\n    value: \"true\"\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test autoscaling with min replicas above max replicas",
+			configMap: configMap("autoscaling:\n  minReplicas: 4\n  maxReplicas: 2\n  targetCPUUtilizationPercentage: 75\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test autoscaling without a target",
+			configMap: configMap("autoscaling:\n  minReplicas: 2\n  maxReplicas: 4\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test request above limit",
+			configMap: configMap("console:\n  resources:\n    requests:\n      cpu: 2\n    limits:\n      cpu: 1\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test limit below the default request",
+			configMap: configMap("console:\n  resources:\n    limits:\n      memory: 64Mi\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test downloads limit below the default request",
+			configMap: configMap("downloads:\n  resources:\n    limits:\n      memory: 32Mi\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "Test limit above the default request",
+			configMap: configMap("downloads:\n  resources:\n    limits:\n      memory: 64Mi\n"),
+			want: &Config{
+				Downloads: &PodOverrides{
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConfig(tt.configMap)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseConfigSections(t *testing.T) {
+	configMap := func(config string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{Data: map[string]string{api.WorkloadConfigKey: config}}
+	}
+	invalidDownloads := configMap(`
+downloads:
+  podLabels:
+    app: other
+autoscaling:
+  minReplicas: 2
+  maxReplicas: 6
+  targetCPUUtilizationPercentage: 75
+`)
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		sections  []string
+		want      *Config
+		wantErr   bool
+	}{
+		{
+			name:      "Test valid section next to an invalid one",
+			configMap: invalidDownloads,
+			sections:  []string{AutoscalingSection},
+			want: &Config{
+				Autoscaling: &Autoscaling{
+					MinReplicas:                    2,
+					MaxReplicas:                    6,
+					TargetCPUUtilizationPercentage: ptr.To[int32](75),
+				},
+			},
+		},
+		{
+			name:      "Test missing section next to an invalid one",
+			configMap: invalidDownloads,
+			sections:  []string{ConsoleSection},
+			want:      &Config{},
+		},
+		{
+			name:      "Test invalid section",
+			configMap: invalidDownloads,
+			sections:  []string{DownloadsSection},
+			wantErr:   true,
+		},
+		{
+			name:      "Test every section",
+			configMap: invalidDownloads,
+			wantErr:   true,
+		},
+		{
+			name:      "Test section with an unknown field",
+			configMap: configMap("downloads:\n  replicas: 3\n"),
+			sections:  []string{DownloadsSection},
+			wantErr:   true,
+		},
+		{
+			name:      "Test unknown section",
+			configMap: configMap("consol:\n  podLabels:\n    team: console\n"),
+			sections:  []string{AutoscalingSection},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConfig(tt.configMap, tt.sections...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}